@@ -0,0 +1,75 @@
+package ns1
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// The package-level default client is a convenience for small scripts and
+// CLIs that talk to a single NS1 account. It is shared process-wide and
+// is not suitable for multi-tenant programs that need several API keys at
+// once; construct clients with rest.NewClient for those instead.
+var (
+	defaultMu     sync.Mutex
+	defaultKey    string
+	defaultClient *api.Client
+)
+
+const defaultTimeout = time.Second * 60
+
+// SetDefaultKey sets the API key used by the default client. The next call
+// to DefaultClient (or Zones, Records, ...) builds a fresh client with
+// this key. If no key is set, the NS1_APIKEY environment variable is used.
+func SetDefaultKey(k string) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultKey = k
+	defaultClient = nil
+}
+
+// SetDefaultClient replaces the default client, e.g. to use a custom
+// endpoint or http.Client. Passing nil resets it so the next call to
+// DefaultClient lazily builds a new one.
+func SetDefaultClient(c *api.Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	defaultClient = c
+}
+
+// DefaultClient returns the package-level client, creating it on first use.
+// It is safe to call from multiple goroutines.
+func DefaultClient() *api.Client {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if defaultClient == nil {
+		k := defaultKey
+		if k == "" {
+			k = os.Getenv("NS1_APIKEY")
+		}
+		httpClient := &http.Client{Timeout: defaultTimeout}
+		defaultClient = api.NewClient(httpClient, api.SetAPIKey(k))
+	}
+	return defaultClient
+}
+
+// Zones returns the zones service of the default client.
+func Zones() *api.ZonesService { return DefaultClient().Zones }
+
+// Records returns the records service of the default client.
+func Records() *api.RecordsService { return DefaultClient().Records }
+
+// Jobs returns the monitoring jobs service of the default client.
+func Jobs() *api.JobsService { return DefaultClient().Jobs }
+
+// DataSources returns the data sources service of the default client.
+func DataSources() *api.DataSourcesService { return DefaultClient().DataSources }
+
+// DataFeeds returns the data feeds service of the default client.
+func DataFeeds() *api.DataFeedsService { return DefaultClient().DataFeeds }
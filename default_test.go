@@ -0,0 +1,48 @@
+package ns1
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func TestDefaultClient(t *testing.T) {
+	defer SetDefaultClient(nil)
+
+	SetDefaultKey("first")
+	c := DefaultClient()
+	assert.Equal(t, "first", c.APIKey)
+	assert.Same(t, c, DefaultClient())
+	assert.Same(t, c.Zones, Zones())
+	assert.Same(t, c.Records, Records())
+
+	SetDefaultKey("second")
+	assert.False(t, c == DefaultClient())
+	assert.Equal(t, "second", DefaultClient().APIKey)
+
+	custom := api.NewClient(nil, api.SetAPIKey("custom"))
+	SetDefaultClient(custom)
+	assert.Same(t, custom, DefaultClient())
+}
+
+func TestDefaultClientConcurrent(t *testing.T) {
+	defer SetDefaultClient(nil)
+	SetDefaultClient(nil)
+
+	var wg sync.WaitGroup
+	clients := make([]*api.Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = DefaultClient()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+}
@@ -5,4 +5,8 @@
 //
 //      https://ns1.com/
 //
+// For one-off scripts, the package also exposes a lazily-initialized
+// default client (see SetDefaultKey, Zones and Records). It shares one
+// API key process-wide, so programs serving several accounts should build
+// their own clients with rest.NewClient.
 package ns1
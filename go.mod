@@ -1,5 +1,5 @@
 module gopkg.in/ns1/ns1-go.v2
 
-go 1.12
+go 1.13
 
 require github.com/stretchr/testify v1.4.0
@@ -0,0 +1,71 @@
+package mockns1
+
+import (
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// AddDataFeedListTestCase sets up a test case for the
+// api.Client.DataFeeds.List() function
+func (s *Service) AddDataFeedListTestCase(
+	sourceID string,
+	requestHeaders, responseHeaders http.Header,
+	response []*data.Feed,
+) error {
+	return s.AddTestCase(
+		http.MethodGet, "/data/feeds/"+sourceID, http.StatusOK, requestHeaders,
+		responseHeaders, "", response,
+	)
+}
+
+// AddDataFeedGetTestCase sets up a test case for the
+// api.Client.DataFeeds.Get() function
+func (s *Service) AddDataFeedGetTestCase(
+	sourceID, feedID string,
+	requestHeaders, responseHeaders http.Header,
+	response *data.Feed,
+) error {
+	return s.AddTestCase(
+		http.MethodGet, "/data/feeds/"+sourceID+"/"+feedID, http.StatusOK,
+		requestHeaders, responseHeaders, "", response,
+	)
+}
+
+// AddDataFeedCreateTestCase sets up a test case for the
+// api.Client.DataFeeds.Create() function
+func (s *Service) AddDataFeedCreateTestCase(
+	sourceID string,
+	requestHeaders, responseHeaders http.Header,
+	feed, response *data.Feed,
+) error {
+	return s.AddTestCase(
+		http.MethodPut, "/data/feeds/"+sourceID, http.StatusOK, requestHeaders,
+		responseHeaders, feed, response,
+	)
+}
+
+// AddDataFeedUpdateTestCase sets up a test case for the
+// api.Client.DataFeeds.Update() function
+func (s *Service) AddDataFeedUpdateTestCase(
+	sourceID string,
+	requestHeaders, responseHeaders http.Header,
+	feed, response *data.Feed,
+) error {
+	return s.AddTestCase(
+		http.MethodPost, "/data/feeds/"+sourceID+"/"+feed.ID, http.StatusOK,
+		requestHeaders, responseHeaders, feed, response,
+	)
+}
+
+// AddDataFeedDeleteTestCase sets up a test case for the
+// api.Client.DataFeeds.Delete() function
+func (s *Service) AddDataFeedDeleteTestCase(
+	sourceID, feedID string,
+	requestHeaders, responseHeaders http.Header,
+) error {
+	return s.AddTestCase(
+		http.MethodDelete, "/data/feeds/"+sourceID+"/"+feedID,
+		http.StatusNoContent, requestHeaders, responseHeaders, "", "",
+	)
+}
@@ -0,0 +1,47 @@
+package mockns1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func TestDataFeed(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	feed := data.NewFeed("lga", data.Config{"label": "lga"})
+	created := *feed
+	created.ID = "feed-1"
+	require.Nil(t, mock.AddDataFeedCreateTestCase("source-1", nil, nil, feed, &created))
+	require.Nil(t, mock.AddDataFeedListTestCase("source-1", nil, nil, []*data.Feed{&created}))
+	require.Nil(t, mock.AddDataFeedGetTestCase("source-1", "feed-1", nil, nil, &created))
+	require.Nil(t, mock.AddDataFeedUpdateTestCase("source-1", nil, nil, &created, &created))
+	require.Nil(t, mock.AddDataFeedDeleteTestCase("source-1", "feed-1", nil, nil))
+
+	_, err = client.DataFeeds.Create("source-1", feed)
+	require.Nil(t, err)
+	require.Equal(t, "feed-1", feed.ID)
+
+	feeds, _, err := client.DataFeeds.List("source-1")
+	require.Nil(t, err)
+	require.Equal(t, 1, len(feeds))
+
+	got, _, err := client.DataFeeds.Get("source-1", "feed-1")
+	require.Nil(t, err)
+	require.Equal(t, "lga", got.Name)
+
+	_, err = client.DataFeeds.Update("source-1", got)
+	require.Nil(t, err)
+
+	_, err = client.DataFeeds.Delete("source-1", "feed-1")
+	require.Nil(t, err)
+
+	require.True(t, mock.AssertExpectations())
+}
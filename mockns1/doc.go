@@ -1,4 +1,9 @@
 // Package mockns1 provides utilities to run a mock service
 // that emulates the NS1 API suitible for mock testing
-// code that relies on the gopkg.in/ns1/ns1-go.v2 pacakge
+// code that relies on the gopkg.in/ns1/ns1-go.v2 pacakge. For unit tests
+// that need no server at all, RecordingDoer stands in for the HTTP client.
+//
+// Besides AddTestCase, there are helpers for the zone, record, data feed and
+// monitoring job endpoints, and AssertExpectations checks at the end of a
+// test that every test case was requested and no unexpected request made.
 package mockns1
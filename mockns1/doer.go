@@ -0,0 +1,145 @@
+package mockns1
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// RecordedRequest is a request sent through a RecordingDoer.
+type RecordedRequest struct {
+	Method string
+	// Path is the request's URL path, e.g. "/v1/zones/example.com".
+	Path string
+	// URI is the request URI, i.e. Path with any query string.
+	URI    string
+	Header http.Header
+	Body   []byte
+}
+
+type cannedResponse struct {
+	status  int
+	headers http.Header
+	body    []byte
+}
+
+// RecordingDoer is an api.Doer for unit testing code using the client
+// without a server: it records each request sent through it and answers
+// with the responses queued by AddResponse, in order. It is safe for
+// concurrent use.
+//
+//	doer := mockns1.NewRecordingDoer()
+//	doer.AddResponse(http.StatusTooManyRequests, mockns1.RateLimitHeaders(api.RateLimit{Limit: 10, Period: 1}), `{"message": "rate limit exceeded"}`)
+//	doer.AddResponse(http.StatusOK, nil, zone)
+//	client := api.NewClient(doer, api.SetRetry(1, time.Millisecond))
+type RecordingDoer struct {
+	// RateLimit, if its Limit is set, is reported by the X-Ratelimit-*
+	// headers of every response that does not set them itself.
+	RateLimit api.RateLimit
+
+	mu        sync.Mutex
+	requests  []RecordedRequest
+	responses []cannedResponse
+}
+
+// NewRecordingDoer returns a RecordingDoer with no responses queued.
+func NewRecordingDoer() *RecordingDoer {
+	return &RecordingDoer{}
+}
+
+// AddResponse queues a response for the next request without one. As with
+// AddTestCase, a []byte or string body is sent as is and any other value is
+// JSON encoded. The headers may be nil.
+func (d *RecordingDoer) AddResponse(status int, headers http.Header, body interface{}) error {
+	b, _, err := convertBody(body)
+	if err != nil {
+		return fmt.Errorf("unable to convert response body to []byte: %s", err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.responses = append(d.responses, cannedResponse{status: status, headers: headers, body: b})
+	return nil
+}
+
+// Do records req and returns the next queued response, or an error if there
+// is none.
+func (d *RecordingDoer) Do(req *http.Request) (*http.Response, error) {
+	rr := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		URI:    req.URL.RequestURI(),
+		Header: req.Header.Clone(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		rr.Body = b
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = append(d.requests, rr)
+	if len(d.responses) == 0 {
+		return nil, fmt.Errorf("mockns1: no response queued for %s %s", rr.Method, rr.URI)
+	}
+	canned := d.responses[0]
+	d.responses = d.responses[1:]
+
+	header := http.Header{}
+	if d.RateLimit.Limit > 0 {
+		for k, v := range RateLimitHeaders(d.RateLimit) {
+			header[k] = v
+		}
+	}
+	for k, v := range canned.headers {
+		header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	if header.Get("Content-Type") == "" && len(canned.body) > 0 {
+		header.Set("Content-Type", "application/json")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", canned.status, http.StatusText(canned.status)),
+		StatusCode:    canned.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(canned.body)),
+		ContentLength: int64(len(canned.body)),
+		Request:       req,
+	}, nil
+}
+
+// Requests returns the requests sent so far, oldest first.
+func (d *RecordingDoer) Requests() []RecordedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]RecordedRequest(nil), d.requests...)
+}
+
+// Pending returns the number of queued responses not yet returned.
+func (d *RecordingDoer) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.responses)
+}
+
+// RateLimitHeaders returns the X-Ratelimit-* response headers reporting rl,
+// e.g. for the response of a 429 Too Many Requests.
+func RateLimitHeaders(rl api.RateLimit) http.Header {
+	h := http.Header{}
+	h.Set("X-Ratelimit-Limit", strconv.Itoa(rl.Limit))
+	h.Set("X-Ratelimit-Remaining", strconv.Itoa(rl.Remaining))
+	h.Set("X-Ratelimit-Period", strconv.Itoa(rl.Period))
+	return h
+}
@@ -0,0 +1,49 @@
+package mockns1_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestRecordingDoer(t *testing.T) {
+	doer := mockns1.NewRecordingDoer()
+	doer.RateLimit = api.RateLimit{Limit: 10, Remaining: 9, Period: 1}
+	require.Nil(t, doer.AddResponse(http.StatusTooManyRequests,
+		mockns1.RateLimitHeaders(api.RateLimit{Limit: 10, Remaining: 0, Period: 1}),
+		`{"message": "rate limit exceeded"}`))
+	require.Nil(t, doer.AddResponse(http.StatusOK, nil, dns.Zone{Zone: "example.com", ID: "z1"}))
+
+	client := api.NewClient(doer, api.SetAPIKey("key"), api.SetRetryPolicy(api.RetryPolicy{
+		MaxRetries: 1, BaseDelay: time.Millisecond, RetryNonIdempotent: true,
+	}))
+	z := dns.NewZone("example.com")
+	_, err := client.Zones.Create(z)
+	require.Nil(t, err)
+	assert.Equal(t, "z1", z.ID)
+	assert.Equal(t, 0, doer.Pending())
+
+	rl, ok := client.LastRateLimit()
+	assert.True(t, ok)
+	assert.Equal(t, 9, rl.Remaining)
+
+	reqs := doer.Requests()
+	require.Len(t, reqs, 2)
+	for _, r := range reqs {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/v1/zones/example.com", r.Path)
+		assert.Equal(t, "key", r.Header.Get("X-NSONE-Key"))
+		assert.JSONEq(t, `{"zone": "example.com"}`, string(r.Body))
+	}
+
+	_, _, err = client.Zones.Get("example.com")
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no response queued for GET /v1/zones/example.com")
+	assert.Len(t, doer.Requests(), 3)
+}
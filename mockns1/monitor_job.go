@@ -0,0 +1,68 @@
+package mockns1
+
+import (
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+// AddMonitorJobListTestCase sets up a test case for the
+// api.Client.Jobs.List() function
+func (s *Service) AddMonitorJobListTestCase(
+	requestHeaders, responseHeaders http.Header,
+	response []*monitor.Job,
+) error {
+	return s.AddTestCase(
+		http.MethodGet, "/monitoring/jobs", http.StatusOK, requestHeaders,
+		responseHeaders, "", response,
+	)
+}
+
+// AddMonitorJobGetTestCase sets up a test case for the api.Client.Jobs.Get()
+// function
+func (s *Service) AddMonitorJobGetTestCase(
+	id string,
+	requestHeaders, responseHeaders http.Header,
+	response *monitor.Job,
+) error {
+	return s.AddTestCase(
+		http.MethodGet, "/monitoring/jobs/"+id, http.StatusOK, requestHeaders,
+		responseHeaders, "", response,
+	)
+}
+
+// AddMonitorJobCreateTestCase sets up a test case for the
+// api.Client.Jobs.Create() function
+func (s *Service) AddMonitorJobCreateTestCase(
+	requestHeaders, responseHeaders http.Header,
+	job, response *monitor.Job,
+) error {
+	return s.AddTestCase(
+		http.MethodPut, "/monitoring/jobs/"+job.ID, http.StatusOK,
+		requestHeaders, responseHeaders, job, response,
+	)
+}
+
+// AddMonitorJobUpdateTestCase sets up a test case for the
+// api.Client.Jobs.Update() function
+func (s *Service) AddMonitorJobUpdateTestCase(
+	requestHeaders, responseHeaders http.Header,
+	job, response *monitor.Job,
+) error {
+	return s.AddTestCase(
+		http.MethodPost, "/monitoring/jobs/"+job.ID, http.StatusOK,
+		requestHeaders, responseHeaders, job, response,
+	)
+}
+
+// AddMonitorJobDeleteTestCase sets up a test case for the
+// api.Client.Jobs.Delete() function
+func (s *Service) AddMonitorJobDeleteTestCase(
+	id string,
+	requestHeaders, responseHeaders http.Header,
+) error {
+	return s.AddTestCase(
+		http.MethodDelete, "/monitoring/jobs/"+id, http.StatusNoContent,
+		requestHeaders, responseHeaders, "", "",
+	)
+}
@@ -0,0 +1,47 @@
+package mockns1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestMonitorJob(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	job := &monitor.Job{Name: "web", Type: "http"}
+	created := *job
+	created.ID = "job-1"
+	require.Nil(t, mock.AddMonitorJobCreateTestCase(nil, nil, job, &created))
+	require.Nil(t, mock.AddMonitorJobListTestCase(nil, nil, []*monitor.Job{&created}))
+	require.Nil(t, mock.AddMonitorJobGetTestCase("job-1", nil, nil, &created))
+	require.Nil(t, mock.AddMonitorJobUpdateTestCase(nil, nil, &created, &created))
+	require.Nil(t, mock.AddMonitorJobDeleteTestCase("job-1", nil, nil))
+
+	_, err = client.Jobs.Create(job)
+	require.Nil(t, err)
+	require.Equal(t, "job-1", job.ID)
+
+	jobs, _, err := client.Jobs.List()
+	require.Nil(t, err)
+	require.Equal(t, 1, len(jobs))
+
+	got, _, err := client.Jobs.Get("job-1")
+	require.Nil(t, err)
+	require.Equal(t, "web", got.Name)
+
+	_, err = client.Jobs.Update(got)
+	require.Nil(t, err)
+
+	_, err = client.Jobs.Delete("job-1")
+	require.Nil(t, err)
+
+	require.True(t, mock.AssertExpectations())
+}
@@ -0,0 +1,56 @@
+package mockns1
+
+import (
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// AddRecordGetTestCase sets up a test case for the api.Client.Records.Get()
+// function
+func (s *Service) AddRecordGetTestCase(
+	zone, domain, recordType string,
+	requestHeaders, responseHeaders http.Header,
+	response *dns.Record,
+) error {
+	return s.AddTestCase(
+		http.MethodGet, "/zones/"+zone+"/"+domain+"/"+recordType, http.StatusOK,
+		requestHeaders, responseHeaders, "", response,
+	)
+}
+
+// AddRecordCreateTestCase sets up a test case for the
+// api.Client.Records.Create() function
+func (s *Service) AddRecordCreateTestCase(
+	requestHeaders, responseHeaders http.Header,
+	record, response *dns.Record,
+) error {
+	return s.AddTestCase(
+		http.MethodPut, "/zones/"+record.Zone+"/"+record.Domain+"/"+record.Type,
+		http.StatusOK, requestHeaders, responseHeaders, record, response,
+	)
+}
+
+// AddRecordUpdateTestCase sets up a test case for the
+// api.Client.Records.Update() function
+func (s *Service) AddRecordUpdateTestCase(
+	requestHeaders, responseHeaders http.Header,
+	record, response *dns.Record,
+) error {
+	return s.AddTestCase(
+		http.MethodPost, "/zones/"+record.Zone+"/"+record.Domain+"/"+record.Type,
+		http.StatusOK, requestHeaders, responseHeaders, record, response,
+	)
+}
+
+// AddRecordDeleteTestCase sets up a test case for the
+// api.Client.Records.Delete() function
+func (s *Service) AddRecordDeleteTestCase(
+	zone, domain, recordType string,
+	requestHeaders, responseHeaders http.Header,
+) error {
+	return s.AddTestCase(
+		http.MethodDelete, "/zones/"+zone+"/"+domain+"/"+recordType,
+		http.StatusNoContent, requestHeaders, responseHeaders, "", "",
+	)
+}
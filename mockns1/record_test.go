@@ -0,0 +1,41 @@
+package mockns1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestRecord(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	record := dns.NewRecord("example.com", "www.example.com", "A")
+	record.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+	require.Nil(t, mock.AddRecordCreateTestCase(nil, nil, record, record))
+	require.Nil(t, mock.AddRecordGetTestCase("example.com", "www.example.com", "A", nil, nil, record))
+	require.Nil(t, mock.AddRecordUpdateTestCase(nil, nil, record, record))
+	require.Nil(t, mock.AddRecordDeleteTestCase("example.com", "www.example.com", "A", nil, nil))
+
+	_, err = client.Records.Create(record)
+	require.Nil(t, err)
+
+	resp, _, err := client.Records.Get("example.com", "www.example.com", "A")
+	require.Nil(t, err)
+	require.Equal(t, record.Domain, resp.Domain)
+	require.Equal(t, 1, len(resp.Answers))
+
+	_, err = client.Records.Update(record)
+	require.Nil(t, err)
+
+	_, err = client.Records.Delete("example.com", "www.example.com", "A")
+	require.Nil(t, err)
+
+	require.True(t, mock.AssertExpectations())
+}
@@ -0,0 +1,51 @@
+package mockns1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// Response headers describing the recorded transfer rather than its content,
+// which are not replayed.
+var transferHeaders = []string{"Content-Length", "Content-Encoding", "Transfer-Encoding", "Date"}
+
+// AddRecordedTestCases adds a test case for each api.RecordedExchange read
+// from r, as written by a client created with api.SetRecorder, so that
+// recorded API interactions can be replayed. Request headers are not
+// matched.
+func (s *Service) AddRecordedTestCases(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		var ex api.RecordedExchange
+		if err := dec.Decode(&ex); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("unable to decode recorded exchange %d: %s", i, err)
+		}
+
+		var reqBody interface{} = ""
+		if len(ex.Request.Body) > 0 {
+			reqBody = ex.Request.Body
+		}
+
+		respHeaders := http.Header{}
+		for k, v := range ex.Response.Headers {
+			respHeaders[k] = v
+		}
+		for _, h := range transferHeaders {
+			respHeaders.Del(h)
+		}
+
+		err := s.AddTestCase(
+			ex.Request.Method, ex.Request.URI, ex.Response.Status,
+			nil, respHeaders, reqBody, []byte(ex.Response.Body),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to add recorded exchange %d: %s", i, err)
+		}
+	}
+}
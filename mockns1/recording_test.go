@@ -0,0 +1,57 @@
+package mockns1_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestRecordedTestCases(t *testing.T) {
+	// Stand-in for the real API.
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/zones/rec.zone":
+			w.Write([]byte(`{"zone": "rec.zone", "ttl": 3600}`)) // nolint: errcheck
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/zones/new.zone":
+			w.Write([]byte(`{"zone": "new.zone", "id": "z1"}`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`)) // nolint: errcheck
+		}
+	}))
+	defer live.Close()
+
+	var golden bytes.Buffer
+	client := api.NewClient(nil, api.SetEndpoint(live.URL+"/v1/"), api.SetAPIKey("secret-key"), api.SetRecorder(&golden))
+
+	run := func(client *api.Client) {
+		z, _, err := client.Zones.Get("rec.zone")
+		require.Nil(t, err)
+		require.Equal(t, 3600, z.TTL)
+
+		nz := dns.NewZone("new.zone")
+		_, err = client.Zones.Create(nz)
+		require.Nil(t, err)
+		require.Equal(t, "z1", nz.ID)
+
+		_, _, err = client.Zones.Get("gone.zone")
+		require.Equal(t, api.ErrZoneMissing, err)
+	}
+	run(client)
+
+	require.NotContains(t, golden.String(), "secret-key")
+	require.Contains(t, golden.String(), "REDACTED")
+
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	require.Nil(t, mock.AddRecordedTestCases(bytes.NewReader(golden.Bytes())))
+	run(api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/")))
+}
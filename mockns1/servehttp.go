@@ -13,15 +13,17 @@ import (
 func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.stopTimer()
 	defer s.startTimer()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	if _, exists := s.tests[r.Method]; !exists {
-		notFoundResponse(w, "method")
+		s.notFound(w, r, "method")
 		return
 	}
 
 	tests, exists := s.tests[r.Method][r.RequestURI]
 	if !exists {
-		notFoundResponse(w, "uri")
+		s.notFound(w, r, "uri")
 		return
 	}
 
@@ -44,9 +46,10 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if test == nil {
-		notFoundResponse(w, "no test")
+		s.notFound(w, r, "no test")
 		return
 	}
+	test.calls++
 
 	for k, vals := range test.response.headers {
 		w.Header().Set(k, vals[0])
@@ -67,6 +70,13 @@ func compareBody(test *testCase, body []byte) bool {
 	return assert.JSONEq(new(testifyT), string(test.request.body), string(body))
 }
 
+// notFound records r as unexpected and responds with a 404; s.mu must be
+// held.
+func (s *Service) notFound(w http.ResponseWriter, r *http.Request, reason string) {
+	s.unexpected = append(s.unexpected, r.Method+" "+r.RequestURI)
+	notFoundResponse(w, reason)
+}
+
 func notFoundResponse(w http.ResponseWriter, reason string) {
 	msg := fmt.Sprintf(`{"message": "request not found: %s"}`, reason)
 	w.WriteHeader(http.StatusNotFound)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 
 	api "gopkg.in/ns1/ns1-go.v2/rest"
@@ -19,8 +20,11 @@ type Service struct {
 	Address string
 
 	server *httptest.Server
-	tests  map[string]map[string][]*testCase // method, uri
 	tb     testing.TB
+
+	mu         sync.Mutex
+	tests      map[string]map[string][]*testCase // method, uri
+	unexpected []string                          // requests matching no test
 }
 
 // New creates and starts a new TLS based *httptest.Server instance. As a
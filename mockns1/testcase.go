@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 
 type testCase struct {
 	status  int
+	calls   int
 	request struct {
 		headers http.Header
 		body    []byte
@@ -32,6 +34,8 @@ func (s *Service) AddTestCase(
 ) error {
 	s.stopTimer()
 	defer s.startTimer()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	if !strings.HasPrefix(uri, "/v1/") {
 		uri = "/v1/" + uri
@@ -74,9 +78,57 @@ func (s *Service) AddTestCase(
 	return nil
 }
 
-// ClearTestCases removes all previously added test cases
+// ClearTestCases removes all previously added test cases, and forgets the
+// unexpected requests
 func (s *Service) ClearTestCases() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.tests = map[string]map[string][]*testCase{}
+	s.unexpected = nil
+}
+
+// Uncalled returns the method and uri of each test case that no request
+// has matched yet, sorted
+func (s *Service) Uncalled() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var uncalled []string
+	for method, uris := range s.tests {
+		for uri, tests := range uris {
+			for _, test := range tests {
+				if test.calls == 0 {
+					uncalled = append(uncalled, method+" "+uri)
+				}
+			}
+		}
+	}
+	sort.Strings(uncalled)
+	return uncalled
+}
+
+// Unexpected returns the method and uri of each request that matched no
+// test case, in the order received
+func (s *Service) Unexpected() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.unexpected...)
+}
+
+// AssertExpectations reports an error on the testing.TB given to New for
+// each test case that was never requested and each request that matched no
+// test case, and returns whether there were none
+func (s *Service) AssertExpectations() bool {
+	ok := true
+	for _, tc := range s.Uncalled() {
+		s.tb.Errorf("mockns1: test case was not requested: %s", tc)
+		ok = false
+	}
+	for _, req := range s.Unexpected() {
+		s.tb.Errorf("mockns1: request matched no test case: %s", req)
+		ok = false
+	}
+	return ok
 }
 
 func convertBody(body interface{}) ([]byte, bool, error) {
@@ -52,4 +52,30 @@ func TestTestCase(t *testing.T) {
 		mock.ServeHTTP(mw, req)
 		require.Equal(t, http.StatusNotFound, mw.status, mw.buf.String())
 	})
+
+	t.Run("Expectations", func(t *testing.T) {
+		mock.ClearTestCases()
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "test/called", http.StatusOK,
+			nil, nil, "", ""))
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "test/uncalled", http.StatusOK,
+			nil, nil, "", ""))
+
+		for _, uri := range []string{"/v1/test/called", "/v1/test/unknown"} {
+			mw := &mockWriter{buf: bytes.NewBufferString("")}
+			mock.ServeHTTP(mw, &http.Request{
+				Method:     http.MethodGet,
+				RequestURI: uri,
+				Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+				Header:     http.Header{},
+			})
+		}
+
+		require.Equal(t, []string{"DELETE /v1/test/uncalled"}, mock.Uncalled())
+		require.Equal(t, []string{"GET /v1/test/unknown"}, mock.Unexpected())
+
+		mock.ClearTestCases()
+		require.Empty(t, mock.Uncalled())
+		require.Empty(t, mock.Unexpected())
+		require.True(t, mock.AssertExpectations())
+	})
 }
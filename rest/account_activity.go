@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// ActivityService handles 'account/activity' endpoint.
+type ActivityService service
+
+// List returns the entries of the account's activity log, filtered by opts,
+// e.g. WithValues(SetTimeParam("start", t)) for the entries from t on,
+// WithParam("resource_type", "record"), or WithParam("limit", "100").
+//
+// NS1 API docs: https://ns1.com/api/#activity-get
+func (s *ActivityService) List(opts ...RequestOption) ([]*account.Activity, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/activity", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	al := []*account.Activity{}
+	resp, err := s.client.Do(req, &al)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return al, resp, nil
+}
+
+// Tail polls the activity log every interval for the entries logged from
+// now on, e.g. to forward them to a SIEM, and sends each one once to the
+// returned channel, oldest first. opts filter the entries as with List; a
+// poll pages through them when there are more than one List call returns.
+// An interval of 0 or less polls every 10 seconds.
+//
+// A failed poll is sent to the error channel if it is being read, and
+// dropped otherwise; polling goes on, and the next poll picks up from the
+// last entry sent. Both channels are closed once ctx is done.
+func (s *ActivityService) Tail(ctx context.Context, interval time.Duration, opts ...RequestOption) (<-chan *account.Activity, <-chan error) {
+	entries := make(chan *account.Activity)
+	errs := make(chan error)
+	activity := s.client.WithContext(ctx).Activity
+
+	go func() {
+		defer close(entries)
+		defer close(errs)
+
+		if interval <= 0 {
+			interval = defaultTailInterval
+		}
+		t := &activityTail{since: time.Now().Unix(), seen: map[string]bool{}}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			al, err := t.poll(activity, opts)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			}
+			for _, a := range t.fresh(al) {
+				select {
+				case entries <- a:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return entries, errs
+}
+
+const (
+	// defaultTailInterval is the polling interval of Tail when none is given.
+	defaultTailInterval = 10 * time.Second
+
+	// activityPageSize is the number of entries Tail asks for per List call.
+	activityPageSize = 100
+)
+
+// activityTail tracks the entries Tail has sent. Polls start at the second of
+// the latest entry, which may have more entries to come, so the IDs of the
+// entries of that second are kept to skip them.
+type activityTail struct {
+	since int64 // unix time of the latest entry
+	seen  map[string]bool
+}
+
+// poll lists the entries from the second of the latest one sent on. The log
+// lists the newest entries first, so while a page is full the next one ends
+// at the oldest entry of the last. If any page fails, none are returned, and
+// the next poll starts over from the same second.
+func (t *activityTail) poll(activity *ActivityService, opts []RequestOption) ([]*account.Activity, error) {
+	start := WithParam("start", strconv.FormatInt(t.since, 10))
+	limit := WithParam("limit", strconv.Itoa(activityPageSize))
+
+	var all []*account.Activity
+	ids := map[string]bool{}
+	var end []RequestOption
+	for {
+		al, _, err := activity.List(append(append(opts[:len(opts):len(opts)], start, limit), end...)...)
+		if err != nil {
+			return nil, err
+		}
+
+		added := false
+		oldest := t.since
+		for i, a := range al {
+			if ts := a.Timestamp.Unix(); i == 0 || ts < oldest {
+				oldest = ts
+			}
+			if !ids[a.ID] {
+				ids[a.ID] = true
+				all = append(all, a)
+				added = true
+			}
+		}
+		// A page of entries of one second would only list itself again.
+		if len(al) < activityPageSize || !added {
+			return all, nil
+		}
+		end = []RequestOption{WithParam("end", strconv.FormatInt(oldest, 10))}
+	}
+}
+
+// fresh returns the entries of al not sent yet, oldest first.
+func (t *activityTail) fresh(al []*account.Activity) []*account.Activity {
+	sort.SliceStable(al, func(i, j int) bool { return al[i].Timestamp.Before(al[j].Timestamp.Time) })
+
+	var fresh []*account.Activity
+	for _, a := range al {
+		ts := a.Timestamp.Unix()
+		if ts < t.since || t.seen[a.ID] {
+			continue
+		}
+		if ts > t.since {
+			t.since, t.seen = ts, map[string]bool{}
+		}
+		t.seen[a.ID] = true
+		fresh = append(fresh, a)
+	}
+	return fresh
+}
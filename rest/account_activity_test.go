@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func activityAt(id string, unix int64) *account.Activity {
+	return &account.Activity{ID: id, Timestamp: model.NewTime(time.Unix(unix, 0))}
+}
+
+func TestActivityTail(t *testing.T) {
+	now := time.Now().Unix()
+	log := []*account.Activity{activityAt("a", now+1), activityAt("b", now+1), activityAt("c", now+2)}
+
+	var (
+		mu    sync.Mutex
+		polls int
+	)
+	// Each poll reveals one more entry, newest first; the second one fails.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		assert.Equal(t, "record", r.URL.Query().Get("resource_type"))
+		if polls == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message": "try again"}`))
+			return
+		}
+		start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		al := []*account.Activity{}
+		for i := len(log) - 1; i >= 0; i-- {
+			if i < polls && log[i].Timestamp.Unix() >= start {
+				al = append(al, log[i])
+			}
+		}
+		json.NewEncoder(w).Encode(al)
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	activity, errs := client.Activity.Tail(ctx, 5*time.Millisecond, WithParam("resource_type", "record"))
+
+	var ids []string
+	for len(ids) < len(log) {
+		select {
+		case a := <-activity:
+			ids = append(ids, a.ID)
+		case <-errs:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %v", ids)
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+
+	cancel()
+	for range activity {
+	}
+	_, ok := <-errs
+	assert.False(t, ok)
+}
+
+func TestActivityTail_paging(t *testing.T) {
+	// More entries than fit a page, several in the same second.
+	now := time.Now().Unix()
+	var log []*account.Activity
+	for i := 0; i < 2*activityPageSize+50; i++ {
+		log = append(log, activityAt(strconv.Itoa(i), now+1+int64(i/3)))
+	}
+
+	var (
+		mu  sync.Mutex
+		bad []string
+	)
+	// The log lists the newest entries first, up to limit, from start to
+	// end inclusive.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		q := r.URL.Query()
+		if q.Get("limit") != strconv.Itoa(activityPageSize) {
+			bad = append(bad, r.URL.RawQuery)
+		}
+		start, _ := strconv.ParseInt(q.Get("start"), 10, 64)
+		end, err := strconv.ParseInt(q.Get("end"), 10, 64)
+		if err != nil {
+			end = now + int64(len(log))
+		}
+		al := []*account.Activity{}
+		for i := len(log) - 1; i >= 0 && len(al) < activityPageSize; i-- {
+			if ts := log[i].Timestamp.Unix(); ts >= start && ts <= end {
+				al = append(al, log[i])
+			}
+		}
+		json.NewEncoder(w).Encode(al)
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	activity, _ := client.Activity.Tail(ctx, 5*time.Millisecond)
+
+	seen := map[string]bool{}
+	for len(seen) < len(log) {
+		select {
+		case a := <-activity:
+			assert.False(t, seen[a.ID], a.ID)
+			seen[a.ID] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %d of %d entries", len(seen), len(log))
+		}
+	}
+	mu.Lock()
+	assert.Empty(t, bad)
+	mu.Unlock()
+}
+
+func TestActivityTail_interval(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	activity, _ := client.Activity.Tail(ctx, 0)
+	cancel()
+	for range activity {
+	}
+}
+
+func TestActivityTail_fresh(t *testing.T) {
+	tail := &activityTail{since: 100, seen: map[string]bool{}}
+	fresh := func(al ...*account.Activity) []string {
+		var ids []string
+		for _, a := range tail.fresh(al) {
+			ids = append(ids, a.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"a", "b"}, fresh(activityAt("b", 101), activityAt("a", 100), activityAt("old", 99)))
+	assert.Equal(t, []string{"c"}, fresh(activityAt("c", 101), activityAt("b", 101)))
+	assert.Empty(t, fresh(activityAt("c", 101), activityAt("a", 100)))
+	assert.Equal(t, int64(101), tail.since)
+}
@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,11 +10,12 @@ import (
 // APIKeysService handles 'account/apikeys' endpoint.
 type APIKeysService service
 
-// List returns all api keys in the account.
+// List returns all api keys in the account. The secret Key of each
+// api key is not included.
 //
 // NS1 API docs: https://ns1.com/api/#apikeys-get
-func (s *APIKeysService) List() ([]*account.APIKey, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "account/apikeys", nil)
+func (s *APIKeysService) List(opts ...RequestOption) ([]*account.APIKey, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/apikeys", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -26,11 +26,16 @@ func (s *APIKeysService) List() ([]*account.APIKey, *http.Response, error) {
 		return nil, resp, err
 	}
 
+	for _, k := range kl {
+		k.Key = ""
+	}
+
 	return kl, resp, nil
 }
 
 // Get returns details of an api key, including permissions, for a single API Key.
 // Note: do not use the API Key itself as the keyid in the URL — use the id of the key.
+// The secret Key is not included.
 //
 // NS1 API docs: https://ns1.com/api/#apikeys-id-get
 func (s *APIKeysService) Get(keyID string) (*account.APIKey, *http.Response, error) {
@@ -54,10 +59,14 @@ func (s *APIKeysService) Get(keyID string) (*account.APIKey, *http.Response, err
 		return nil, resp, err
 	}
 
+	a.Key = ""
+
 	return &a, resp, nil
 }
 
 // Create takes a *APIKey and creates a new account apikey.
+// On success the secret Key is populated on the given *APIKey. This is the
+// only time NS1 returns the secret; it can not be retrieved later.
 //
 // NS1 API docs: https://ns1.com/api/#apikeys-put
 func (s *APIKeysService) Create(a *account.APIKey) (*http.Response, error) {
@@ -67,7 +76,7 @@ func (s *APIKeysService) Create(a *account.APIKey) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && a != nil {
+	if s.client.snapshot().DDI && a != nil {
 		ddiAPIKey := apiKeyToDDIAPIKey(a)
 		req, err = s.client.NewRequest("PUT", "account/apikeys", ddiAPIKey)
 		if err != nil {
@@ -107,7 +116,7 @@ func (s *APIKeysService) Update(a *account.APIKey) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && a != nil {
+	if s.client.snapshot().DDI && a != nil {
 		ddiAPIKey := apiKeyToDDIAPIKey(a)
 		req, err = s.client.NewRequest("POST", path, ddiAPIKey)
 		if err != nil {
@@ -160,11 +169,37 @@ func (s *APIKeysService) Delete(keyID string) (*http.Response, error) {
 	return resp, nil
 }
 
+// Rotate replaces an api key with a new one named name, carrying over the
+// teams, permissions and IP whitelist of the old key, and then deletes the
+// old key. The returned *APIKey holds the new secret Key. The old key is
+// only deleted once the new one is created; if deleting it fails, the new
+// key is returned along with the error so its secret is not lost.
+func (s *APIKeysService) Rotate(keyID, name string) (*account.APIKey, *http.Response, error) {
+	old, resp, err := s.Get(keyID)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	k := &account.APIKey{
+		Name:              name,
+		TeamIDs:           old.TeamIDs,
+		Permissions:       old.Permissions,
+		IPWhitelist:       old.IPWhitelist,
+		IPWhitelistStrict: old.IPWhitelistStrict,
+	}
+	if resp, err = s.Create(k); err != nil {
+		return nil, resp, err
+	}
+
+	resp, err = s.Delete(keyID)
+	return k, resp, err
+}
+
 var (
 	// ErrKeyExists bundles PUT create error.
-	ErrKeyExists = errors.New("key already exists")
+	ErrKeyExists = existsError("key already exists")
 	// ErrKeyMissing bundles GET/POST/DELETE error.
-	ErrKeyMissing = errors.New("key does not exist")
+	ErrKeyMissing = missingError("key does not exist")
 )
 
 func apiKeyToDDIAPIKey(k *account.APIKey) *ddiAPIKey {
@@ -153,3 +153,113 @@ func TestCreateDDIAPIKey(t *testing.T) {
 	_, err = c.APIKeys.Create(k)
 	require.NoError(t, err)
 }
+
+func TestAPIKeySecret(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k := account.APIKey{ID: "id-1", Key: "secret-1", Name: "name-1"}
+		switch r.Method {
+		case http.MethodPut:
+			assert.NoError(t, json.NewEncoder(w).Encode(k))
+		case http.MethodGet:
+			if r.URL.Path == "/account/apikeys" {
+				assert.NoError(t, json.NewEncoder(w).Encode([]account.APIKey{k}))
+				return
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(k))
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(nil, SetEndpoint(ts.URL))
+
+	k := &account.APIKey{Name: "name-1"}
+	_, err := c.APIKeys.Create(k)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-1", k.Key)
+	assert.NotContains(t, k.String(), "secret-1")
+
+	got, _, err := c.APIKeys.Get("id-1")
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", got.ID)
+	assert.Empty(t, got.Key)
+
+	kl, _, err := c.APIKeys.List()
+	require.NoError(t, err)
+	require.Len(t, kl, 1)
+	assert.Empty(t, kl[0].Key)
+}
+
+func TestAPIKeyPermissions(t *testing.T) {
+	// As returned by the API: List and Get never include the secret.
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/account/apikeys":
+			w.Write([]byte(`[{"id": "id-1", "name": "other", "permissions": {"dns": {"manage_zones": true, "zones_allow_by_default": true}}},
+				{"id": "id-2", "name": "mine", "permissions": {"dns": {"view_zones": true, "manage_zones": true, "zones_allow": ["mine.com"]}}}]`))
+		case "/account/apikeys/id-2":
+			w.Write([]byte(`{"id": "id-2", "name": "mine", "permissions": {"dns": {"view_zones": true, "manage_zones": true,
+				"zones_allow": ["mine.com"], "records_deny": [{"zone": "mine.com", "domain": "mine.com", "type": "NS"}]}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "unknown api key"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL), SetAPIKey("my-key"), SetAPIKeyID("id-2"))
+
+	ok, err := c.APIKeys.CanManageZone("mine.com")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.APIKeys.CanAccessZone("theirs.com")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = c.APIKeys.CanManageRecord("mine.com", "mine.com", "NS")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, hits)
+
+	_, err = c.APIKeys.RefreshPermissions()
+	require.NoError(t, err)
+	assert.Equal(t, 2, hits)
+
+	c = NewClient(nil, SetEndpoint(ts.URL), SetAPIKey("my-key"))
+	_, err = c.APIKeys.CanAccessZone("mine.com")
+	assert.Equal(t, ErrCurrentKeyUnknown, err)
+	assert.Equal(t, 2, hits)
+
+	c = NewClient(nil, SetEndpoint(ts.URL), SetAPIKey("my-key"), SetAPIKeyID("id-9"))
+	_, err = c.APIKeys.CanAccessZone("mine.com")
+	assert.Equal(t, ErrKeyMissing, err)
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.NoError(t, json.NewEncoder(w).Encode(account.APIKey{
+				ID: "id-1", Name: "ci", TeamIDs: []string{"team-1"}, IPWhitelist: []string{"10.0.0.0/8"},
+			}))
+		case http.MethodPut:
+			var k account.APIKey
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&k))
+			assert.Equal(t, "ci-2", k.Name)
+			assert.Equal(t, []string{"team-1"}, k.TeamIDs)
+			assert.Equal(t, []string{"10.0.0.0/8"}, k.IPWhitelist)
+			k.ID, k.Key = "id-2", "secret-2"
+			assert.NoError(t, json.NewEncoder(w).Encode(k))
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(nil, SetEndpoint(ts.URL))
+
+	k, _, err := c.APIKeys.Rotate("id-1", "ci-2")
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", k.ID)
+	assert.Equal(t, "secret-2", k.Key)
+	assert.Equal(t, []string{"/account/apikeys/id-1"}, deleted)
+}
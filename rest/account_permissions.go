@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"errors"
+	"sync"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// ErrCurrentKeyUnknown is returned when the client has no id for its API key,
+// see SetAPIKeyID, so its permissions cannot be determined.
+var ErrCurrentKeyUnknown = errors.New("current API key id not set")
+
+// permissionsCache holds the permissions of the client's API key. It is
+// shared by pointer between copies of a Client.
+type permissionsCache struct {
+	mu    sync.Mutex
+	perms *account.PermissionsMap
+}
+
+// CurrentPermissions returns the permissions of the client's own API key,
+// looked up on first use and cached for the client's lifetime (see
+// RefreshPermissions). The key is identified by the id given to
+// SetAPIKeyID; ErrCurrentKeyUnknown is returned without one. Looking the
+// key up requires the manage_apikeys permission. These are the key's own
+// permissions: those it gets from its teams are not merged in.
+func (s *APIKeysService) CurrentPermissions() (*account.PermissionsMap, error) {
+	pc := s.client.permissions
+	pc.mu.Lock()
+	perms := pc.perms
+	pc.mu.Unlock()
+
+	if perms != nil {
+		return perms, nil
+	}
+	return s.RefreshPermissions()
+}
+
+// RefreshPermissions looks up the permissions of the client's own API key
+// again and replaces the cached copy.
+func (s *APIKeysService) RefreshPermissions() (*account.PermissionsMap, error) {
+	id := s.client.snapshot().apiKeyID
+	if id == "" {
+		return nil, ErrCurrentKeyUnknown
+	}
+
+	k, _, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	perms := k.Permissions
+
+	pc := s.client.permissions
+	pc.mu.Lock()
+	pc.perms = &perms
+	pc.mu.Unlock()
+
+	return &perms, nil
+}
+
+// CanAccessZone reports whether the client's API key may read zone, given
+// the global DNS permission flags and the zone allow/deny lists.
+func (s *APIKeysService) CanAccessZone(zone string) (bool, error) {
+	perms, err := s.CurrentPermissions()
+	if err != nil {
+		return false, err
+	}
+	return perms.DNS.CanViewZone(zone), nil
+}
+
+// CanManageZone reports whether the client's API key may modify zone and
+// its records. Use it to preflight mutations instead of failing with a 403
+// part way through. Single records may still be allowed or denied, see
+// CanManageRecord.
+func (s *APIKeysService) CanManageZone(zone string) (bool, error) {
+	perms, err := s.CurrentPermissions()
+	if err != nil {
+		return false, err
+	}
+	return perms.DNS.CanManageZone(zone), nil
+}
+
+// CanManageRecord reports whether the client's API key may modify a record
+// of zone, given its zone permissions and the records allow/deny lists.
+func (s *APIKeysService) CanManageRecord(zone, domain, recordType string) (bool, error) {
+	perms, err := s.CurrentPermissions()
+	if err != nil {
+		return false, err
+	}
+	return perms.DNS.CanManageRecord(zone, domain, recordType), nil
+}
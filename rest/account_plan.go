@@ -0,0 +1,28 @@
+package rest
+
+import (
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// PlanService handles 'account/plan' endpoint.
+type PlanService service
+
+// Get returns the account's billing plan.
+//
+// NS1 API docs: https://ns1.com/api/#plan-get
+func (s *PlanService) Get() (*account.Plan, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/plan", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p account.Plan
+	resp, err := s.client.Do(req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &p, resp, nil
+}
@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,8 +13,8 @@ type TeamsService service
 // List returns all teams in the account.
 //
 // NS1 API docs: https://ns1.com/api/#teams-get
-func (s *TeamsService) List() ([]*account.Team, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "account/teams", nil)
+func (s *TeamsService) List(opts ...RequestOption) ([]*account.Team, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/teams", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,7 +64,7 @@ func (s *TeamsService) Create(t *account.Team) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && t != nil {
+	if s.client.snapshot().DDI && t != nil {
 		ddiTeam := teamToDDITeam(t)
 		req, err = s.client.NewRequest("PUT", "account/teams", ddiTeam)
 		if err != nil {
@@ -105,7 +104,7 @@ func (s *TeamsService) Update(t *account.Team) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && t != nil {
+	if s.client.snapshot().DDI && t != nil {
 		ddiTeam := teamToDDITeam(t)
 		req, err = s.client.NewRequest("POST", path, ddiTeam)
 		if err != nil {
@@ -160,9 +159,9 @@ func (s *TeamsService) Delete(id string) (*http.Response, error) {
 
 var (
 	// ErrTeamExists bundles PUT create error.
-	ErrTeamExists = errors.New("team already exists")
+	ErrTeamExists = existsError("team already exists")
 	// ErrTeamMissing bundles GET/POST/DELETE error.
-	ErrTeamMissing = errors.New("team does not exist")
+	ErrTeamMissing = missingError("team does not exist")
 )
 
 func teamToDDITeam(t *account.Team) *ddiTeam {
@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,8 +13,8 @@ type UsersService service
 // List returns all users in the account.
 //
 // NS1 API docs: https://ns1.com/api/#users-get
-func (s *UsersService) List() ([]*account.User, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "account/users", nil)
+func (s *UsersService) List(opts ...RequestOption) ([]*account.User, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/users", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,7 +64,7 @@ func (s *UsersService) Create(u *account.User) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && u != nil {
+	if s.client.snapshot().DDI && u != nil {
 		ddiUser := userToDDIUser(u)
 		req, err = s.client.NewRequest("PUT", "account/users", ddiUser)
 		if err != nil {
@@ -105,7 +104,7 @@ func (s *UsersService) Update(u *account.User) (*http.Response, error) {
 	)
 
 	// If this is DDI then the permissions need to be transformed to DDI-compatible permissions.
-	if s.client.DDI && u != nil {
+	if s.client.snapshot().DDI && u != nil {
 		ddiUser := userToDDIUser(u)
 		req, err = s.client.NewRequest("POST", path, ddiUser)
 		if err != nil {
@@ -160,9 +159,9 @@ func (s *UsersService) Delete(username string) (*http.Response, error) {
 
 var (
 	// ErrUserExists bundles PUT create error.
-	ErrUserExists = errors.New("user already exists")
+	ErrUserExists = existsError("user already exists")
 	// ErrUserMissing bundles GET/POST/DELETE error.
-	ErrUserMissing = errors.New("user does not exist")
+	ErrUserMissing = missingError("user does not exist")
 )
 
 func userToDDIUser(u *account.User) *ddiUser {
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// WhitelistService handles 'account/whitelist' endpoint.
+type WhitelistService service
+
+// List returns all IP whitelist entries of the account.
+//
+// NS1 API docs: https://ns1.com/api/#whitelist-get
+func (s *WhitelistService) List(opts ...RequestOption) ([]*account.WhitelistEntry, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "account/whitelist", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wl := []*account.WhitelistEntry{}
+	resp, err := s.client.Do(req, &wl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return wl, resp, nil
+}
+
+// Get takes a whitelist entry id and returns the entry.
+//
+// NS1 API docs: https://ns1.com/api/#whitelist-id-get
+func (s *WhitelistService) Get(id string) (*account.WhitelistEntry, *http.Response, error) {
+	path := fmt.Sprintf("account/whitelist/%s", id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var w account.WhitelistEntry
+	resp, err := s.client.Do(req, &w)
+	if err != nil {
+		return nil, resp, whitelistError(err)
+	}
+
+	return &w, resp, nil
+}
+
+// Create takes a *WhitelistEntry and creates it. The entry's ID is set from
+// the response.
+//
+// NS1 API docs: https://ns1.com/api/#whitelist-put
+func (s *WhitelistService) Create(w *account.WhitelistEntry) (*http.Response, error) {
+	req, err := s.client.NewRequest("PUT", "account/whitelist", &w)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update whitelist entry fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &w)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Update takes a *WhitelistEntry and replaces its name and values, e.g. to
+// rotate the CIDR ranges of an office or VPN.
+//
+// NS1 API docs: https://ns1.com/api/#whitelist-id-post
+func (s *WhitelistService) Update(w *account.WhitelistEntry) (*http.Response, error) {
+	path := fmt.Sprintf("account/whitelist/%s", w.ID)
+
+	req, err := s.client.NewRequest("POST", path, &w)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update whitelist entry fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &w)
+	if err != nil {
+		return resp, whitelistError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes a whitelist entry id and deletes the entry.
+//
+// NS1 API docs: https://ns1.com/api/#whitelist-id-delete
+func (s *WhitelistService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("account/whitelist/%s", id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, whitelistError(err)
+	}
+
+	return resp, nil
+}
+
+func whitelistError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrWhitelistMissing
+	}
+	return err
+}
+
+var (
+	// ErrWhitelistMissing bundles GET/POST/DELETE error.
+	ErrWhitelistMissing = missingError("whitelist entry does not exist")
+)
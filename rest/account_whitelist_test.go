@@ -0,0 +1,81 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestWhitelist(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	office := &account.WhitelistEntry{ID: "w1", Name: "office", Values: []string{"192.0.2.0/24"}}
+
+	t.Run("List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/account/whitelist", http.StatusOK, nil, nil, "",
+			[]*account.WhitelistEntry{office}))
+		entries, _, err := client.Whitelist.List()
+		require.Nil(t, err)
+		require.Equal(t, []*account.WhitelistEntry{office}, entries)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/account/whitelist/w1", http.StatusOK, nil, nil, "", office))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/account/whitelist/w2", http.StatusNotFound, nil, nil, "",
+			`{"message": "whitelist not found"}`))
+
+		entry, _, err := client.Whitelist.Get("w1")
+		require.Nil(t, err)
+		require.Equal(t, office, entry)
+
+		_, _, err = client.Whitelist.Get("w2")
+		require.Equal(t, api.ErrWhitelistMissing, err)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		vpn := &account.WhitelistEntry{Name: "vpn", Values: []string{"198.51.100.7"}}
+		created := &account.WhitelistEntry{ID: "w3", Name: "vpn", Values: []string{"198.51.100.7"}}
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/account/whitelist", http.StatusOK, nil, nil, vpn, created))
+
+		_, err := client.Whitelist.Create(vpn)
+		require.Nil(t, err)
+		require.Equal(t, "w3", vpn.ID)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		rotated := &account.WhitelistEntry{ID: "w1", Name: "office", Values: []string{"203.0.113.0/24"}}
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/account/whitelist/w1", http.StatusOK, nil, nil, rotated, rotated))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/account/whitelist/w2", http.StatusNotFound, nil, nil,
+			&account.WhitelistEntry{ID: "w2"}, `{"message": "whitelist not found"}`))
+
+		_, err := client.Whitelist.Update(rotated)
+		require.Nil(t, err)
+
+		_, err = client.Whitelist.Update(&account.WhitelistEntry{ID: "w2"})
+		require.Equal(t, api.ErrWhitelistMissing, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "/account/whitelist/w1", http.StatusNoContent, nil, nil, "", ""))
+		_, err := client.Whitelist.Delete("w1")
+		require.Nil(t, err)
+	})
+}
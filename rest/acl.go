@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// ACLsService handles the DDI 'acls' endpoints.
+type ACLsService service
+
+// List returns all ACLs of the account.
+//
+// NS1 API docs: https://ns1.com/api#getlist-acls
+func (s *ACLsService) List(opts ...RequestOption) ([]*dns.ACL, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "acls", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	al := []*dns.ACL{}
+	resp, err := s.client.Do(req, &al)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return al, resp, nil
+}
+
+// Get takes an ACL name and returns the ACL.
+//
+// NS1 API docs: https://ns1.com/api#getview-acl-details
+func (s *ACLsService) Get(name string) (*dns.ACL, *http.Response, error) {
+	path := fmt.Sprintf("acls/%s", name)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var a dns.ACL
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return nil, resp, aclError(err)
+	}
+
+	return &a, resp, nil
+}
+
+// Create takes an *ACL and creates a new ACL.
+//
+// NS1 API docs: https://ns1.com/api#putcreate-an-acl
+func (s *ACLsService) Create(a *dns.ACL) (*http.Response, error) {
+	path := fmt.Sprintf("acls/%s", a.Name)
+
+	req, err := s.client.NewRequest("PUT", path, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update acl fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return resp, aclError(err)
+	}
+
+	return resp, nil
+}
+
+// Update takes an *ACL and replaces the configuration of the ACL.
+//
+// NS1 API docs: https://ns1.com/api#patchedit-an-acl
+func (s *ACLsService) Update(a *dns.ACL) (*http.Response, error) {
+	path := fmt.Sprintf("acls/%s", a.Name)
+
+	req, err := s.client.NewRequest("PATCH", path, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update acl fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return resp, aclError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes an ACL name and deletes the ACL. ACLs still referenced by a
+// view can not be deleted.
+//
+// NS1 API docs: https://ns1.com/api#deletedelete-an-acl
+func (s *ACLsService) Delete(name string) (*http.Response, error) {
+	path := fmt.Sprintf("acls/%s", name)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, aclError(err)
+	}
+
+	return resp, nil
+}
+
+// aclError maps API errors to ErrACLExists and ErrACLMissing.
+func aclError(err error) error {
+	if e, ok := err.(*Error); ok {
+		switch e.statusCode() {
+		case http.StatusConflict:
+			return ErrACLExists
+		case http.StatusNotFound:
+			return ErrACLMissing
+		}
+	}
+	return err
+}
+
+var (
+	// ErrACLExists bundles PUT create error.
+	ErrACLExists = existsError("acl already exists")
+	// ErrACLMissing bundles GET/PATCH/DELETE error.
+	ErrACLMissing = missingError("acl does not exist")
+)
@@ -0,0 +1,56 @@
+package rest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestACL(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	office := dns.NewACL("office", "10.0.0.0/8", "192.0.2.1")
+
+	t.Run("Create and List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/acls/office", http.StatusOK, nil, nil, office, office))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/acls", http.StatusOK, nil, nil, "", []*dns.ACL{office}))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/acls/dup", http.StatusConflict, nil, nil,
+			dns.NewACL("dup"), `{"message": "acl already exists"}`))
+
+		_, err := client.ACLs.Create(dns.NewACL("office", "10.0.0.0/8", "192.0.2.1"))
+		require.Nil(t, err)
+
+		al, _, err := client.ACLs.List()
+		require.Nil(t, err)
+		require.Equal(t, []*dns.ACL{office}, al)
+
+		_, err = client.ACLs.Create(dns.NewACL("dup"))
+		require.Equal(t, api.ErrACLExists, err)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/acls/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "acl not found"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "/acls/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "acl not found"}`))
+
+		_, _, err := client.ACLs.Get("gone")
+		require.Equal(t, api.ErrACLMissing, err)
+		require.True(t, errors.Is(err, api.ErrNotFound))
+		_, err = client.ACLs.Delete("gone")
+		require.Equal(t, api.ErrACLMissing, err)
+	})
+}
@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
+)
+
+// alertsPath is the path of the alerts, relative to the API version: the
+// alerting API has its own, beside the endpoint's.
+const alertsPath = "../alerting/v1/alerts"
+
+// AlertsService handles 'alerting/v1/alerts' endpoint.
+type AlertsService service
+
+// alertList is a page of the alerts listing.
+type alertList struct {
+	Results []*alerting.Alert `json:"results"`
+	Next    string            `json:"next"`
+}
+
+// List returns all alerts of the account, reading every page of the
+// listing.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-get
+func (s *AlertsService) List(opts ...RequestOption) ([]*alerting.Alert, *http.Response, error) {
+	al := []*alerting.Alert{}
+	next := ""
+	for {
+		pageOpts := opts
+		if next != "" {
+			pageOpts = append(append([]RequestOption(nil), opts...), WithParam("next", next))
+		}
+		req, err := s.client.NewRequest("GET", alertsPath, nil, pageOpts...)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var page alertList
+		resp, err := s.client.Do(req, &page)
+		if err != nil {
+			return nil, resp, err
+		}
+		al = append(al, page.Results...)
+		if page.Next == "" || page.Next == next {
+			return al, resp, nil
+		}
+		next = page.Next
+	}
+}
+
+// Get takes an alert id and returns the alert.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-id-get
+func (s *AlertsService) Get(id string) (*alerting.Alert, *http.Response, error) {
+	path := fmt.Sprintf("%s/%s", alertsPath, id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var a alerting.Alert
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return nil, resp, alertError(err)
+	}
+
+	return &a, resp, nil
+}
+
+// Create takes an *Alert and creates it. The alert's ID and the fields set
+// by NS1 are set from the response.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-post
+func (s *AlertsService) Create(a *alerting.Alert) (*http.Response, error) {
+	req, err := s.client.NewRequest("POST", alertsPath, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update alert fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			if err.(*Error).statusCode() == http.StatusConflict {
+				return resp, ErrAlertExists
+			}
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Update takes an *Alert and modifies the alert, e.g. its zones or notifier
+// lists. Its Type and Subtype can't be changed.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-id-patch
+func (s *AlertsService) Update(a *alerting.Alert) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%s", alertsPath, a.ID)
+
+	req, err := s.client.NewRequest("PATCH", path, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update alert fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return resp, alertError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes an alert id and deletes the alert.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-id-delete
+func (s *AlertsService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%s", alertsPath, id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, alertError(err)
+	}
+
+	return resp, nil
+}
+
+// Test takes an alert id and sends a test notification to the alert's
+// notifier lists, e.g. to check them after setting the alert up.
+//
+// NS1 API docs: https://ns1.com/api/#alerts-id-test-post
+func (s *AlertsService) Test(id string) (*http.Response, error) {
+	path := fmt.Sprintf("%s/%s/test", alertsPath, id)
+
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, alertError(err)
+	}
+
+	return resp, nil
+}
+
+func alertError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrAlertMissing
+	}
+	return err
+}
+
+var (
+	// ErrAlertExists bundles POST create error.
+	ErrAlertExists = existsError("alert already exists")
+	// ErrAlertMissing bundles GET/PATCH/DELETE error.
+	ErrAlertMissing = missingError("alert does not exist")
+)
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/alerting"
+)
+
+func TestAlerts(t *testing.T) {
+	var (
+		created   map[string]interface{}
+		createErr error
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.RequestURI() {
+		case "GET /alerting/v1/alerts":
+			w.Write([]byte(`{"results": [{"id": "a1", "type": "zone", "subtype": "transfer_failed"}], "next": "c2"}`))
+		case "GET /alerting/v1/alerts?next=c2":
+			w.Write([]byte(`{"results": [{"id": "a2", "type": "account", "subtype": "query_usage", "data": {"alert_at_percent": 80}}]}`))
+		case "GET /alerting/v1/alerts/a1":
+			w.Write([]byte(`{"id": "a1", "name": "xfr", "type": "zone", "subtype": "transfer_failed", "zone_names": ["example.com"], "created_at": 1600000000}`))
+		case "POST /alerting/v1/alerts":
+			b, _ := ioutil.ReadAll(r.Body)
+			// Asserted by the test goroutine: require cannot stop it from here.
+			createErr = json.Unmarshal(b, &created)
+			if created["name"] == "dup" {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message": "alert already exists"}`))
+				return
+			}
+			created["id"] = "a3"
+			json.NewEncoder(w).Encode(created)
+		case "PATCH /alerting/v1/alerts/a1", "POST /alerting/v1/alerts/a1/test":
+			b, _ := ioutil.ReadAll(r.Body)
+			w.Write(b)
+		case "DELETE /alerting/v1/alerts/a1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "alert not found"}`))
+		}
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/v1/"))
+
+	alerts, _, err := client.Alerts.List()
+	require.Nil(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "a1", alerts[0].ID)
+	assert.Equal(t, 80, alerts[1].Data.AlertAtPercent)
+
+	a, _, err := client.Alerts.Get("a1")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com"}, a.ZoneNames)
+	assert.EqualValues(t, 1600000000, a.CreatedAt.Unix())
+
+	_, _, err = client.Alerts.Get("a9")
+	assert.Equal(t, ErrAlertMissing, err)
+
+	usage := alerting.NewUsageAlert("queries", alerting.SubtypeQueryUsage, 90, []string{"n1"})
+	_, err = client.Alerts.Create(usage)
+	require.Nil(t, err)
+	require.Nil(t, createErr)
+	assert.Equal(t, "a3", usage.ID)
+	assert.Equal(t, "account", created["type"])
+	assert.NotContains(t, created, "created_at")
+	assert.NotContains(t, created, "updated_at")
+	assert.Equal(t, map[string]interface{}{"alert_at_percent": float64(90)}, created["data"])
+
+	_, err = client.Alerts.Create(alerting.NewZoneAlert("dup", []string{"example.com"}, nil))
+	assert.Equal(t, ErrAlertExists, err)
+
+	a.ZoneNames = append(a.ZoneNames, "example.net")
+	_, err = client.Alerts.Update(a)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"example.com", "example.net"}, a.ZoneNames)
+
+	_, err = client.Alerts.Test("a1")
+	require.Nil(t, err)
+	_, err = client.Alerts.Delete("a1")
+	require.Nil(t, err)
+	_, err = client.Alerts.Delete("a9")
+	assert.Equal(t, ErrAlertMissing, err)
+}
@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"bytes"
+	"container/list"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// SetResponseCache makes the client revalidate GET requests it has seen
+// before instead of reading them again: the body of up to maxEntries
+// responses carrying an ETag or Last-Modified header is kept, by URL, and
+// the next GET of the URL is sent with If-None-Match / If-Modified-Since.
+// On a 304 Not Modified, Do decodes the kept body into v as for the
+// original response, and returns the 304 response, so callers can tell
+// nothing changed from its StatusCode. Other requests to a URL drop what is
+// kept for it, as they may change it. The least recently used entries are
+// evicted first, and entries are only used with the API key that read them.
+//
+// Whether this saves rate limit quota as well as transfers depends on the
+// API counting 304 responses. A maxEntries of 0 or less disables the cache.
+func SetResponseCache(maxEntries int) func(*Client) {
+	return func(c *Client) {
+		if maxEntries <= 0 {
+			c.cache = nil
+			return
+		}
+		c.cache = &responseCache{max: maxEntries, ll: list.New(), entries: map[string]*list.Element{}}
+	}
+}
+
+// responseCache is an LRU cache of response bodies for revalidation, safe
+// for concurrent use.
+type responseCache struct {
+	mu      sync.Mutex
+	max     int
+	ll      *list.List // of *cacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+// cacheEntry is a kept response of a URL.
+type cacheEntry struct {
+	url          string
+	apiKey       string
+	etag         string
+	lastModified string
+	contentType  string
+	body         []byte
+}
+
+// prepare returns the request to send for req, and the entry kept for it,
+// if any. With an entry, the request is a copy of req with the entry's
+// conditional headers added, so that req itself stays unconditional: sent
+// again once the entry is evicted, it must not get a 304 back.
+func (rc *responseCache) prepare(req *http.Request) (*http.Request, *cacheEntry) {
+	if rc == nil || req.Method != http.MethodGet {
+		return req, nil
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	el, ok := rc.entries[req.URL.String()]
+	if !ok {
+		return req, nil
+	}
+	e := el.Value.(*cacheEntry)
+	if e.apiKey != req.Header.Get(headerAuth) {
+		return req, nil
+	}
+	rc.ll.MoveToFront(el)
+	req = req.Clone(req.Context())
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+	return req, e
+}
+
+// handle updates the cache with resp, the response to req sent after
+// prepare returned kept. It reports whether resp is a 304 whose body was
+// replaced with the kept one.
+func (rc *responseCache) handle(req *http.Request, resp *http.Response, kept *cacheEntry) (bool, error) {
+	if rc == nil {
+		return false, nil
+	}
+	url := req.URL.String()
+	if req.Method != http.MethodGet {
+		rc.drop(url)
+		return false, nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && kept != nil:
+		resp.Body = ioutil.NopCloser(bytes.NewReader(kept.body))
+		resp.Header.Del("Content-Length")
+		if kept.contentType != "" {
+			resp.Header.Set("Content-Type", kept.contentType)
+		}
+		return true, nil
+	case resp.StatusCode != http.StatusOK:
+		return false, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		rc.drop(url)
+		return false, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	rc.put(&cacheEntry{
+		url:          url,
+		apiKey:       req.Header.Get(headerAuth),
+		etag:         etag,
+		lastModified: lastModified,
+		contentType:  resp.Header.Get("Content-Type"),
+		body:         body,
+	})
+	return false, nil
+}
+
+func (rc *responseCache) put(e *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if el, ok := rc.entries[e.url]; ok {
+		el.Value = e
+		rc.ll.MoveToFront(el)
+		return
+	}
+	rc.entries[e.url] = rc.ll.PushFront(e)
+	for rc.ll.Len() > rc.max {
+		oldest := rc.ll.Back()
+		rc.ll.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*cacheEntry).url)
+	}
+}
+
+func (rc *responseCache) drop(url string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if el, ok := rc.entries[url]; ok {
+		rc.ll.Remove(el)
+		delete(rc.entries, url)
+	}
+}
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestClient_SetResponseCache(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		version = "1"
+		full    int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPost {
+			version = "2"
+			w.Write([]byte(`{"zone": "example.com"}`))
+			return
+		}
+		etag := `"v` + version + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"zone": "example.com", "ttl": ` + version + `}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetAPIKey("key"), SetResponseCache(10))
+
+	get := func() (*dns.Zone, *http.Response) {
+		z, resp, err := client.Zones.Get("example.com")
+		require.Nil(t, err)
+		return z, resp
+	}
+
+	z, resp := get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, z.TTL)
+
+	// Unchanged: the kept body is decoded.
+	z, resp = get()
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, "example.com", z.Zone)
+	assert.Equal(t, 1, z.TTL)
+	assert.Equal(t, 1, full)
+
+	// An update drops the entry.
+	_, err := client.Zones.Update(&dns.Zone{Zone: "example.com"})
+	require.Nil(t, err)
+	z, resp = get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, z.TTL)
+
+	// Other API keys don't use the entries.
+	SetAPIKey("other")(client)
+	_, resp = get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, full)
+
+	SetResponseCache(0)(client)
+	_, resp = get()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestResponseCache_Eviction(t *testing.T) {
+	c := &Client{}
+	SetResponseCache(2)(c)
+	rc := c.cache
+
+	for _, u := range []string{"a", "b", "a", "c"} {
+		rc.put(&cacheEntry{url: u})
+	}
+	assert.Equal(t, 2, rc.ll.Len())
+	assert.Contains(t, rc.entries, "a")
+	assert.Contains(t, rc.entries, "c")
+	assert.NotContains(t, rc.entries, "b")
+}
+
+func TestResponseCache_ReusedRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"zone": "example.com"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetResponseCache(10))
+
+	req, err := client.NewRequest("GET", "zones/example.com", nil)
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = client.Do(req, &dns.Zone{})
+		require.Nil(t, err)
+	}
+	// The conditional headers went out on a copy, so once the entry is
+	// dropped the request fetches the zone again instead of getting a 304.
+	assert.Empty(t, req.Header.Get("If-None-Match"))
+	client.cache.drop(req.URL.String())
+	var z dns.Zone
+	resp, err := client.Do(req, &z)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "example.com", z.Zone)
+}
@@ -2,12 +2,19 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand"
+	"mime"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -31,7 +38,20 @@ type Doer interface {
 }
 
 // Client manages communication with the NS1 Rest API.
+//
+// A Client is safe for concurrent use once constructed. To change its
+// configuration while other goroutines use it, apply options with Configure
+// or call the RateLimitStrategy, SetRegion and transport methods; each
+// request is built and sent with the configuration as of when NewRequest or
+// Do was called, so a request in flight is unaffected. Assigning the
+// exported fields directly is only safe before the client is shared.
 type Client struct {
+	// Guards the configuration against concurrent changes, see Configure.
+	mu *sync.RWMutex
+
+	// Context of the requests built by NewRequest, see WithContext.
+	ctx context.Context
+
 	// httpClient handles all rest api communication,
 	// and expects an *http.Client.
 	httpClient Doer
@@ -39,21 +59,108 @@ type Client struct {
 	// NS1 rest endpoint, overrides default if given.
 	Endpoint *url.URL
 
+	// Endpoint of the DDI (IPAM, DHCP and ACL) requests if set, see
+	// SetDDIEndpoint.
+	DDIEndpoint *url.URL
+
+	// Errors of SetEndpoint and SetDDIEndpoint given an invalid URL,
+	// returned by NewRequest.
+	endpointErr    error
+	ddiEndpointErr error
+
 	// NS1 api key (value for http request header 'X-NSONE-Key').
 	APIKey string
 
+	// Provider of the api key, taking precedence over APIKey, see
+	// SetAPIKeyFunc.
+	apiKeyFunc func() string
+
+	// ID of the api key, identifying it for CurrentPermissions, see
+	// SetAPIKeyID.
+	apiKeyID string
+
 	// NS1 go rest user agent (value for http request header 'User-Agent').
 	UserAgent string
 
-	// Func to call after response is returned in Do
+	// Func to call after response is returned in Do. It is not called while
+	// RateLimitContextFunc is set, as RateLimitStrategySleep and
+	// RateLimitStrategyConcurrent do: use SetRateLimitFunc, which clears
+	// it, or clear it too when assigning this field directly.
 	RateLimitFunc func(RateLimit)
 
+	// Context-aware func to call after response is returned in Do, with the
+	// request's context; if set, it is called instead of RateLimitFunc.
+	RateLimitContextFunc func(context.Context, RateLimit)
+
+	// Limiter to acquire from before each request is sent in Do.
+	SharedLimiter SharedLimiter
+
+	// Whether Do refuses to send requests without a rate limit strategy, see
+	// RequireRateLimitStrategy.
+	requireRateLimitStrategy bool
+
+	// Policy for retrying failed requests in Do, none if nil.
+	Retry *RetryPolicy
+
+	// Bound on each attempt of a request in Do, none if 0, see SetTimeout.
+	Timeout time.Duration
+
+	// Whether requests ask for compressed responses and compress large
+	// bodies, see SetCompression.
+	Compression bool
+
+	// Whether requests never ask for compressed responses unless
+	// Compression is set, see SetResponseCompression.
+	noResponseGzip bool
+
+	// Whether the Retry policy also retries successful responses whose body
+	// is truncated or malformed JSON, see SetRetryOnDecodeError.
+	RetryOnDecodeError bool
+
+	// Headers added to every request, see SetDefaultHeaders.
+	defaultHeaders http.Header
+
+	// Destination of the client's log output, the standard logger by
+	// default.
+	Logger Logger
+
 	// Whether the client should handle paginated responses automatically.
 	FollowPagination bool
 
 	// Enables permissions compatibility with the DDI API.
 	DDI bool
 
+	// Request counters, see RequestStats.
+	counters *requestCounters
+
+	// Rate limit of the latest response, see LastRateLimit.
+	lastRate *lastRateLimit
+
+	// Date of the latest response, see ServerTime.
+	clock *serverClock
+
+	// Cached monitoring catalogs, see JobsService.Catalogs.
+	catalogs *catalogCache
+
+	// Cached permissions of APIKey, see APIKeysService.CurrentPermissions.
+	permissions *permissionsCache
+
+	// Kept responses for conditional requests, see SetResponseCache.
+	cache *responseCache
+
+	// Hooks around each round trip, see SetOnRequest and SetOnResponse.
+	onRequest  func(*http.Request)
+	onResponse func(*http.Request, *http.Response, time.Duration, error)
+
+	// Decorators of httpClient for each round trip, see SetMiddleware.
+	middleware []Decorator
+
+	// Source of a span for each call of Do, see SetTracer.
+	tracer Tracer
+
+	// Sink for request/response pairs, see SetRecorder.
+	recorder *recorder
+
 	// From the excellent github-go client.
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
@@ -65,17 +172,32 @@ type Client struct {
 	Notifications *NotificationsService
 	Records       *RecordsService
 	Settings      *SettingsService
+	Plan          *PlanService
 	Stats         *StatsService
 	Teams         *TeamsService
 	Users         *UsersService
 	Warnings      *WarningsService
+	Activity      *ActivityService
+	Whitelist     *WhitelistService
 	Zones         *ZonesService
+	ZoneVersions  *ZoneVersionsService
 	DNSSEC        *DNSSECService
 	IPAM          *IPAMService
 	ScopeGroup    *ScopeGroupService
 	Scope         *ScopeService
 	Reservation   *ReservationService
+	Lease         *LeaseService
 	OptionDef     *OptionDefService
+	Views         *ViewsService
+	ACLs          *ACLsService
+	Search        *SearchService
+	TSIG          *TSIGService
+	Applications  *ApplicationsService
+	PulsarJobs    *PulsarJobsService
+	Redirects     *RedirectService
+	RedirectCerts *RedirectCertificateService
+	Datasets      *DatasetsService
+	Alerts        *AlertsService
 }
 
 // NewClient constructs and returns a reference to an instantiated Client.
@@ -87,13 +209,35 @@ func NewClient(httpClient Doer, options ...func(*Client)) *Client {
 	}
 
 	c := &Client{
+		mu:               &sync.RWMutex{},
 		httpClient:       httpClient,
 		Endpoint:         endpoint,
 		RateLimitFunc:    defaultRateLimitFunc,
+		SharedLimiter:    noopSharedLimiter{},
+		Logger:           stdLogger{},
+		counters:         &requestCounters{},
+		lastRate:         &lastRateLimit{},
+		clock:            &serverClock{},
+		catalogs:         &catalogCache{ttl: defaultCatalogTTL},
+		permissions:      &permissionsCache{},
 		UserAgent:        defaultUserAgent,
 		FollowPagination: defaultShouldFollowPagination,
 	}
 
+	c.registerServices()
+
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+type service struct {
+	client *Client
+}
+
+// registerServices points the client's services at c.
+func (c *Client) registerServices() {
 	c.common.client = c
 	c.APIKeys = (*APIKeysService)(&c.common)
 	c.DataFeeds = (*DataFeedsService)(&c.common)
@@ -102,26 +246,92 @@ func NewClient(httpClient Doer, options ...func(*Client)) *Client {
 	c.Notifications = (*NotificationsService)(&c.common)
 	c.Records = (*RecordsService)(&c.common)
 	c.Settings = (*SettingsService)(&c.common)
+	c.Plan = (*PlanService)(&c.common)
 	c.Stats = (*StatsService)(&c.common)
 	c.Teams = (*TeamsService)(&c.common)
 	c.Users = (*UsersService)(&c.common)
 	c.Warnings = (*WarningsService)(&c.common)
+	c.Activity = (*ActivityService)(&c.common)
+	c.Whitelist = (*WhitelistService)(&c.common)
 	c.Zones = (*ZonesService)(&c.common)
+	c.ZoneVersions = (*ZoneVersionsService)(&c.common)
 	c.DNSSEC = (*DNSSECService)(&c.common)
 	c.IPAM = (*IPAMService)(&c.common)
 	c.ScopeGroup = (*ScopeGroupService)(&c.common)
 	c.Scope = (*ScopeService)(&c.common)
 	c.Reservation = (*ReservationService)(&c.common)
+	c.Lease = (*LeaseService)(&c.common)
 	c.OptionDef = (*OptionDefService)(&c.common)
+	c.Views = (*ViewsService)(&c.common)
+	c.ACLs = (*ACLsService)(&c.common)
+	c.Search = (*SearchService)(&c.common)
+	c.TSIG = (*TSIGService)(&c.common)
+	c.Applications = (*ApplicationsService)(&c.common)
+	c.PulsarJobs = (*PulsarJobsService)(&c.common)
+	c.Redirects = (*RedirectService)(&c.common)
+	c.RedirectCerts = (*RedirectCertificateService)(&c.common)
+	c.Datasets = (*DatasetsService)(&c.common)
+	c.Alerts = (*AlertsService)(&c.common)
+}
 
+// WithContext returns a copy of the client whose requests, including those
+// of its services, are bound to ctx: cancelling ctx or reaching its deadline
+// aborts them, and ends their rate limit waits early.
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+//	defer cancel()
+//	zone, _, err := client.WithContext(ctx).Zones.Get("example.com")
+//
+// The copy is cheap, meant to be made per operation. It has the client's
+// configuration as of the call and shares its request statistics, rate
+// limit state and caches; later changes to either client's configuration do
+// not affect the other.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	copied := c.snapshot()
+	copied.mu = &sync.RWMutex{}
+	copied.ctx = ctx
+	copied.registerServices()
+	return &copied
+}
+
+// Configure applies options to a client that may be in use by other
+// goroutines, e.g. Configure(SetAPIKey(key), SetRetry(3, time.Second)) on a
+// long-lived server's client. The options are applied together, so
+// concurrent requests see either none or all of them. Options must not
+// themselves call Configure or the methods reconfiguring the client.
+func (c *Client) Configure(options ...func(*Client)) {
+	c.lock()
+	defer c.unlock()
 	for _, option := range options {
 		option(c)
 	}
-	return c
 }
 
-type service struct {
-	client *Client
+// snapshot returns a copy of the client's configuration, consistent with
+// any concurrent Configure.
+func (c *Client) snapshot() Client {
+	if c.mu != nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+	}
+	return *c
+}
+
+// lock and unlock guard a change of configuration; the zero Client has no
+// lock.
+func (c *Client) lock() {
+	if c.mu != nil {
+		c.mu.Lock()
+	}
+}
+
+func (c *Client) unlock() {
+	if c.mu != nil {
+		c.mu.Unlock()
+	}
 }
 
 // SetHTTPClient sets a Client instances' httpClient.
@@ -134,9 +344,62 @@ func SetAPIKey(key string) func(*Client) {
 	return func(c *Client) { c.APIKey = key }
 }
 
-// SetEndpoint sets a Client instances' Endpoint.
+// SetAPIKeyID sets the id of the client's API key, as shown in the portal
+// and returned when the key is created. APIKeysService.CurrentPermissions
+// reads the key's permissions by this id: the API identifies keys by id, and
+// never returns the secret of an existing key to match it against.
+func SetAPIKeyID(id string) func(*Client) {
+	return func(c *Client) { c.apiKeyID = id }
+}
+
+// SetAPIKeyFunc makes the client call keyFunc for the api key each time it
+// builds a request, in place of the static APIKey, so a long-lived client
+// picks up keys rotated e.g. by a secrets manager. keyFunc is called from
+// concurrent requests and should return quickly, such as by returning a
+// cached key refreshed elsewhere. Keys set per request with WithAPIKey still
+// take precedence. A nil keyFunc restores the use of APIKey.
+func SetAPIKeyFunc(keyFunc func() string) func(*Client) {
+	return func(c *Client) { c.apiKeyFunc = keyFunc }
+}
+
+// apiKey returns the api key to send, from the key function if one is set.
+func (c Client) apiKey() string {
+	if c.apiKeyFunc != nil {
+		return c.apiKeyFunc()
+	}
+	return c.APIKey
+}
+
+// SetEndpoint sets a Client instances' Endpoint, the base URL request paths
+// are resolved against. A slash is added to the end of the endpoint's path
+// if it has none, so "https://host/v1" and "https://host/v1/" both resolve
+// "zones" to "https://host/v1/zones". To change only the API version, see
+// SetAPIVersion.
+//
+// If the endpoint can't be parsed, the endpoint is left unchanged and
+// NewRequest fails with an error matching ErrInvalidEndpoint until a valid
+// one is set; use ParseEndpoint to validate and normalize an endpoint taken
+// from configuration up front.
 func SetEndpoint(endpoint string) func(*Client) {
-	return func(c *Client) { c.Endpoint, _ = url.Parse(endpoint) }
+	return func(c *Client) {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			c.endpointErr = fmt.Errorf("%w %q: %v", ErrInvalidEndpoint, endpoint, err)
+			return
+		}
+		addSlash(u)
+		c.Endpoint, c.endpointErr = u, nil
+	}
+}
+
+// addSlash adds a slash to the end of u's path if it has none.
+func addSlash(u *url.URL) {
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
 }
 
 // SetUserAgent sets a Client instances' user agent.
@@ -144,9 +407,112 @@ func SetUserAgent(ua string) func(*Client) {
 	return func(c *Client) { c.UserAgent = ua }
 }
 
-// SetRateLimitFunc sets a Client instances' RateLimitFunc.
+// SetDefaultHeaders makes the client add the given headers to every request
+// it builds, e.g. the token of an authenticating gateway in front of a DDI
+// deployment, replacing the client's own value of any header given. Headers
+// set per request with WithHeader take precedence. Recordings (see
+// SetRecorder) redact the values of headers whose names suggest
+// credentials, such as Authorization or X-Gateway-Token.
+func SetDefaultHeaders(h http.Header) func(*Client) {
+	return func(c *Client) {
+		c.defaultHeaders = make(http.Header, len(h))
+		for k, v := range h {
+			c.defaultHeaders[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+}
+
+// SetRateLimitFunc sets a Client instances' RateLimitFunc, replacing any
+// RateLimitContextFunc.
 func SetRateLimitFunc(ratefunc func(rl RateLimit)) func(*Client) {
-	return func(c *Client) { c.RateLimitFunc = ratefunc }
+	return func(c *Client) {
+		c.RateLimitFunc = ratefunc
+		c.RateLimitContextFunc = nil
+	}
+}
+
+// SetRateLimitContextFunc sets a Client instances' RateLimitContextFunc,
+// which is passed the request's context so waits can end early when it is
+// done.
+func SetRateLimitContextFunc(ratefunc func(ctx context.Context, rl RateLimit)) func(*Client) {
+	return func(c *Client) { c.RateLimitContextFunc = ratefunc }
+}
+
+// SetSharedLimiter sets a SharedLimiter to coordinate requests with other
+// clients sharing the same quota. A nil limiter restores the no-op default.
+func SetSharedLimiter(l SharedLimiter) func(*Client) {
+	if l == nil {
+		l = noopSharedLimiter{}
+	}
+	return func(c *Client) { c.SharedLimiter = l }
+}
+
+// RequireRateLimitStrategy makes Do fail with ErrNoRateLimitStrategy until
+// a rate limit strategy is set, i.e. a RateLimitFunc or RateLimitContextFunc
+// other than the default, or a SharedLimiter. By default the client does not
+// pace its requests at all, so a busy program exhausts the account's quota
+// and then gets 429 responses; this catches a forgotten
+// RateLimitStrategySleep (or similar) the first time a request is made. The
+// check is in Do because strategies are usually set after NewClient.
+func RequireRateLimitStrategy() func(*Client) {
+	return func(c *Client) { c.requireRateLimitStrategy = true }
+}
+
+// hasRateLimitStrategy reports whether the client's rate limiting differs
+// from the no-op default.
+func (c Client) hasRateLimitStrategy() bool {
+	if c.RateLimitContextFunc != nil {
+		return true
+	}
+	if _, noop := c.SharedLimiter.(noopSharedLimiter); !noop && c.SharedLimiter != nil {
+		return true
+	}
+	return c.RateLimitFunc != nil &&
+		reflect.ValueOf(c.RateLimitFunc).Pointer() != reflect.ValueOf(defaultRateLimitFunc).Pointer()
+}
+
+// SetTimeout bounds each attempt of a request in Do to d, whatever the Doer:
+// the attempt's context gets a deadline d from its start (within any
+// deadline of the request's own context), which cancels the round trip if
+// it has not completed, including reading the response body. The bound
+// covers the whole attempt, i.e. also waiting for a SharedLimiter and the
+// rate limit strategy's sleep after the response, so allow for those when
+// choosing d. A Retry policy's backoff between attempts is not included.
+// When the timeout fires, Do returns an error matching ErrRequestTimeout
+// (and context.DeadlineExceeded) via errors.Is. A d of 0 removes the bound.
+func SetTimeout(d time.Duration) func(*Client) {
+	return func(c *Client) { c.Timeout = d }
+}
+
+// SetOnRequest sets a hook that Do calls before each attempt of a request is
+// sent (once rate limiting let it through), e.g. to start a tracing span or
+// count requests. A nil hook removes it. A panic of the hook is recovered
+// and logged.
+func SetOnRequest(hook func(req *http.Request)) func(*Client) {
+	return func(c *Client) { c.onRequest = hook }
+}
+
+// SetOnResponse sets a hook that Do calls after each attempt of a request,
+// with the response, the round trip's duration and the transport error, if
+// any, in which case resp is nil. It is called for every status, before the
+// response is checked for errors and its rate limit headers parsed, and
+// must not consume resp.Body. A nil hook removes it. As with SetOnRequest,
+// a panic of the hook is recovered and logged.
+func SetOnResponse(hook func(req *http.Request, resp *http.Response, took time.Duration, err error)) func(*Client) {
+	return func(c *Client) { c.onResponse = hook }
+}
+
+// SetMiddleware sets Decorators that wrap the client's Doer for each attempt
+// of a request in Do, e.g. to add headers, capture metrics or audit
+// requests, without replacing the Doer given to NewClient or SetHTTPClient.
+// They are applied in order: the first one sees each request first and its
+// response last. Unlike the SetOnRequest and SetOnResponse hooks they may
+// alter or short-circuit the round trip; a panic fails the attempt with an
+// error. Calling it again replaces the middleware, and no Decorators remove
+// it.
+func SetMiddleware(ds ...Decorator) func(*Client) {
+	ds = append([]Decorator(nil), ds...)
+	return func(c *Client) { c.middleware = ds }
 }
 
 // SetFollowPagination sets a Client instances' FollowPagination attribute.
@@ -159,32 +525,297 @@ func SetDDIAPI() func(*Client) {
 	return func(c *Client) { c.DDI = true }
 }
 
+// DoWithContext is Do with req bound to ctx: cancelling ctx or reaching its
+// deadline aborts the round trip, and ends any rate limit wait early.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	return c.Do(req.WithContext(ctx), v)
+}
+
 // Do satisfies the Doer interface. resp will be nil if a non-HTTP error
 // occurs, otherwise it is available for inspection when the error reflects a
-// non-2XX response.
-func (c Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.httpClient.Do(req)
+// non-2XX response. The request's context applies to the round trip and to
+// the rate limit strategy, see DoWithContext. With a Retry policy, failed
+// attempts may be retried before returning, see RetryPolicy.
+//
+// The body of a successful response is decoded as JSON into v, or copied as
+// is if v is an io.Writer, e.g. a *bytes.Buffer for zone file exports or CSV
+// reports. A JSON body that fails to decode is kept in the returned
+// *DecodeError.
+//
+// Do and the other Do methods take a *Client so that they read the client's
+// configuration under its lock while Configure may change it; a Client value
+// does not satisfy Doer.
+func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	return c.snapshot().send(req, v)
+}
+
+// DoWithOptions is Do with opts applied to a copy of req, as if given to
+// NewRequest, e.g. WithTimeout for a call with its own latency budget. req
+// itself is left as is, and can be sent again with other options.
+func (c *Client) DoWithOptions(req *http.Request, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	r := req.WithContext(req.Context())
+	u := *req.URL
+	r.URL = &u
+	r.Header = make(http.Header, len(req.Header))
+	for k, vs := range req.Header {
+		r.Header[k] = append([]string(nil), vs...)
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return c.Do(r, v)
+}
+
+// send is Do with the configuration c.
+func (c Client) send(req *http.Request, v interface{}) (*http.Response, error) {
+	if c.requireRateLimitStrategy && !c.hasRateLimitStrategy() {
+		return nil, ErrNoRateLimitStrategy
+	}
+	if c.tracer != nil {
+		return c.traced(req, v)
+	}
+	if d, ok := req.Context().Value(callTimeoutKey{}).(time.Duration); ok && d > 0 {
+		return c.sendWithin(req, v, d)
+	}
+	return c.sendAttempts(req, v)
+}
+
+// sendWithin is send bounded by the timeout of WithTimeout.
+func (c Client) sendWithin(req *http.Request, v interface{}, d time.Duration) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+	defer cancel()
+	resp, err := c.sendAttempts(req.WithContext(ctx), v)
+	if err != nil && ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+		return resp, &timeoutError{timeout: d, err: err}
+	}
+	return resp, err
+}
+
+// sendAttempts makes the attempts of send, retrying as the Retry policy
+// allows.
+func (c Client) sendAttempts(req *http.Request, v interface{}) (*http.Response, error) {
+	if c.Retry == nil || !c.Retry.allows(req.Method) {
+		return c.do(req, v, false)
+	}
+	return c.doWithRetry(req, v)
+}
+
+// DoWithRate is Do, also returning the rate limit reported by the response
+// (after any retries, the last one), e.g. to decide whether to batch the
+// next operation. The RateLimitFunc is called as with Do. The RateLimit is
+// zero if there is no response, i.e. on transport or decoding errors.
+func (c *Client) DoWithRate(req *http.Request, v interface{}) (*http.Response, RateLimit, error) {
+	resp, err := c.Do(req, v)
+	if resp == nil {
+		return resp, RateLimit{}, err
+	}
+	return resp, parseRate(resp), err
+}
+
+// do makes a single attempt of Do within the client's Timeout; retry tells
+// whether it is a retry of an earlier attempt.
+func (c Client) do(req *http.Request, v interface{}, retry bool) (*http.Response, error) {
+	if c.Timeout <= 0 {
+		return c.attempt(req, v, retry)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.Timeout)
+	defer cancel()
+	resp, err := c.attempt(req.WithContext(ctx), v, retry)
+	if err != nil && ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+		return resp, &timeoutError{timeout: c.Timeout, err: err}
+	}
+	return resp, err
+}
+
+// attempt is do without the Timeout.
+func (c Client) attempt(req *http.Request, v interface{}, retry bool) (*http.Response, error) {
+	if c.SharedLimiter != nil {
+		start := time.Now()
+		err := c.SharedLimiter.Acquire(req.Context())
+		c.counters.waited(time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var reqBody []byte
+	if c.recorder != nil {
+		reqBody = requestBody(req)
+	}
+
+	req, kept := c.cache.prepare(req)
+	c.counters.attempt(retry)
+	countAttempt(req)
+	if c.onRequest != nil {
+		c.guard("request hook", func() { c.onRequest(req) })
+	}
+	h := c.counters.histogram()
+	ll, leveled := c.Logger.(LeveledLogger)
+	timed := h != nil || c.onResponse != nil || leveled
+	var sent time.Time
+	if timed {
+		sent = time.Now()
+	}
+	resp, err := c.roundTrip(req)
+	if timed {
+		took := time.Since(sent)
+		if h != nil {
+			h.observe(took)
+		}
+		if leveled {
+			c.guard("logger", func() { c.logRoundTrip(ll, req, resp, took, err) })
+		}
+		if c.onResponse != nil {
+			c.guard("response hook", func() { c.onResponse(req, resp, took, err) })
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	decompressResponse(resp)
 	defer resp.Body.Close()
 
+	if c.recorder != nil {
+		// Recording is best-effort: a failing writer does not fail the
+		// request.
+		if err := c.recorder.record(req, reqBody, resp); err != nil {
+			c.logf("ns1: recording %s %s: %v", req.Method, req.URL, err)
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.counters.rateLimitResponse()
+	}
+
+	c.clock.observe(resp)
 	rl := parseRate(resp)
-	c.RateLimitFunc(rl)
+	hasRate := resp.Header.Get(headerRateRemaining) != ""
+	if hasRate {
+		c.lastRate.set(rl)
+	}
+	if hasRate || rl.RetryAfter > 0 {
+		if o, ok := c.SharedLimiter.(RateObserver); ok {
+			o.Observe(rl)
+		}
+	}
+	start := time.Now()
+	c.callRateLimitFunc(req.Context(), rl)
+	took := time.Since(start)
+	c.counters.waited(took)
+	if w, ok := req.Context().Value(rateWaitKey{}).(*time.Duration); ok {
+		*w = took
+	}
 
-	err = CheckResponse(resp)
+	notModified, err := c.cache.handle(req, resp, kept)
 	if err != nil {
-		return resp, err
+		return nil, err
+	}
+	if !notModified {
+		if err := CheckResponse(resp); err != nil {
+			return resp, err
+		}
 	}
 
 	if v != nil {
-		// Try to unmarshal body into given type using streaming decoder.
-		if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return decodeBody(resp, v)
+	}
+
+	return resp, err
+}
+
+// roundTrip sends req through the middleware and the HTTP client. A panic
+// of either fails the attempt with an error rather than the calling
+// goroutine.
+func (c Client) roundTrip(req *http.Request) (resp *http.Response, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logf("ns1: recovered from panic in middleware: %v", r)
+			resp, err = nil, fmt.Errorf("ns1: panic in middleware: %v", r)
+		}
+	}()
+	return c.doer().Do(req)
+}
+
+// doer returns httpClient wrapped in the middleware, the first outermost.
+func (c Client) doer() Doer {
+	d := c.httpClient
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
+
+// decodeBody decodes the body of a successful response into v: copied as is
+// if v is an io.Writer, and decoded as JSON otherwise. A body of another
+// declared content type that is not JSON either yields a *ContentTypeError.
+// An empty body, e.g. of a 204 No Content, leaves v untouched.
+func decodeBody(resp *http.Response, v interface{}) (*http.Response, error) {
+	if resp.StatusCode == http.StatusNoContent || resp.Header.Get("Content-Length") == "0" {
+		return resp, nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		if _, err := io.Copy(w, resp.Body); err != nil {
 			return nil, err
 		}
+		return resp, nil
 	}
 
-	return resp, err
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !isJSONContentType(ct) {
+		// Servers and proxies may label JSON as text, so only fail if the
+		// body really isn't JSON.
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			return resp, nil
+		}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return resp, &ContentTypeError{Resp: resp, ContentType: ct, Body: body}
+		}
+		return resp, nil
+	}
+
+	// Try to unmarshal body into given type using streaming decoder, keeping
+	// what it reads for a DecodeError.
+	// io.EOF means there was no value at all, i.e. the body was empty.
+	read := &cappedBuffer{max: maxDecodeErrorBody}
+	if err := json.NewDecoder(io.TeeReader(resp.Body, read)).Decode(&v); err != nil && err != io.EOF {
+		io.Copy(read, resp.Body) // nolint: errcheck
+		return nil, &DecodeError{Resp: resp, Body: read.Bytes(), Err: err}
+	}
+	return resp, nil
+}
+
+// maxDecodeErrorBody is the most of a response body kept by a DecodeError.
+const maxDecodeErrorBody = 1 << 20
+
+// cappedBuffer is a bytes.Buffer discarding what is written past max bytes.
+type cappedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room < len(p) {
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// isJSONContentType reports whether the media type ct is JSON, e.g.
+// application/json or application/problem+json.
+func isJSONContentType(ct string) bool {
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
 }
 
 // NextFunc knows how to get and parse additional info from uri into v.
@@ -194,14 +825,14 @@ type NextFunc func(v *interface{}, uri string) (*http.Response, error)
 // Response is from the last URI visited - either the last page, or one that
 // responded with a non-2XX status. If a non-HTTP error occurs, resp will be
 // nil.
-func (c Client) DoWithPagination(req *http.Request, v interface{}, f NextFunc) (*http.Response, error) {
+func (c *Client) DoWithPagination(req *http.Request, v interface{}, f NextFunc) (*http.Response, error) {
 	resp, err := c.Do(req, v)
 	if err != nil {
 		return resp, err
 	}
 
 	// See PLAT-188
-	forceHTTPS := c.Endpoint.Scheme == "https"
+	forceHTTPS := c.snapshot().Endpoint.Scheme == "https"
 
 	nextURI := ParseLink(resp.Header.Get("Link"), forceHTTPS).Next()
 	for nextURI != "" {
@@ -214,51 +845,246 @@ func (c Client) DoWithPagination(req *http.Request, v interface{}, f NextFunc) (
 	return resp, nil
 }
 
-// NewRequest constructs and returns a http.Request.
-func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+// CloseIdleConnections closes any idle keep-alive connections held by the
+// underlying http client, e.g. before a known idle period or on shutdown.
+// It delegates to Doers that implement CloseIdleConnections (as
+// *http.Client does), and is a no-op otherwise.
+func (c *Client) CloseIdleConnections() {
+	type idleCloser interface {
+		CloseIdleConnections()
+	}
+	if ic, ok := c.snapshot().httpClient.(idleCloser); ok {
+		ic.CloseIdleConnections()
+	}
+}
+
+// RequestOption modifies a single request built by NewRequest, without
+// touching the shared Client.
+type RequestOption func(*http.Request)
+
+// ForNetwork scopes a request to the DDI network with the given id, e.g. to
+// read or write the network-specific variant of a record. Without it,
+// requests apply to all networks.
+func ForNetwork(id int) RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		q.Set("networks", strconv.Itoa(id))
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// WithParams adds query parameters to a request, percent-encoded, e.g. to
+// filter a list. They are merged with any query already in the request's
+// path, replacing parameters of the same name.
+func WithParams(params url.Values) RequestOption {
+	return func(req *http.Request) {
+		q := req.URL.Query()
+		for k, vs := range params {
+			q[k] = append([]string(nil), vs...)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// WithAPIKey overrides the client's APIKey for a single request, e.g. for a
+// one-off call as another tenant. The shared Client is left untouched.
+func WithAPIKey(key string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(headerAuth, key)
+	}
+}
+
+// WithHeader sets a header on a single request, replacing any value set by
+// the client, including its default headers.
+func WithHeader(key, value string) RequestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+// callTimeoutKey is the context key of the timeout of WithTimeout.
+type callTimeoutKey struct{}
+
+// WithTimeout bounds a single call of Do to d, e.g. for a caller with a
+// tighter latency budget than the others sharing the client. Unlike
+// SetTimeout, which bounds each attempt, the bound covers the whole call:
+// all attempts of a Retry policy, the backoff between them, and the rate
+// limit strategy's sleeps. When it fires, Do returns an error matching
+// ErrRequestTimeout (and context.DeadlineExceeded) via errors.Is. A d of 0
+// removes the bound. Like any option, it changes the request it is applied
+// to; use DoWithOptions to bound a call of a request built elsewhere.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), callTimeoutKey{}, d))
+	}
+}
+
+// NewRequest constructs and returns a http.Request. A non-nil body is sent
+// as JSON: an io.Reader, []byte or json.RawMessage is sent as is, e.g. JSON
+// that is already serialized or streamed, and any other value is encoded.
+// Any opts are applied to the request after the default headers are set. The
+// request is bound to the client's context, see WithContext.
+func (c *Client) NewRequest(method, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
+	ctx := c.snapshot().ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return c.NewRequestWithContext(ctx, method, path, body, opts...)
+}
+
+// NewRequestWithContext is NewRequest for a request bound to ctx, see
+// DoWithContext.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, path string, body interface{}, opts ...RequestOption) (*http.Request, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
-	uri := c.Endpoint.ResolveReference(rel)
-
-	// Encode body as json
-	buf := new(bytes.Buffer)
-	if body != nil {
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
+	cfg := c.snapshot()
+	if cfg.endpointErr != nil {
+		return nil, cfg.endpointErr
+	}
+	if cfg.ddiEndpointErr != nil {
+		return nil, cfg.ddiEndpointErr
+	}
+	uri := cfg.endpointFor(rel).ResolveReference(rel)
+
+	// Encode body as json, unless it already is.
+	var r io.Reader
+	var encoded []byte
+	switch b := body.(type) {
+	case nil:
+		r = new(bytes.Buffer)
+	case io.Reader:
+		r = b
+	case []byte:
+		encoded = b
+	case json.RawMessage:
+		encoded = b
+	default:
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return nil, err
 		}
+		encoded = buf.Bytes()
+	}
+	gzipped := false
+	if encoded != nil {
+		if cfg.Compression {
+			compressed, err := compressBody(encoded)
+			if err != nil {
+				return nil, err
+			}
+			if compressed != nil {
+				encoded, gzipped = compressed, true
+			}
+		}
+		r = bytes.NewReader(encoded)
 	}
 
-	req, err := http.NewRequest(method, uri.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), r)
 	if err != nil {
 		return nil, err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if cfg.Compression || (!cfg.noResponseGzip && !transparentGzip(cfg.httpClient)) {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	req.Header.Add(headerAuth, cfg.apiKey())
+	req.Header.Add("User-Agent", cfg.UserAgent)
+	for k, v := range cfg.defaultHeaders {
+		req.Header[k] = append([]string(nil), v...)
+	}
 
-	req.Header.Add(headerAuth, c.APIKey)
-	req.Header.Add("User-Agent", c.UserAgent)
+	for _, opt := range opts {
+		opt(req)
+	}
 	return req, nil
 }
 
-// Response wraps stdlib http response.
+// Response wraps stdlib http response. As returned by DoResponse, it also
+// carries the decoded body and the response's rate limit; the embedded
+// *http.Response gives the status code, headers and the next page's Cursor.
 type Response struct {
 	*http.Response
+
+	// Value is the v passed to DoResponse, into which the body was decoded.
+	Value interface{}
+
+	// RateLimit is the rate limit reported by the response.
+	RateLimit RateLimit
+}
+
+// DoResponse is Do returning its results bundled as a *Response, e.g. for
+// helpers passing a single result around. The body is decoded into v as
+// with Do, and v is also the Response's Value. Like Do's, the Response is
+// nil if a non-HTTP error occurs, and otherwise available for inspection
+// along with the error of a non-2XX response.
+func (c *Client) DoResponse(req *http.Request, v interface{}) (*Response, error) {
+	resp, err := c.Do(req, v)
+	if resp == nil {
+		return nil, err
+	}
+	return &Response{Response: resp, Value: v, RateLimit: parseRate(resp)}, err
 }
 
 // Error contains all http responses outside the 2xx range.
 type Error struct {
 	Resp    *http.Response
 	Message string
+
+	// Code is the error code given by the body, if any, e.g. by the DDI API.
+	// Numeric codes are kept in their decimal form.
+	Code string
+
+	// Details holds the other fields of a JSON error body, if any.
+	Details map[string]interface{}
+
+	// Body is the raw response body, nil if it was empty. Resp.Body can
+	// also be read again, even after Do has returned.
+	Body []byte
 }
 
 // Satisfy std lib error interface.
 func (re *Error) Error() string {
-	return fmt.Sprintf("%v %v: %d %v", re.Resp.Request.Method, re.Resp.Request.URL, re.Resp.StatusCode, re.Message)
+	msg := re.Message
+	if re.Code != "" {
+		msg = fmt.Sprintf("%s (code %s)", msg, re.Code)
+	}
+	return fmt.Sprintf("%v %v: %d %v", re.Resp.Request.Method, re.Resp.Request.URL, re.Resp.StatusCode, msg)
+}
+
+// IsNotFound reports whether the error is for a resource that does not
+// exist, see ErrNotFound.
+func (re *Error) IsNotFound() bool {
+	return re.Is(ErrNotFound)
+}
+
+// IsAlreadyExists reports whether the error is for creating a resource that
+// already exists, see ErrAlreadyExists.
+func (re *Error) IsAlreadyExists() bool {
+	return re.Is(ErrAlreadyExists)
 }
 
+// IsRateLimited reports whether the request was rejected with a 429 Too Many
+// Requests for exceeding the rate limit, see ErrRateLimited.
+func (re *Error) IsRateLimited() bool {
+	return re.Is(ErrRateLimited)
+}
+
+// maxErrorBodyMessage caps the Message taken from a non-JSON error body.
+const maxErrorBodyMessage = 512
+
 // CheckResponse handles parsing of rest api errors. Returns nil if no error.
+//
+// A JSON body fills the Message, Code and Details of the *Error; any other
+// body (e.g. an HTML page from a proxy) is kept as the Message, trimmed.
 func CheckResponse(resp *http.Response) error {
 	if c := resp.StatusCode; c >= 200 && c <= 299 {
 		return nil
@@ -270,18 +1096,55 @@ func CheckResponse(resp *http.Response) error {
 	if err != nil {
 		return err
 	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
 	if len(b) == 0 {
 		return restErr
 	}
+	restErr.Body = b
 
-	err = json.Unmarshal(b, restErr)
-	if err != nil {
-		return err
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		msg := strings.TrimSpace(string(b))
+		if len(msg) > maxErrorBodyMessage {
+			msg = msg[:maxErrorBodyMessage] + "..."
+		}
+		restErr.Message = msg
+		return restErr
+	}
+	for k, raw := range fields {
+		switch strings.ToLower(k) {
+		case "message":
+			json.Unmarshal(raw, &restErr.Message)
+		case "code":
+			restErr.Code = errorCode(raw)
+		default:
+			var v interface{}
+			if json.Unmarshal(raw, &v) == nil {
+				if restErr.Details == nil {
+					restErr.Details = map[string]interface{}{}
+				}
+				restErr.Details[k] = v
+			}
+		}
 	}
 
 	return restErr
 }
 
+// errorCode returns the string form of a JSON error code, a string or a
+// number.
+func errorCode(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	return ""
+}
+
 // RateLimitFunc is rate limiting strategy for the Client instance.
 type RateLimitFunc func(RateLimit)
 
@@ -290,21 +1153,40 @@ type RateLimit struct {
 	Limit     int
 	Remaining int
 	Period    int
+
+	// RetryAfter is the wait asked for by the Retry-After header of a 429
+	// response, or 0 if there was none.
+	RetryAfter time.Duration
 }
 
 var defaultRateLimitFunc = func(rl RateLimit) {}
 
-// PercentageLeft returns the ratio of Remaining to Limit as a percentage
+// RateLimitNone is a RateLimitFunc that does not pace requests at all. It
+// behaves like the default, but counts as a strategy for
+// RequireRateLimitStrategy, for programs that deliberately leave the pacing
+// to the API's 429 responses (and a RetryPolicy).
+var RateLimitNone RateLimitFunc = func(RateLimit) {}
+
+// PercentageLeft returns the ratio of Remaining to Limit as a percentage, or
+// 0 if the Limit is unknown (no rate limit headers were seen).
 func (rl RateLimit) PercentageLeft() int {
+	if rl.Limit <= 0 {
+		return 0
+	}
 	return rl.Remaining * 100 / rl.Limit
 }
 
-// WaitTime returns the time.Duration ratio of Period to Limit
+// WaitTime returns the time.Duration ratio of Period to Limit, or the whole
+// Period if the Limit is unknown.
 func (rl RateLimit) WaitTime() time.Duration {
+	if rl.Limit <= 0 {
+		return time.Second * time.Duration(rl.Period)
+	}
 	return (time.Second * time.Duration(rl.Period)) / time.Duration(rl.Limit)
 }
 
-// WaitTimeRemaining returns the time.Duration ratio of Period to Remaining
+// WaitTimeRemaining returns the time.Duration ratio of Period to Remaining,
+// or the whole Period if fewer than two requests remain.
 func (rl RateLimit) WaitTimeRemaining() time.Duration {
 	if rl.Remaining < 2 {
 		return time.Second * time.Duration(rl.Period)
@@ -312,25 +1194,170 @@ func (rl RateLimit) WaitTimeRemaining() time.Duration {
 	return (time.Second * time.Duration(rl.Period)) / time.Duration(rl.Remaining)
 }
 
-// RateLimitStrategySleep sets RateLimitFunc to sleep by WaitTimeRemaining
-func (c *Client) RateLimitStrategySleep() {
-	c.RateLimitFunc = func(rl RateLimit) {
-		remaining := rl.WaitTimeRemaining()
-		time.Sleep(remaining)
+// lastRateLimit holds the RateLimit of the latest response carrying rate
+// limit headers, shared by all goroutines using the Client.
+type lastRateLimit struct {
+	mu   sync.RWMutex
+	rl   RateLimit
+	seen bool
+}
+
+func (l *lastRateLimit) set(rl RateLimit) {
+	if l == nil {
+		return
 	}
+	l.mu.Lock()
+	l.rl, l.seen = rl, true
+	l.mu.Unlock()
+}
+
+func (l *lastRateLimit) get() (RateLimit, bool) {
+	if l == nil {
+		return RateLimit{}, false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.rl, l.seen
+}
+
+// LastRateLimit returns the rate limit reported by the most recent response,
+// across all goroutines, and false if no response has reported one yet.
+func (c *Client) LastRateLimit() (RateLimit, bool) {
+	return c.lastRate.get()
 }
 
-// RateLimitStrategyConcurrent sleeps for WaitTime * parallelism when
-// remaining is less than or equal to parallelism.
+// QuotaRemaining returns the number of requests left in the current rate
+// limit period as of the most recent response, or -1 if unknown. It is meant
+// for schedulers deciding whether to dispatch more work; it does not account
+// for requests in flight.
+func (c *Client) QuotaRemaining() int {
+	rl, ok := c.lastRate.get()
+	if !ok {
+		return -1
+	}
+	return rl.Remaining
+}
+
+// RateLimitStrategySleep sets RateLimitFunc to sleep by RetryAfter when a 429
+// response had a Retry-After header, and by WaitTimeRemaining otherwise. In
+// Do, the sleep ends early when the request's context is done. When the Retry
+// policy then retries the request, its backoff only waits for what is left.
+func (c *Client) RateLimitStrategySleep() {
+	c.setRateLimitWait(func(rl RateLimit) time.Duration {
+		if rl.RetryAfter > 0 {
+			return rl.RetryAfter
+		}
+		return rl.WaitTimeRemaining()
+	})
+}
+
+// RateLimitStrategyConcurrent paces parallelism workers sharing the client
+// by dividing the remaining quota among them: after each response a worker
+// sleeps for WaitTimeRemaining * parallelism, at most the Period, or by
+// RetryAfter when a 429 response had a Retry-After header. Each sleep is
+// lengthened by a random jitter of up to 10%, so that workers started
+// together do not keep sending their requests in bursts. In Do, the sleep
+// ends early when the request's context is done.
 func (c *Client) RateLimitStrategyConcurrent(parallelism int) {
+	c.setRateLimitWait(func(rl RateLimit) time.Duration {
+		return jitterWait(concurrentWait(rl, parallelism))
+	})
+}
+
+// concurrentWait is the sleep of RateLimitStrategyConcurrent, before jitter.
+func concurrentWait(rl RateLimit, parallelism int) time.Duration {
+	if rl.RetryAfter > 0 {
+		return rl.RetryAfter
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	d := rl.WaitTimeRemaining() * time.Duration(parallelism)
+	if period := time.Second * time.Duration(rl.Period); d > period {
+		d = period
+	}
+	return d
+}
+
+// jitterWait returns d lengthened by a random duration of up to 10%.
+func jitterWait(d time.Duration) time.Duration {
+	if spread := int64(d) / 10; spread > 0 {
+		d += time.Duration(rand.Int63n(spread + 1))
+	}
+	return d
+}
+
+// setRateLimitWait installs a strategy sleeping for wait(rl) as both the
+// RateLimitContextFunc and, for direct callers, the RateLimitFunc.
+func (c *Client) setRateLimitWait(wait func(RateLimit) time.Duration) {
+	c.lock()
+	defer c.unlock()
+	c.RateLimitContextFunc = func(ctx context.Context, rl RateLimit) {
+		sleepContext(ctx, wait(rl)) // nolint: errcheck
+	}
 	c.RateLimitFunc = func(rl RateLimit) {
-		if rl.Remaining <= parallelism {
-			wait := rl.WaitTime() * time.Duration(parallelism)
-			time.Sleep(wait)
-		}
+		time.Sleep(wait(rl))
 	}
 }
 
+// sleepContext sleeps for d, or until ctx is done and returns its error.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// SharedLimiter coordinates rate limiting across several clients (e.g. the
+// replicas of a service) that share one account's quota, where the local
+// view of RateLimit.Remaining is misleading. Acquire is called before each
+// request is sent and should block until the request may proceed, or return
+// an error (typically ctx.Err()) to abort it.
+//
+// The client only provides the hook; a distributed implementation (backed
+// by Redis or similar) is up to the user. When a SharedLimiter is in use the
+// RateLimitFunc is usually left at its no-op default. Limiters implementing
+// RateObserver are also passed each response's RateLimit; TokenBucketLimiter
+// is one for goroutines sharing a single Client.
+type SharedLimiter interface {
+	Acquire(ctx context.Context) error
+}
+
+// noopSharedLimiter is the default SharedLimiter, it never blocks.
+type noopSharedLimiter struct{}
+
+func (noopSharedLimiter) Acquire(context.Context) error { return nil }
+
+// callRateLimitFunc calls the RateLimitContextFunc, or else the
+// RateLimitFunc, guarded against panics.
+func (c Client) callRateLimitFunc(ctx context.Context, rl RateLimit) {
+	c.guard("RateLimitFunc", func() {
+		if c.RateLimitContextFunc != nil {
+			c.RateLimitContextFunc(ctx, rl)
+			return
+		}
+		c.RateLimitFunc(rl)
+	})
+}
+
+// guard calls fn, a callback of the user named hook, recovering and logging
+// any panic so that a buggy callback does not fail the request.
+func (c Client) guard(hook string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logf("ns1: recovered from panic in %s: %v", hook, r)
+		}
+	}()
+	fn()
+}
+
 // parseRate parses rate related headers from http response.
 func parseRate(resp *http.Response) RateLimit {
 	var rl RateLimit
@@ -344,6 +1371,14 @@ func parseRate(resp *http.Response) RateLimit {
 	if period := resp.Header.Get(headerRatePeriod); period != "" {
 		rl.Period, _ = strconv.Atoi(period)
 	}
+	// Retry-After on other statuses, e.g. a 503 during maintenance, is not
+	// about the rate limit; the Retry policy's backoff honors it instead.
+	if resp.StatusCode == http.StatusTooManyRequests {
+		now := time.Now()
+		if at, ok := parseRetryAfter(resp.Header, now); ok && at.After(now) {
+			rl.RetryAfter = at.Sub(now)
+		}
+	}
 
 	return rl
 }
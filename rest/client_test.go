@@ -2,15 +2,26 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_RateLimit(t *testing.T) {
@@ -52,6 +63,300 @@ func TestClient_RateLimit(t *testing.T) {
 	}
 }
 
+func TestClient_RateLimitZeroValues(t *testing.T) {
+	// Before any rate limit headers were seen.
+	var r RateLimit
+	assert.NotPanics(t, func() {
+		assert.Equal(t, 0, r.PercentageLeft())
+		assert.Equal(t, time.Duration(0), r.WaitTime())
+		assert.Equal(t, time.Duration(0), r.WaitTimeRemaining())
+	})
+
+	r = RateLimit{Limit: 0, Remaining: 0, Period: 10}
+	assert.NotPanics(t, func() {
+		assert.Equal(t, 0, r.PercentageLeft())
+		assert.Equal(t, 10*time.Second, r.WaitTime())
+		assert.Equal(t, 10*time.Second, r.WaitTimeRemaining())
+	})
+
+	r = RateLimit{Limit: 10, Remaining: 0, Period: 10}
+	assert.NotPanics(t, func() {
+		assert.Equal(t, 0, r.PercentageLeft())
+		assert.Equal(t, time.Second, r.WaitTime())
+		assert.Equal(t, 10*time.Second, r.WaitTimeRemaining())
+	})
+}
+
+func TestClient_RequireRateLimitStrategy(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	do := func(c *Client) error {
+		req, _ := c.NewRequest("GET", "zones", nil)
+		_, err := c.Do(req, nil)
+		return err
+	}
+
+	assert.Nil(t, do(NewClient(nil, SetEndpoint(ts.URL+"/"))))
+
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), RequireRateLimitStrategy())
+	assert.Equal(t, ErrNoRateLimitStrategy, do(client))
+	assert.Equal(t, int64(0), client.RequestStats().Attempts)
+
+	client.RateLimitStrategySleep()
+	assert.Nil(t, do(client))
+
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), RequireRateLimitStrategy())
+	client.RateLimitStrategyTokenBucket()
+	assert.Nil(t, do(client))
+
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), RequireRateLimitStrategy(),
+		SetRateLimitFunc(func(RateLimit) {}))
+	assert.Nil(t, do(client))
+
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), RequireRateLimitStrategy(),
+		SetRateLimitFunc(RateLimitNone))
+	assert.Nil(t, do(client))
+}
+
+func TestClient_RateLimitStrategyConcurrent(t *testing.T) {
+	// 100 requests left for the next 10s, shared by 10 workers: each one
+	// gets a request per second.
+	rl := RateLimit{Limit: 100, Remaining: 100, Period: 10}
+	assert.Equal(t, time.Second, concurrentWait(rl, 10))
+	assert.Equal(t, 100*time.Millisecond, concurrentWait(rl, 0))
+
+	// Never more than the rest of the period.
+	rl.Remaining = 5
+	assert.Equal(t, 10*time.Second, concurrentWait(rl, 10))
+
+	rl.RetryAfter = 3 * time.Second
+	assert.Equal(t, 3*time.Second, concurrentWait(rl, 10))
+
+	// The Retry-After of a 503 leaves the pacing to the quota.
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	resp.Header.Set(headerRateLimit, "100")
+	resp.Header.Set(headerRateRemaining, "100")
+	resp.Header.Set(headerRatePeriod, "10")
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, time.Second, concurrentWait(parseRate(resp), 10))
+	resp.StatusCode = http.StatusTooManyRequests
+	assert.Equal(t, time.Hour, concurrentWait(parseRate(resp), 10).Round(time.Second))
+
+	// Without rate limit headers there is nothing to pace by.
+	assert.Equal(t, time.Duration(0), concurrentWait(RateLimit{}, 10))
+
+	for i := 0; i < 100; i++ {
+		d := jitterWait(time.Second)
+		assert.True(t, d >= time.Second && d <= 1100*time.Millisecond, d)
+	}
+	assert.Equal(t, time.Duration(0), jitterWait(0))
+}
+
+func TestClient_Hooks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer ts.Close()
+
+	type call struct {
+		path   string
+		status int
+		err    bool
+	}
+	var requests []string
+	var responses []call
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"),
+		SetOnRequest(func(req *http.Request) { requests = append(requests, req.URL.Path) }),
+		SetOnResponse(func(req *http.Request, resp *http.Response, took time.Duration, err error) {
+			assert.True(t, took > 0)
+			c := call{path: req.URL.Path, err: err != nil}
+			if resp != nil {
+				c.status = resp.StatusCode
+			}
+			responses = append(responses, c)
+		}))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	_, err := client.Do(req, nil)
+	assert.Nil(t, err)
+	req, _ = client.NewRequest("GET", "missing", nil)
+	_, err = client.Do(req, nil)
+	assert.IsType(t, &Error{}, err)
+	req, _ = http.NewRequest("GET", "http://127.0.0.1:0/down", nil)
+	_, err = client.Do(req, nil)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []string{"/zones", "/missing", "/down"}, requests)
+	assert.Equal(t, []call{{"/zones", 200, false}, {"/missing", 404, false}, {"/down", 0, true}}, responses)
+}
+
+func TestClient_SetMiddleware(t *testing.T) {
+	var gotTenant, gotKey string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTenant = r.Header.Get("X-Tenant")
+		gotKey = r.Header.Get(headerAuth)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var order []string
+	trace := func(name string) Decorator {
+		return func(d Doer) Doer {
+			return DoerFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name+" >")
+				resp, err := d.Do(r)
+				order = append(order, name+" <")
+				return resp, err
+			})
+		}
+	}
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetAPIKey("secret"),
+		SetMiddleware(trace("outer"), Header("X-Tenant", "acme"), trace("inner")))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	_, err := client.Do(req, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"outer >", "inner >", "inner <", "outer <"}, order)
+	assert.Equal(t, "acme", gotTenant)
+	assert.Equal(t, "secret", gotKey)
+	assert.Empty(t, req.Header.Get("X-Tenant"))
+
+	// Middleware may answer without calling the Doer.
+	SetMiddleware(func(Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("offline")
+		})
+	})(client)
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	assert.EqualError(t, err, "offline")
+
+	SetMiddleware()(client)
+	gotTenant = ""
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	require.Nil(t, err)
+	assert.Empty(t, gotTenant)
+}
+
+func TestClient_DoWithRate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{`))
+			return
+		}
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "7")
+		w.Header().Set(headerRatePeriod, "5")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var called []RateLimit
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRateLimitFunc(func(rl RateLimit) {
+		called = append(called, rl)
+	}))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	var v map[string]interface{}
+	resp, rl, err := client.DoWithRate(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, RateLimit{Limit: 10, Remaining: 7, Period: 5}, rl)
+	assert.Equal(t, []RateLimit{rl}, called)
+
+	req, _ = client.NewRequest("GET", "missing", nil)
+	_, rl, err = client.DoWithRate(req, nil)
+	assert.IsType(t, &Error{}, err)
+	assert.Equal(t, 7, rl.Remaining)
+
+	req, _ = client.NewRequest("GET", "broken", nil)
+	resp, rl, err = client.DoWithRate(req, &v)
+	assert.NotNil(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, RateLimit{}, rl)
+}
+
+func TestClient_DoResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "4")
+		w.Header().Set(headerRatePeriod, "5")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`))
+			return
+		}
+		w.Header().Set("Link", `<`+"http://"+r.Host+`/zones?after=b>; rel="next"`)
+		w.Write([]byte(`[{"zone": "a"}, {"zone": "b"}]`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	var zones []map[string]string
+	resp, err := client.DoResponse(req, &zones)
+	require.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, &zones, resp.Value)
+	assert.Len(t, zones, 2)
+	assert.Equal(t, RateLimit{Limit: 10, Remaining: 4, Period: 5}, resp.RateLimit)
+	assert.Equal(t, "b", resp.Cursor())
+	assert.NotNil(t, resp.Response.Request)
+
+	req, _ = client.NewRequest("GET", "missing", nil)
+	resp, err = client.DoResponse(req, nil)
+	assert.IsType(t, &Error{}, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, 4, resp.RateLimit.Remaining)
+
+	req, _ = http.NewRequest("GET", "http://127.0.0.1:0/", nil)
+	resp, err = client.DoResponse(req, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, resp)
+}
+
+func TestClient_RateLimitRetryAfter(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set(headerRateLimit, "10")
+	resp.Header.Set(headerRateRemaining, "0")
+	resp.Header.Set(headerRatePeriod, "60")
+	assert.Zero(t, parseRate(resp).RetryAfter)
+
+	resp.Header.Set("Retry-After", "2")
+	assert.Equal(t, 2*time.Second, parseRate(resp).RetryAfter.Round(time.Second))
+
+	now := time.Now().UTC()
+	resp.Header.Set("Date", now.Format(http.TimeFormat))
+	resp.Header.Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+	assert.Equal(t, 5*time.Second, parseRate(resp).RetryAfter.Round(time.Second))
+
+	// Only a 429 asks to wait for the rate limit.
+	resp.StatusCode = http.StatusServiceUnavailable
+	assert.Zero(t, parseRate(resp).RetryAfter)
+	resp.StatusCode = http.StatusTooManyRequests
+
+	// The sleep strategy prefers Retry-After over the minute long
+	// WaitTimeRemaining.
+	c := NewClient(nil)
+	c.RateLimitStrategySleep()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	c.RateLimitContextFunc(ctx, RateLimit{Limit: 10, Remaining: 0, Period: 60, RetryAfter: 10 * time.Millisecond})
+	assert.True(t, time.Since(start) < time.Second)
+}
+
 func TestClient_Do(t *testing.T) {
 	// It should return the response without error
 	httpClient := mockHTTPClient{}
@@ -139,7 +444,8 @@ func TestClient_DoWithNon2XXResponse(t *testing.T) {
 }
 
 func TestClient_DoWithNonJSONResponse(t *testing.T) {
-	// It should return a nil response, and the error from JSON Decoder
+	// It should return a nil response, and the error from JSON Decoder with
+	// the body
 	httpClient := mockHTTPClient{}
 	client := NewClient(&httpClient, SetEndpoint(""))
 	req, _ := http.NewRequest("GET", "http://example.com", new(bytes.Buffer))
@@ -155,7 +461,57 @@ func TestClient_DoWithNonJSONResponse(t *testing.T) {
 	httpClient.AssertExpectations(t)
 
 	assert.Nil(t, resp)
-	assert.IsType(t, &json.SyntaxError{}, err)
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	require.IsType(t, &DecodeError{}, err)
+	assert.Equal(t, "INVALID", string(err.(*DecodeError).Body))
+}
+
+func TestCappedBuffer(t *testing.T) {
+	b := &cappedBuffer{max: 4}
+	n, err := b.Write([]byte("abc"))
+	assert.Equal(t, 3, n)
+	assert.Nil(t, err)
+	n, err = b.Write([]byte("defg"))
+	assert.Equal(t, 4, n)
+	assert.Nil(t, err)
+	b.Write([]byte("h"))
+	assert.Equal(t, "abcd", b.String())
+}
+
+func TestClient_DoWithEmptyBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("\n"))
+		}
+		// Otherwise a 200 with an empty body.
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	for _, path := range []string{"no-content", "empty", "text"} {
+		req, _ := client.NewRequest("DELETE", path, nil)
+		v := map[string]string{"kept": "yes"}
+		resp, err := client.Do(req, &v)
+		assert.Nil(t, err, path)
+		assert.NotNil(t, resp, path)
+		assert.Equal(t, map[string]string{"kept": "yes"}, v, path)
+	}
+
+	// Without a Content-Length, an empty body is detected while decoding.
+	httpClient := mockHTTPClient{}
+	client = NewClient(&httpClient, SetEndpoint(""))
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	mockResp := http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(" ")), StatusCode: 200}
+	httpClient.On("Do", req).Return(&mockResp, nil)
+	var v struct{ Zone string }
+	resp, err := client.Do(req, &v)
+	assert.Nil(t, err)
+	assert.Equal(t, &mockResp, resp)
 }
 
 func TestClient_DoWithPagination(t *testing.T) {
@@ -235,6 +591,444 @@ func TestClient_getURI(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestClient_NewRequestBody(t *testing.T) {
+	client := NewClient(nil)
+	read := func(req *http.Request) string {
+		b, err := ioutil.ReadAll(req.Body)
+		require.Nil(t, err)
+		return string(b)
+	}
+
+	cases := []struct {
+		name string
+		body interface{}
+		want string
+	}{
+		{"Value", map[string]int{"ttl": 60}, "{\"ttl\":60}\n"},
+		{"Bytes", []byte(`{"ttl": 60}`), `{"ttl": 60}`},
+		{"RawMessage", json.RawMessage(`{"ttl": 60}`), `{"ttl": 60}`},
+		{"Reader", strings.NewReader(`{"ttl": 60}`), `{"ttl": 60}`},
+	}
+	for _, tc := range cases {
+		req, err := client.NewRequest("POST", "zones/example.com", tc.body)
+		require.Nil(t, err, tc.name)
+		assert.Equal(t, tc.want, read(req), tc.name)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"), tc.name)
+	}
+
+	// Bodies from a stream that can't be rewound are buffered for retries.
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetryPolicy(RetryPolicy{
+		MaxRetries: 1, BaseDelay: time.Millisecond, RetryNonIdempotent: true,
+	}))
+	req, err := client.NewRequest("PUT", "zones/example.com", ioutil.NopCloser(strings.NewReader(`{"zone":"example.com"}`)))
+	require.Nil(t, err)
+	_, err = client.Do(req, nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{`{"zone":"example.com"}`, `{"zone":"example.com"}`}, bodies)
+
+	req, err = client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Empty(t, req.Header.Get("Content-Type"))
+}
+
+func TestClient_NewRequestWithParams(t *testing.T) {
+	client := NewClient(nil, SetEndpoint("https://api.example.com/v1/"))
+
+	req, err := client.NewRequest("GET", "zones?limit=10&after=a", nil, WithParams(url.Values{
+		"after": {"b"},
+		"q":     {"a b&c=d"},
+		"tag":   {"x", "y"},
+	}))
+	require.Nil(t, err)
+	assert.Equal(t, "/v1/zones", req.URL.Path)
+	assert.Equal(t, "after=b&limit=10&q=a+b%26c%3Dd&tag=x&tag=y", req.URL.RawQuery)
+	assert.Equal(t, "a b&c=d", req.URL.Query().Get("q"))
+
+	req, err = client.NewRequest("GET", "zones", nil, WithParams(nil))
+	require.Nil(t, err)
+	assert.Equal(t, "https://api.example.com/v1/zones", req.URL.String())
+}
+
+func TestClient_WithHeader(t *testing.T) {
+	client := NewClient(nil, SetAPIKey("key"), SetUserAgent("ns1-go"))
+
+	req, err := client.NewRequest("POST", "zones/example.com", nil,
+		WithHeader("If-Match", `"etag"`), WithHeader("User-Agent", "one-off"))
+	require.Nil(t, err)
+	assert.Equal(t, `"etag"`, req.Header.Get("If-Match"))
+	assert.Equal(t, []string{"one-off"}, req.Header["User-Agent"])
+	assert.Equal(t, "key", req.Header.Get(headerAuth))
+
+	req, err = client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "ns1-go", req.Header.Get("User-Agent"))
+	assert.Empty(t, req.Header.Get("If-Match"))
+}
+
+func TestClient_WithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"zone": "example.com"}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scoped := client.WithContext(ctx)
+	z, _, err := scoped.Zones.Get("example.com")
+	require.Nil(t, err)
+	assert.Equal(t, "example.com", z.Zone)
+
+	cancel()
+	_, _, err = scoped.Zones.Get("example.com")
+	assert.True(t, errors.Is(err, context.Canceled), "%v", err)
+	req, _ := scoped.NewRequest("GET", "zones", nil)
+	assert.Equal(t, ctx, req.Context())
+
+	// The original client is unaffected, and shares the statistics.
+	_, _, err = client.Zones.Get("example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), client.RequestStats().Attempts)
+
+	scoped.Configure(SetUserAgent("scoped"))
+	req, _ = client.NewRequest("GET", "zones", nil)
+	assert.Equal(t, defaultUserAgent, req.Header.Get("User-Agent"))
+}
+
+func TestClient_SetTimeout(t *testing.T) {
+	// A Doer that never responds on its own.
+	hung := DoerFunc(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+	client := NewClient(hung, SetEndpoint("http://example.com/v1/"), SetTimeout(20*time.Millisecond))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	start := time.Now()
+	resp, err := client.Do(req, nil)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrRequestTimeout), "%v", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "timed out after 20ms")
+	assert.True(t, time.Since(start) < time.Second)
+
+	// The caller's own cancellation is not reported as a timeout.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.DoWithContext(ctx, req, nil)
+	assert.Equal(t, context.Canceled, err)
+
+	// The bound covers the rate limit strategy's sleep.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRatePeriod, "60")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), SetTimeout(50*time.Millisecond))
+	client.RateLimitStrategySleep()
+	req, _ = client.NewRequest("GET", "zones", nil)
+	start = time.Now()
+	client.Do(req, nil)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	// Without a timeout, requests are unbounded.
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"))
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	assert.Nil(t, err)
+}
+
+func TestClient_WithTimeout(t *testing.T) {
+	hung := DoerFunc(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+	client := NewClient(hung, SetEndpoint("http://example.com/v1/"))
+
+	req, _ := client.NewRequest("GET", "zones", nil, WithTimeout(20*time.Millisecond))
+	_, err := client.Do(req, nil)
+	assert.True(t, errors.Is(err, ErrRequestTimeout), "%v", err)
+	assert.Contains(t, err.Error(), "timed out after 20ms")
+
+	// Given to DoWithOptions, with a header for the same call; the request
+	// is left unchanged.
+	var got http.Header
+	client = NewClient(DoerFunc(func(r *http.Request) (*http.Response, error) {
+		got = r.Header
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	}), SetEndpoint("http://example.com/v1/"))
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.DoWithOptions(req, nil, WithTimeout(10*time.Millisecond),
+		WithHeader("X-NSONE-API-Version", "2"), WithParam("limit", "1"))
+	assert.True(t, errors.Is(err, ErrRequestTimeout), "%v", err)
+	assert.Equal(t, "2", got.Get("X-NSONE-API-Version"))
+	assert.Empty(t, req.Header.Get("X-NSONE-API-Version"))
+	assert.Empty(t, req.URL.RawQuery)
+	assert.Nil(t, req.Context().Value(callTimeoutKey{}))
+
+	// The bound covers all attempts and the backoff between them.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"message": "unavailable"}`))
+	}))
+	defer ts.Close()
+	tracer := &recordingTracer{}
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), SetTracer(tracer), SetRetry(10, time.Second))
+	req, _ = client.NewRequest("GET", "zones", nil, WithTimeout(50*time.Millisecond))
+	start := time.Now()
+	_, err = client.Do(req, nil)
+	assert.True(t, errors.Is(err, ErrRequestTimeout), "%v", err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+	require.Len(t, tracer.results, 1)
+	assert.Equal(t, err, tracer.results[0].Err)
+}
+
+// Run with -race: requests from several goroutines while others reconfigure
+// the client.
+func TestClient_ConcurrentConfigure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "1000")
+		w.Header().Set(headerRateRemaining, "999")
+		w.Header().Set(headerRatePeriod, "1")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	stop := make(chan struct{})
+	var reconfigure sync.WaitGroup
+	reconfigure.Add(1)
+	go func() {
+		defer reconfigure.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			switch i % 4 {
+			case 0:
+				client.RateLimitStrategySleep()
+			case 1:
+				client.RateLimitStrategyTokenBucket()
+			case 2:
+				client.Configure(SetRateLimitFunc(func(RateLimit) {}), SetLogger(nil), SetUserAgent("ua"))
+			case 3:
+				client.Configure(SetAPIKey(strconv.Itoa(i)), SetFollowPagination(i%8 == 3), SetRetry(1, time.Millisecond))
+			}
+		}
+	}()
+
+	var requests sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		requests.Add(1)
+		go func() {
+			defer requests.Done()
+			for i := 0; i < 20; i++ {
+				req, err := client.NewRequest("GET", "zones", nil)
+				if !assert.Nil(t, err) {
+					return
+				}
+				var v map[string]interface{}
+				_, err = client.Do(req, &v)
+				assert.Nil(t, err)
+			}
+		}()
+	}
+	requests.Wait()
+	close(stop)
+	reconfigure.Wait()
+}
+
+func TestClient_SetAPIKeyFunc(t *testing.T) {
+	key := "first"
+	client := NewClient(nil, SetAPIKey("static"), SetAPIKeyFunc(func() string { return key }))
+
+	req, err := client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "first", req.Header.Get(headerAuth))
+
+	key = "rotated"
+	req, err = client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "rotated", req.Header.Get(headerAuth))
+
+	req, err = client.NewRequest("GET", "zones", nil, WithAPIKey("tenant"))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"tenant"}, req.Header.Values(headerAuth))
+
+	SetAPIKeyFunc(nil)(client)
+	req, err = client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "static", req.Header.Get(headerAuth))
+}
+
+func TestClient_DefaultHeaders(t *testing.T) {
+	defaults := http.Header{}
+	defaults.Set("X-Gateway-Token", "gw-secret")
+	defaults.Set("X-Tenant", "blue")
+	client := NewClient(nil, SetAPIKey("key"), SetDefaultHeaders(defaults))
+	defaults.Set("X-Tenant", "changed")
+
+	req, err := client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "gw-secret", req.Header.Get("X-Gateway-Token"))
+	assert.Equal(t, "blue", req.Header.Get("X-Tenant"))
+	assert.Equal(t, "key", req.Header.Get(headerAuth))
+
+	req, err = client.NewRequest("GET", "zones", nil, WithHeader("X-Tenant", "green"))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"green"}, req.Header["X-Tenant"])
+	assert.Equal(t, "gw-secret", req.Header.Get("X-Gateway-Token"))
+
+	// Per-request changes do not leak into the defaults.
+	req, err = client.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"blue"}, req.Header["X-Tenant"])
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	var rec bytes.Buffer
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), SetAPIKey("key"), SetDefaultHeaders(defaults), SetRecorder(&rec))
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	require.Nil(t, err)
+	assert.NotContains(t, rec.String(), "gw-secret")
+	assert.NotContains(t, rec.String(), `"key"`)
+	assert.Contains(t, rec.String(), "changed")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestClient_RecorderFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"zone": "example.com"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	l := &bufLogger{}
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetLogger(l), SetRecorder(failingWriter{}))
+	req, err := client.NewRequest("GET", "zones/example.com", nil)
+	require.Nil(t, err)
+	var v map[string]string
+	_, err = client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, "example.com", v["zone"])
+	require.Len(t, l.lines, 1)
+	assert.Contains(t, l.lines[0], "ns1: recording GET")
+	assert.Contains(t, l.lines[0], "disk full")
+}
+
+func TestClient_RecorderRedactsBodies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-value"})
+		w.Write([]byte(`{"id": "k1", "name": "ci", "key": "api-key-value", "ttl": 60}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	var rec bytes.Buffer
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRecorder(&rec))
+	body := map[string]interface{}{"name": "tsig", "secret": "tsig-secret", "tsig": map[string]string{"key": "zone-key"}}
+	req, err := client.NewRequest("PUT", "tsig/tsig", body)
+	require.Nil(t, err)
+	v := map[string]interface{}{}
+	_, err = client.Do(req, &v)
+	require.Nil(t, err)
+	// The caller still gets the response as sent.
+	assert.Equal(t, "api-key-value", v["key"])
+
+	var ex RecordedExchange
+	require.Nil(t, json.Unmarshal(rec.Bytes(), &ex))
+	assert.JSONEq(t, `{"name": "tsig", "secret": "REDACTED", "tsig": {"key": "REDACTED"}}`, string(ex.Request.Body))
+	assert.JSONEq(t, `{"id": "k1", "name": "ci", "key": "REDACTED", "ttl": 60}`, string(ex.Response.Body))
+	assert.Equal(t, redacted, ex.Response.Headers.Get("Set-Cookie"))
+	for _, secret := range []string{"api-key-value", "tsig-secret", "zone-key", "cookie-value"} {
+		assert.NotContains(t, rec.String(), secret)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	req, _ := http.NewRequest("PUT", "http://example.com/zones/example.com", nil)
+	check := func(status int, body string) *Error {
+		err := CheckResponse(&http.Response{
+			Request:    req,
+			StatusCode: status,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		})
+		require.IsType(t, &Error{}, err)
+		return err.(*Error)
+	}
+
+	err := check(400, `{"message": "invalid ttl", "code": "E_TTL", "field": "ttl"}`)
+	assert.Equal(t, "invalid ttl", err.Message)
+	assert.Equal(t, "E_TTL", err.Code)
+	assert.Equal(t, map[string]interface{}{"field": "ttl"}, err.Details)
+	assert.Equal(t, "PUT http://example.com/zones/example.com: 400 invalid ttl (code E_TTL)", err.Error())
+
+	err = check(404, `{"message": "zone not found", "code": 4040}`)
+	assert.Equal(t, "4040", err.Code)
+	assert.Nil(t, err.Details)
+	assert.True(t, err.IsNotFound())
+	assert.False(t, err.IsAlreadyExists())
+	assert.False(t, err.IsRateLimited())
+
+	err = check(400, `{"message": "zone already exists"}`)
+	assert.True(t, err.IsAlreadyExists())
+	assert.False(t, err.IsNotFound())
+
+	err = check(429, "")
+	assert.True(t, err.IsRateLimited())
+	assert.Equal(t, "PUT http://example.com/zones/example.com: 429 ", err.Error())
+
+	err = check(502, "<html>\n<body>Bad Gateway</body>\n</html>\n")
+	assert.Equal(t, "<html>\n<body>Bad Gateway</body>\n</html>", err.Message)
+	assert.Empty(t, err.Code)
+
+	err = check(500, strings.Repeat("x", 1000))
+	assert.Equal(t, strings.Repeat("x", 512)+"...", err.Message)
+}
+
+func TestCheckResponse_Body(t *testing.T) {
+	for _, body := range []string{`{"message": "bad", "unexpected": [1, 2]}`, "<html>Bad Gateway</html>"} {
+		resp := &http.Response{StatusCode: 400, Body: ioutil.NopCloser(bytes.NewBufferString(body))}
+		err := CheckResponse(resp)
+		require.IsType(t, &Error{}, err)
+		assert.Equal(t, []byte(body), err.(*Error).Body)
+
+		resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(t, body, string(b))
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "invalid", "field": "ttl"}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	req, _ := client.NewRequest("GET", "zones", nil)
+	resp, err := client.Do(req, nil)
+	require.IsType(t, &Error{}, err)
+	assert.Equal(t, `{"message": "invalid", "field": "ttl"}`, string(err.(*Error).Body))
+	b, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"message": "invalid", "field": "ttl"}`, string(b))
+}
+
 func TestClient_getURIWithNon2XXResponse(t *testing.T) {
 	// It should return a pointer to the response, and a pointer to Error (with
 	// the response
@@ -251,7 +1045,7 @@ func TestClient_getURIWithNon2XXResponse(t *testing.T) {
 	resp, err := client.getURI(v, "http://example.com")
 
 	assert.Equal(t, &mockResp, resp)
-	assert.Equal(t, &Error{Resp: &mockResp}, err)
+	assert.Equal(t, &Error{Resp: &mockResp, Body: []byte("{}")}, err)
 }
 
 type mockHTTPClient struct {
@@ -271,3 +1065,363 @@ func (c *mockHTTPClient) nextFunc(v *interface{}, uri string) (*http.Response, e
 	args := c.Called(v, uri)
 	return args.Get(0).(*http.Response), args.Error(1)
 }
+
+type idleCloserDoer struct {
+	DoerFunc
+	closed int
+}
+
+func (d *idleCloserDoer) CloseIdleConnections() {
+	d.closed++
+}
+
+func TestClient_CloseIdleConnections(t *testing.T) {
+	d := &idleCloserDoer{}
+	c := NewClient(d)
+	c.CloseIdleConnections()
+	assert.Equal(t, 1, d.closed)
+
+	// Doers without CloseIdleConnections are left alone.
+	c = NewClient(DoerFunc(func(*http.Request) (*http.Response, error) { return nil, nil }))
+	assert.NotPanics(t, c.CloseIdleConnections)
+
+	c = NewClient(&http.Client{})
+	assert.NotPanics(t, c.CloseIdleConnections)
+}
+
+type countingLimiter struct {
+	calls int
+	err   error
+}
+
+func (l *countingLimiter) Acquire(ctx context.Context) error {
+	l.calls++
+	return l.err
+}
+
+func TestClient_DoWithSharedLimiter(t *testing.T) {
+	limiter := &countingLimiter{}
+	httpClient := mockHTTPClient{}
+	client := NewClient(&httpClient, SetEndpoint(""), SetSharedLimiter(limiter))
+	req, _ := http.NewRequest("GET", "http://example.com", new(bytes.Buffer))
+
+	mockResp := http.Response{
+		Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		StatusCode: 200,
+	}
+	httpClient.On("Do", req).Return(&mockResp, nil).Once()
+
+	_, err := client.Do(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, limiter.calls)
+
+	// A failing Acquire aborts the request before it is sent.
+	limiter.err = context.Canceled
+	resp, err := client.Do(req, nil)
+	assert.Nil(t, resp)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 2, limiter.calls)
+	httpClient.AssertExpectations(t)
+
+	// Resetting to nil restores the no-op limiter.
+	SetSharedLimiter(nil)(client)
+	assert.Nil(t, client.SharedLimiter.Acquire(context.Background()))
+}
+
+func TestClient_NewRequestWithAPIKey(t *testing.T) {
+	var buf bytes.Buffer
+	var keys []string
+	doer := Decorate(DoerFunc(func(r *http.Request) (*http.Response, error) {
+		keys = append(keys, r.Header.Get(headerAuth))
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewBufferString("{}"))}, nil
+	}), Logging(log.New(&buf, "", 0)))
+	client := NewClient(doer, SetAPIKey("default-key"))
+
+	req, err := client.NewRequest("GET", "zones", nil, WithAPIKey("tenant-key"))
+	assert.Nil(t, err)
+	_, err = client.Do(req, nil)
+	assert.Nil(t, err)
+
+	req, err = client.NewRequest("GET", "zones", nil)
+	assert.Nil(t, err)
+	_, err = client.Do(req, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"tenant-key", "default-key"}, keys)
+	assert.Equal(t, "default-key", client.APIKey)
+	assert.NotContains(t, buf.String(), "tenant-key")
+	assert.NotContains(t, buf.String(), "default-key")
+}
+
+func TestClient_DoWithPanickingRateLimitFunc(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	httpClient := mockHTTPClient{}
+	client := NewClient(&httpClient, SetEndpoint(""), SetRateLimitFunc(func(RateLimit) {
+		panic("boom")
+	}))
+	req, _ := http.NewRequest("GET", "http://example.com", new(bytes.Buffer))
+
+	mockResp := http.Response{
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"zone": "example.com"}`)),
+		StatusCode: 200,
+	}
+	httpClient.On("Do", req).Return(&mockResp, nil)
+
+	var v map[string]string
+	resp, err := client.Do(req, &v)
+	assert.Nil(t, err)
+	assert.Equal(t, &mockResp, resp)
+	assert.Equal(t, "example.com", v["zone"])
+	assert.Contains(t, buf.String(), "recovered from panic in RateLimitFunc: boom")
+}
+
+type panickingLogger struct{ bufLogger }
+
+func (l *panickingLogger) Debug(string, ...interface{}) { panic("log") }
+func (l *panickingLogger) Info(string, ...interface{})  { panic("log") }
+func (l *panickingLogger) Warn(string, ...interface{})  { panic("log") }
+func (l *panickingLogger) Error(string, ...interface{}) { panic("log") }
+
+func TestClient_DoWithPanickingHooks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"zone": "example.com"}`))
+	}))
+	defer ts.Close()
+
+	l := &panickingLogger{}
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetLogger(l),
+		SetOnRequest(func(*http.Request) { panic("boom") }),
+		SetOnResponse(func(*http.Request, *http.Response, time.Duration, error) { panic("boom") }))
+	req, _ := client.NewRequest("GET", "zones/example.com", nil)
+	var v map[string]string
+	_, err := client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, "example.com", v["zone"])
+	assert.Equal(t, []string{
+		"ns1: recovered from panic in request hook: boom",
+		"ns1: recovered from panic in logger: log",
+		"ns1: recovered from panic in response hook: boom",
+	}, l.lines)
+
+	// Panicking middleware fails the request instead of the goroutine.
+	l = &panickingLogger{}
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), SetLogger(l), SetMiddleware(func(d Doer) Doer {
+		return DoerFunc(func(*http.Request) (*http.Response, error) { panic("boom") })
+	}))
+	req, _ = client.NewRequest("GET", "zones/example.com", nil)
+	_, err = client.Do(req, nil)
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "panic in middleware: boom")
+}
+
+type bufLogger struct{ lines []string }
+
+func (l *bufLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestClient_SetLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+	panicking := SetRateLimitFunc(func(RateLimit) { panic("boom") })
+
+	l := &bufLogger{}
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), panicking, SetLogger(l))
+	req, _ := client.NewRequest("GET", "zones", nil)
+	_, err := client.Do(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ns1: recovered from panic in RateLimitFunc: boom"}, l.lines)
+
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), panicking, SetLogger(nil))
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, buf.String())
+}
+
+func TestClient_QuotaRemaining(t *testing.T) {
+	var remaining int32 = 10
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.Header().Set(headerRateLimit, "10")
+			w.Header().Set(headerRateRemaining, strconv.Itoa(int(atomic.AddInt32(&remaining, -1))))
+			w.Header().Set(headerRatePeriod, "10")
+		}
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	get := func(path string) {
+		req, err := client.NewRequest("GET", path, nil)
+		require.Nil(t, err)
+		_, err = client.Do(req, nil)
+		require.Nil(t, err)
+	}
+
+	assert.Equal(t, -1, client.QuotaRemaining())
+	get("unlimited")
+	assert.Equal(t, -1, client.QuotaRemaining())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get("limited")
+		}()
+	}
+	wg.Wait()
+	get("limited")
+	assert.Equal(t, 4, client.QuotaRemaining())
+
+	get("unlimited")
+	rl, ok := client.LastRateLimit()
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{Limit: 10, Remaining: 4, Period: 10}, rl)
+}
+
+func TestClient_DoWithContext(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-release
+		}
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRatePeriod, "60")
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	// Cancelling the context aborts the round trip.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := c.NewRequestWithContext(ctx, "GET", "slow", nil)
+	require.Nil(t, err)
+	_, err = c.Do(req, nil)
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The sleep strategy would wait a whole minute, but returns when the
+	// context is done.
+	c.RateLimitStrategySleep()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err = c.NewRequest("GET", "fast", nil)
+	require.Nil(t, err)
+	start := time.Now()
+	var v map[string]interface{}
+	_, err = c.DoWithContext(ctx, req, &v)
+	assert.Nil(t, err)
+	assert.NotNil(t, v)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	// A plain RateLimitFunc replaces the strategy.
+	var called bool
+	SetRateLimitFunc(func(RateLimit) { called = true })(c)
+	_, err = c.DoWithContext(context.Background(), req, nil)
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
+
+func TestClient_DoWithNonJSONContentType(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/json" {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write([]byte(`{"token": "abc"}`)) // nolint: errcheck
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("abc")) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	do := func(path string, v interface{}) (*http.Response, error) {
+		req, err := c.NewRequest("GET", path, nil)
+		require.Nil(t, err)
+		return c.Do(req, v)
+	}
+
+	_, err := do("token", nil)
+	assert.Nil(t, err)
+
+	var buf bytes.Buffer
+	_, err = do("token", &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", buf.String())
+
+	var v map[string]string
+	resp, err := do("token", &v)
+	require.NotNil(t, err)
+	ctErr, ok := err.(*ContentTypeError)
+	require.True(t, ok)
+	assert.Equal(t, "text/plain", ctErr.ContentType)
+	assert.Equal(t, []byte("abc"), ctErr.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, err.Error(), "unexpected text/plain response")
+
+	_, err = do("json", &v)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", v["token"])
+}
+
+func TestClient_DoAll(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get(headerAuth))
+		switch r.URL.Query().Get("after") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/zones?after=b&limit=2>; rel="next"`, ts.URL))
+			w.Write([]byte(`[{"zone":"a"},{"zone":"b"}]`))
+		case "b":
+			w.Header().Set("Link", `</zones?after=d&limit=2>; rel="next"`)
+			w.Write([]byte(`[{"zone":"c"},{"zone":"d"}]`))
+		case "d":
+			w.Write([]byte(`[{"zone":"e"}]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+		}
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetAPIKey("key"))
+
+	req, _ := client.NewRequest("GET", "zones", nil)
+	var zones []map[string]string
+	resp, err := client.DoAll(req, &zones)
+	require.Nil(t, err)
+	assert.Equal(t, []map[string]string{{"zone": "a"}, {"zone": "b"}, {"zone": "c"}, {"zone": "d"}, {"zone": "e"}}, zones)
+	assert.Equal(t, "", Response{Response: resp}.NextURI())
+	assert.Equal(t, int64(3), client.RequestStats().Attempts)
+
+	req, _ = client.NewRequest("GET", "zones", nil)
+	resp, err = client.Do(req, nil)
+	require.Nil(t, err)
+	page := Response{Response: resp}
+	assert.Equal(t, ts.URL+"/zones?after=b&limit=2", page.NextURI())
+	assert.Equal(t, "b", page.Cursor())
+	assert.Equal(t, "next", page.Links()["next"].Rel)
+
+	req, _ = client.NewRequest("GET", "zones?after=x", nil)
+	zones = nil
+	resp, err = client.DoAll(req, &zones)
+	require.IsType(t, &Error{}, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	_, err = client.DoAll(req, zones)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// serverClock holds the Date of the latest response and the local time it
+// was received at, shared by all goroutines using the Client.
+type serverClock struct {
+	mu     sync.RWMutex
+	server time.Time
+	local  time.Time
+}
+
+// observe records the Date header of resp, if it has a valid one.
+func (sc *serverClock) observe(resp *http.Response) {
+	if sc == nil {
+		return
+	}
+	server, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	sc.mu.Lock()
+	sc.server, sc.local = server, time.Now()
+	sc.mu.Unlock()
+}
+
+func (sc *serverClock) get() (server, local time.Time, ok bool) {
+	if sc == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.server, sc.local, !sc.server.IsZero()
+}
+
+// ServerTime returns the time reported by the Date header of the most recent
+// response, and false if no response had a valid one yet. The header has a
+// resolution of one second.
+func (c *Client) ServerTime() (time.Time, bool) {
+	server, _, ok := c.clock.get()
+	return server, ok
+}
+
+// ClockSkew returns how far the API server's clock is ahead of the local one
+// (negative if behind), as of the most recent response with a Date header,
+// and false if there was none. Skew within a second or so is expected from
+// the header's resolution and network latency; larger values can explain
+// surprising Retry-After or rate limit behavior.
+func (c *Client) ClockSkew() (time.Duration, bool) {
+	server, local, ok := c.clock.get()
+	if !ok {
+		return 0, false
+	}
+	return server.Sub(local.Truncate(time.Second)), true
+}
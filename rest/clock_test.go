@@ -0,0 +1,74 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ClockSkew(t *testing.T) {
+	date := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = []string{date}
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	get := func() {
+		req, err := client.NewRequest("GET", "zones", nil)
+		require.Nil(t, err)
+		_, err = client.Do(req, nil)
+		require.Nil(t, err)
+	}
+
+	_, ok := client.ServerTime()
+	assert.False(t, ok)
+
+	date = "not a date"
+	get()
+	_, ok = client.ClockSkew()
+	assert.False(t, ok)
+
+	ahead := time.Now().Add(time.Hour).UTC()
+	date = ahead.Format(http.TimeFormat)
+	get()
+	server, ok := client.ServerTime()
+	assert.True(t, ok)
+	assert.Equal(t, ahead.Truncate(time.Second), server.UTC())
+	skew, ok := client.ClockSkew()
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(skew), float64(2*time.Second))
+
+	// Older formats are accepted too.
+	date = time.Now().Add(-time.Minute).UTC().Format(time.RFC850)
+	get()
+	skew, _ = client.ClockSkew()
+	assert.InDelta(t, float64(-time.Minute), float64(skew), float64(2*time.Second))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	at, ok := parseRetryAfter(http.Header{"Retry-After": {"30"}}, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(30*time.Second), at)
+
+	// An HTTP date is relative to the server's clock, ten minutes ahead.
+	h := http.Header{
+		"Retry-After": {"Sat, 01 Feb 2020 12:15:00 GMT"},
+		"Date":        {"Sat, 01 Feb 2020 12:10:00 GMT"},
+	}
+	at, ok = parseRetryAfter(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(5*time.Minute), at)
+
+	for _, v := range []string{"", "-1", "soon"} {
+		_, ok = parseRetryAfter(http.Header{"Retry-After": {v}}, now)
+		assert.False(t, ok, v)
+	}
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressMinBodySize is the size from which request bodies are compressed
+// by a client with Compression enabled; smaller ones gain little.
+const compressMinBodySize = 1024
+
+// SetCompression makes the client ask for gzip-encoded responses, and gzip
+// request bodies of 1 KiB or more, e.g. for bulk record changes and large
+// zone listings. Bodies given to NewRequest as an io.Reader are streamed and
+// not compressed. Gzip-encoded responses are decompressed by Do whether or
+// not the option is set, before error bodies are read by CheckResponse and
+// others are decoded, so callers see no difference but the smaller
+// transfers.
+func SetCompression(compress bool) func(*Client) {
+	return func(c *Client) { c.Compression = compress }
+}
+
+// SetResponseCompression sets whether a client whose Doer does not
+// decompress responses itself asks for gzip-encoded ones, as it does by
+// default; Do decompresses them before decoding. An *http.Client with the
+// standard transport asks for and decompresses gzip responses on its own,
+// so this only changes anything for other Doers, e.g. one built on a custom
+// http.RoundTripper, or a transport with DisableCompression set. Disable it
+// for a Doer that can't handle compressed responses. With Compression set,
+// requests ask for gzip regardless.
+func SetResponseCompression(enabled bool) func(*Client) {
+	return func(c *Client) { c.noResponseGzip = !enabled }
+}
+
+// transparentGzip reports whether d asks for and decompresses gzip-encoded
+// responses on its own, as the standard http.Transport does.
+func transparentGzip(d Doer) bool {
+	hc, ok := d.(*http.Client)
+	if !ok {
+		return false
+	}
+	switch t := hc.Transport.(type) {
+	case nil:
+		dt, ok := http.DefaultTransport.(*http.Transport)
+		return ok && !dt.DisableCompression
+	case *http.Transport:
+		return !t.DisableCompression
+	}
+	return false
+}
+
+// compressBody returns b gzipped, or nil if it is too small to bother.
+func compressBody(b []byte) ([]byte, error) {
+	if len(b) < compressMinBodySize {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressResponse replaces the body of a gzip-encoded response with its
+// decompressed content, as the http.Transport does when it asked for gzip
+// itself.
+func decompressResponse(resp *http.Response) {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody decompresses a response body as it is read. The gzip reader is
+// only created on the first read, so empty bodies (of a 204 or a HEAD
+// request) read as empty.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.zr == nil && b.err == nil {
+		b.zr, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.zr.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	require.Nil(t, err)
+	require.Nil(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestClient_SetCompression(t *testing.T) {
+	var gotEncoding, gotAccept string
+	var gotBody []byte
+	var gotErr error
+	example := gzipped(t, `{"zone": "example.com"}`)
+	missing := gzipped(t, `{"message": "zone not found"}`)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+		gotAccept = r.Header.Get("Accept-Encoding")
+		body := r.Body
+		if gotEncoding == "gzip" {
+			body, gotErr = gzip.NewReader(r.Body)
+			if gotErr != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+		gotBody, _ = ioutil.ReadAll(body)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/plain":
+			w.Write([]byte(`{"zone": "plain.com"}`))
+		case "/missing":
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(missing)
+		default:
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(example)
+		}
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetCompression(true))
+
+	large := map[string]string{"zone": "example.com", "pad": strings.Repeat("x", 2*compressMinBodySize)}
+	req, err := client.NewRequest("PUT", "zones/example.com", large)
+	require.Nil(t, err)
+	var v map[string]string
+	_, err = client.Do(req, &v)
+	require.Nil(t, gotErr)
+	require.Nil(t, err)
+	assert.Equal(t, "gzip", gotEncoding)
+	assert.Equal(t, "gzip", gotAccept)
+	assert.Contains(t, string(gotBody), `"pad":"xx`)
+	assert.Equal(t, "example.com", v["zone"])
+
+	// Small bodies are sent as is.
+	req, _ = client.NewRequest("PUT", "zones/example.com", map[string]string{"zone": "example.com"})
+	_, err = client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Empty(t, gotEncoding)
+	assert.JSONEq(t, `{"zone": "example.com"}`, string(gotBody))
+
+	req, _ = client.NewRequest("GET", "missing", nil)
+	_, err = client.Do(req, nil)
+	require.IsType(t, &Error{}, err)
+	assert.Equal(t, "zone not found", err.(*Error).Message)
+
+	req, _ = client.NewRequest("GET", "plain", nil)
+	_, err = client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, "plain.com", v["zone"])
+
+	// Without the option, large bodies are not compressed.
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"))
+	req, _ = client.NewRequest("PUT", "zones/example.com", large)
+	assert.Empty(t, req.Header.Get("Accept-Encoding"))
+	_, err = client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Empty(t, gotEncoding)
+}
+
+func TestClient_DecompressResponse(t *testing.T) {
+	// A custom Doer does not decompress for us.
+	doer := DoerFunc(func(r *http.Request) (*http.Response, error) {
+		h := http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {"application/json"}}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(bytes.NewReader(gzipped(t, `{"zone": "example.com"}`))),
+			Request:    r,
+		}, nil
+	})
+	client := NewClient(doer, SetEndpoint("https://api.example.com/v1/"))
+	req, _ := client.NewRequest("GET", "zones/example.com", nil)
+	var v map[string]string
+	resp, err := client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, "example.com", v["zone"])
+	assert.True(t, resp.Uncompressed)
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+}
+
+func TestClient_SetResponseCompression(t *testing.T) {
+	doer := DoerFunc(func(r *http.Request) (*http.Response, error) { return nil, nil })
+	accepts := func(c *Client) string {
+		req, err := c.NewRequest("GET", "zones", nil)
+		require.Nil(t, err)
+		return req.Header.Get("Accept-Encoding")
+	}
+
+	// Doers that don't decompress on their own are asked gzip for.
+	assert.Equal(t, "gzip", accepts(NewClient(doer)))
+	assert.Equal(t, "gzip", accepts(NewClient(&http.Client{Transport: &http.Transport{DisableCompression: true}})))
+	assert.Empty(t, accepts(NewClient(doer, SetResponseCompression(false))))
+	assert.Equal(t, "gzip", accepts(NewClient(doer, SetResponseCompression(false), SetCompression(true))))
+
+	// The standard transport asks for gzip itself.
+	assert.Empty(t, accepts(NewClient(nil)))
+	assert.Empty(t, accepts(NewClient(&http.Client{Transport: &http.Transport{}})))
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// ErrWriteNotVisible is returned by mutations made WithReadYourWrites when
+// the write could not be read back before the timeout. The write itself
+// succeeded.
+var ErrWriteNotVisible = errors.New("write not visible before read-your-writes timeout")
+
+// readYourWritesInterval is the delay between reads while waiting for a
+// write to become visible, shortened in tests.
+var readYourWritesInterval = 250 * time.Millisecond
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites makes a mutating call (RecordsService.Create and
+// Update) return only once the written resource is visible to reads, as
+// compared by dns.RecordsEqual, polling for at most timeout. Without it,
+// an immediate read may return stale data while the change propagates.
+//
+// If the write is not read back in time ErrWriteNotVisible is returned; if
+// the request's context is canceled first, its error is returned.
+func WithReadYourWrites(timeout time.Duration) RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), readYourWritesKey{}, timeout))
+	}
+}
+
+// readYourWritesTimeout returns the timeout set by WithReadYourWrites on req.
+func readYourWritesTimeout(req *http.Request) (time.Duration, bool) {
+	timeout, ok := req.Context().Value(readYourWritesKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// awaitRecord polls the record until it equals want, if the write request
+// req was made WithReadYourWrites. opts are applied to the reads as well.
+func (s *RecordsService) awaitRecord(req *http.Request, want *dns.Record, opts []RequestOption) (*http.Response, error) {
+	timeout, ok := readYourWritesTimeout(req)
+	if !ok {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	defer cancel()
+
+	path := fmt.Sprintf("zones/%s/%s/%s", want.Zone, want.Domain, want.Type)
+	for {
+		get, err := s.client.NewRequest("GET", path, nil, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		var got dns.Record
+		resp, err := s.client.Do(get.WithContext(ctx), &got)
+		if err == nil && dns.RecordsEqual(want, &got) {
+			return resp, nil
+		}
+		if e, ok := err.(*Error); ok && e.Message != "record not found" {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := req.Context().Err(); err != nil {
+				return resp, err
+			}
+			return resp, ErrWriteNotVisible
+		case <-time.After(readYourWritesInterval):
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestWithReadYourWrites(t *testing.T) {
+	defer func(d time.Duration) { readYourWritesInterval = d }(readYourWritesInterval)
+	readYourWritesInterval = time.Millisecond
+
+	var visibleAfter, reads int32
+	var written atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			b, _ := ioutil.ReadAll(r.Body)
+			written.Store(b)
+			w.Write(b) // nolint: errcheck
+			return
+		}
+		switch n := atomic.AddInt32(&reads, 1); {
+		case n == 1:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "record not found"}`)) // nolint: errcheck
+		case n < atomic.LoadInt32(&visibleAfter):
+			w.Write([]byte(`{"zone":"example.com","domain":"www.example.com","type":"A","ttl":60,"answers":[]}`)) // nolint: errcheck
+		default:
+			w.Write(written.Load().([]byte)) // nolint: errcheck
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	newRecord := func() *dns.Record {
+		r := dns.NewRecord("example.com", "www.example.com", "A")
+		r.TTL = 300
+		r.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+		return r
+	}
+
+	atomic.StoreInt32(&visibleAfter, 3)
+	_, err := c.Records.Create(newRecord(), WithReadYourWrites(time.Second))
+	require.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&reads))
+
+	// Without the option, nothing is read.
+	atomic.StoreInt32(&reads, 0)
+	_, err = c.Records.Update(newRecord())
+	require.Nil(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&reads))
+
+	atomic.StoreInt32(&reads, 0)
+	atomic.StoreInt32(&visibleAfter, 1<<30)
+	_, err = c.Records.Update(newRecord(), WithReadYourWrites(20*time.Millisecond))
+	assert.Equal(t, ErrWriteNotVisible, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	withCtx := func(req *http.Request) { *req = *req.WithContext(ctx) }
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err = c.Records.Update(newRecord(), withCtx, WithReadYourWrites(time.Minute))
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
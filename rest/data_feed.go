@@ -13,10 +13,10 @@ type DataFeedsService service
 // List returns all data feeds connected to a given data source.
 //
 // NS1 API docs: https://ns1.com/api/#feeds-get
-func (s *DataFeedsService) List(sourceID string) ([]*data.Feed, *http.Response, error) {
+func (s *DataFeedsService) List(sourceID string, opts ...RequestOption) ([]*data.Feed, *http.Response, error) {
 	path := fmt.Sprintf("data/feeds/%s", sourceID)
 
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
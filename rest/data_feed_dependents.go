@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"net/http"
+	"sort"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+const defaultDependentsParallelism = 4
+
+// FeedDependent is a record, region or answer whose metadata references a
+// data feed, as found by DataFeedsService.Dependents.
+type FeedDependent struct {
+	Zone   string `json:"zone"`
+	Domain string `json:"domain"`
+	Type   string `json:"type"`
+
+	// Level is where the reference is: "record", "region" or "answer",
+	// matching data.Destination's Type.
+	Level string `json:"level"`
+	// Region is the region name for the region level.
+	Region string `json:"region,omitempty"`
+	// Answer is the answer's rdata for the answer level.
+	Answer []string `json:"answer,omitempty"`
+	// Fields are the metadata fields (e.g. "up") pointing at the feed.
+	Fields []string `json:"fields"`
+}
+
+// Dependents returns the records, regions and answers whose metadata
+// references the given feed, e.g. to check that a feed or source is unused
+// before deleting it.
+//
+// The feed's destinations are used to narrow the scan to the records NS1
+// lists as targets; if it has none, every record of every zone is read.
+// Records are read concurrently, so large accounts should be queried with
+// RateLimitStrategyConcurrent set up for the same parallelism (4).
+func (s *DataFeedsService) Dependents(sourceID, feedID string) ([]*FeedDependent, *http.Response, error) {
+	feed, resp, err := s.Get(sourceID, feedID)
+	if err != nil {
+		return nil, resp, err
+	}
+	targets := map[string]bool{}
+	for _, d := range feed.Destinations {
+		targets[d.RecordID] = true
+	}
+
+	zones, resp, err := s.client.Zones.List()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	type ref struct {
+		zone string
+		zr   *dns.ZoneRecord
+	}
+	var refs []ref
+	for _, z := range zones {
+		zone, resp, err := s.client.Zones.Get(z.Zone)
+		if err != nil {
+			return nil, resp, err
+		}
+		for _, zr := range zone.Records {
+			if len(targets) == 0 || targets[zr.ID] {
+				refs = append(refs, ref{zone: z.Zone, zr: zr})
+			}
+		}
+	}
+
+	found := make([][]*FeedDependent, len(refs))
+	errs := make([]error, len(refs))
+	resps := make([]*http.Response, len(refs))
+	parallel(len(refs), defaultDependentsParallelism, func(i int) {
+		r, resp, err := s.client.Records.Get(refs[i].zone, refs[i].zr.Domain, refs[i].zr.Type)
+		resps[i] = resp
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		found[i] = feedDependents(r, feedID)
+	})
+
+	deps := []*FeedDependent{}
+	for i := range refs {
+		if errs[i] != nil {
+			return nil, resps[i], errs[i]
+		}
+		deps = append(deps, found[i]...)
+	}
+	return deps, resp, nil
+}
+
+// feedDependents returns the references to feedID in the record metadata,
+// region metadata and answer metadata of r.
+func feedDependents(r *dns.Record, feedID string) []*FeedDependent {
+	var deps []*FeedDependent
+	add := func(meta *data.Meta, dep FeedDependent) {
+		for field, id := range meta.FeedRefs() {
+			if id == feedID {
+				dep.Fields = append(dep.Fields, field)
+			}
+		}
+		if len(dep.Fields) > 0 {
+			sort.Strings(dep.Fields)
+			dep.Zone, dep.Domain, dep.Type = r.Zone, r.Domain, r.Type
+			deps = append(deps, &dep)
+		}
+	}
+
+	add(r.Meta, FeedDependent{Level: "record"})
+
+	names := make([]string, 0, len(r.Regions))
+	for name := range r.Regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		region := r.Regions[name]
+		add(&region.Meta, FeedDependent{Level: "region", Region: name})
+	}
+
+	for _, a := range r.Answers {
+		add(a.Meta, FeedDependent{Level: "answer", Answer: a.Rdata})
+	}
+	return deps
+}
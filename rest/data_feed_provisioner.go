@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// FeedProvisioningState records the data sources and feeds created by a
+// FeedProvisioner, so they can be torn down later, possibly by another
+// process: it marshals to JSON and can be passed back to NewFeedProvisioner.
+type FeedProvisioningState struct {
+	// SourceIDs are the ids of the created data sources.
+	SourceIDs []string `json:"source_ids,omitempty"`
+	// Feeds are the created feeds, by source id and feed id.
+	Feeds []ProvisionedFeed `json:"feeds,omitempty"`
+}
+
+// ProvisionedFeed identifies a feed created by a FeedProvisioner.
+type ProvisionedFeed struct {
+	SourceID string `json:"source_id"`
+	FeedID   string `json:"feed_id"`
+}
+
+// FeedProvisioner binds answers to health feeds of a data source, creating
+// the source and feeds as needed, and keeps track of what it created so
+// Teardown can remove exactly that: sources and feeds that existed before
+// are reused but never deleted. It is safe for concurrent use.
+type FeedProvisioner struct {
+	client *Client
+
+	// SourceName and SourceType identify the data source feeds are created
+	// in; it is created if the account has no source of that name and type.
+	SourceName string
+	SourceType string
+
+	mu    sync.Mutex
+	state *FeedProvisioningState
+}
+
+// NewFeedProvisioner returns a FeedProvisioner creating feeds in the data
+// source named sourceName of type sourceType (e.g. "nsone_v1"). state holds
+// the resources created earlier, e.g. by a previous run; if nil, it starts
+// empty.
+func NewFeedProvisioner(c *Client, sourceName, sourceType string, state *FeedProvisioningState) *FeedProvisioner {
+	if state == nil {
+		state = &FeedProvisioningState{}
+	}
+	return &FeedProvisioner{client: c, SourceName: sourceName, SourceType: sourceType, state: state}
+}
+
+// State returns a copy of the resources created and not yet torn down.
+func (p *FeedProvisioner) State() FeedProvisioningState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return FeedProvisioningState{
+		SourceIDs: append([]string(nil), p.state.SourceIDs...),
+		Feeds:     append([]ProvisionedFeed(nil), p.state.Feeds...),
+	}
+}
+
+// BindAnswer points the 'up' metadata of the answer of the given record
+// whose rdata matches at the feed named feedName of the provisioner's data
+// source, creating the source and feed if needed (see
+// RecordsService.BindAnswerToFeed).
+func (p *FeedProvisioner) BindAnswer(zone, domain, t, rdata, feedName string) (*data.Feed, *http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	src, resp, err := p.ensureSource()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	feeds, resp, err := p.client.DataFeeds.List(src.ID)
+	if err != nil {
+		return nil, resp, err
+	}
+	existed := false
+	for _, f := range feeds {
+		if f.Name == feedName {
+			existed = true
+			break
+		}
+	}
+
+	feed, resp, err := p.client.Records.BindAnswerToFeed(zone, domain, t, rdata, src.ID, feedName)
+	if err != nil {
+		return nil, resp, err
+	}
+	if !existed {
+		p.state.Feeds = append(p.state.Feeds, ProvisionedFeed{SourceID: src.ID, FeedID: feed.ID})
+	}
+	return feed, resp, nil
+}
+
+// ensureSource returns the provisioner's data source, creating it if needed.
+func (p *FeedProvisioner) ensureSource() (*data.Source, *http.Response, error) {
+	sources, resp, err := p.client.DataSources.List()
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, src := range sources {
+		if src.Name == p.SourceName && src.Type == p.SourceType {
+			return src, resp, nil
+		}
+	}
+
+	src := data.NewSource(p.SourceName, p.SourceType)
+	if resp, err = p.client.DataSources.Create(src); err != nil {
+		return nil, resp, err
+	}
+	p.state.SourceIDs = append(p.state.SourceIDs, src.ID)
+	return src, resp, nil
+}
+
+// Teardown deletes the feeds and then the data sources the provisioner
+// created, and removes them from its state as they are deleted. Resources
+// that are already gone count as deleted, so Teardown is idempotent and can
+// be retried after an error. Records are not modified: answers bound to a
+// removed feed keep their last value, so rebind or remove them first.
+func (p *FeedProvisioner) Teardown() (*http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var resp *http.Response
+	for len(p.state.Feeds) > 0 {
+		f := p.state.Feeds[0]
+		r, err := p.client.DataFeeds.Delete(f.SourceID, f.FeedID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return r, err
+		}
+		resp = r
+		p.state.Feeds = p.state.Feeds[1:]
+	}
+	for len(p.state.SourceIDs) > 0 {
+		r, err := p.client.DataSources.Delete(p.state.SourceIDs[0])
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return r, err
+		}
+		resp = r
+		p.state.SourceIDs = p.state.SourceIDs[1:]
+	}
+	return resp, nil
+}
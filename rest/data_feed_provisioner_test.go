@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedProvisioner(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		sources = map[string]bool{}
+		feeds   = map[string]string{"f0": "pre"}
+		deleted []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		write := func(body string) { w.Write([]byte(body)) } // nolint: errcheck
+		path := r.Method + " " + r.URL.Path
+		switch path {
+		case "GET /data/sources":
+			if sources["s1"] {
+				write(`[{"id":"s1","name":"health","sourcetype":"nsone_v1"}]`)
+			} else {
+				write(`[{"id":"s0","name":"other","sourcetype":"nsone_v1"}]`)
+			}
+		case "PUT /data/sources":
+			sources["s1"] = true
+			write(`{"id":"s1","name":"health","sourcetype":"nsone_v1"}`)
+		case "GET /data/feeds/s1":
+			list := []map[string]string{}
+			for id, name := range feeds {
+				list = append(list, map[string]string{"id": id, "name": name})
+			}
+			json.NewEncoder(w).Encode(list) // nolint: errcheck
+		case "PUT /data/feeds/s1":
+			feeds["f1"] = "web"
+			write(`{"id":"f1","name":"web"}`)
+		case "GET /data/feeds/s1/f0", "GET /data/feeds/s1/f1":
+			id := r.URL.Path[len("/data/feeds/s1/"):]
+			write(`{"id":"` + id + `","name":"` + feeds[id] + `"}`)
+		case "GET /zones/example.com/www.example.com/A":
+			write(`{"zone":"example.com","domain":"www.example.com","type":"A","answers":[{"answer":["1.2.3.4"]},{"answer":["5.6.7.8"]}]}`)
+		case "POST /zones/example.com/www.example.com/A":
+			write(`{"zone":"example.com","domain":"www.example.com","type":"A"}`)
+		case "DELETE /data/feeds/s1/f1", "DELETE /data/sources/s1":
+			deleted = append(deleted, r.URL.Path)
+			write(`{}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			write(`{"message":"not found"}`)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	p := NewFeedProvisioner(c, "health", "nsone_v1", nil)
+
+	feed, _, err := p.BindAnswer("example.com", "www.example.com", "A", "1.2.3.4", "web")
+	require.Nil(t, err)
+	assert.Equal(t, "f1", feed.ID)
+
+	// Pre-existing feeds are reused and not recorded.
+	feed, _, err = p.BindAnswer("example.com", "www.example.com", "A", "5.6.7.8", "pre")
+	require.Nil(t, err)
+	assert.Equal(t, "f0", feed.ID)
+
+	state := p.State()
+	assert.Equal(t, FeedProvisioningState{
+		SourceIDs: []string{"s1"},
+		Feeds:     []ProvisionedFeed{{SourceID: "s1", FeedID: "f1"}},
+	}, state)
+
+	// Teardown can be resumed from a saved state, and only removes what was
+	// created.
+	b, err := json.Marshal(state)
+	require.Nil(t, err)
+	var saved FeedProvisioningState
+	require.Nil(t, json.Unmarshal(b, &saved))
+	p = NewFeedProvisioner(c, "health", "nsone_v1", &saved)
+
+	_, err = p.Teardown()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"/data/feeds/s1/f1", "/data/sources/s1"}, deleted)
+	assert.Empty(t, p.State().Feeds)
+	assert.Empty(t, p.State().SourceIDs)
+
+	_, err = p.Teardown()
+	require.Nil(t, err)
+	assert.Len(t, deleted, 2)
+
+	// Resources deleted elsewhere count as torn down.
+	p = NewFeedProvisioner(c, "health", "nsone_v1", &FeedProvisioningState{
+		Feeds: []ProvisionedFeed{{SourceID: "s1", FeedID: "gone"}},
+	})
+	_, err = p.Teardown()
+	require.Nil(t, err)
+	assert.Empty(t, p.State().Feeds)
+}
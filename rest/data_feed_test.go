@@ -0,0 +1,57 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestDataFeed(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("Dependents", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		feed := &data.Feed{ID: "f1", Name: "web1", Destinations: []data.Destination{{RecordID: "r1"}}}
+		zone := &dns.Zone{Zone: "example.com", Records: []*dns.ZoneRecord{
+			{ID: "r1", Domain: "www.example.com", Type: "A"},
+			{ID: "r2", Domain: "mail.example.com", Type: "A"},
+		}}
+		up := map[string]interface{}{"feed": "f1"}
+		record := &dns.Record{
+			Zone: "example.com", Domain: "www.example.com", Type: "A",
+			Regions: data.Regions{"us": {Meta: data.Meta{Up: up}}},
+			Answers: []*dns.Answer{
+				{Rdata: []string{"1.1.1.1"}, Meta: &data.Meta{Up: up, Priority: map[string]interface{}{"feed": "f1"}}},
+				{Rdata: []string{"2.2.2.2"}, Meta: &data.Meta{Up: map[string]interface{}{"feed": "f2"}}},
+			},
+		}
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/src1/f1", http.StatusOK, nil, nil, "", feed))
+		require.Nil(t, mock.AddZoneListTestCase(nil, nil, []*dns.Zone{{Zone: "example.com"}}))
+		require.Nil(t, mock.AddZoneGetTestCase("example.com", nil, nil, zone))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/www.example.com/A", http.StatusOK, nil, nil, "", record))
+
+		deps, _, err := client.DataFeeds.Dependents("src1", "f1")
+		require.Nil(t, err)
+		require.Len(t, deps, 2)
+		assert.Equal(t, &api.FeedDependent{
+			Zone: "example.com", Domain: "www.example.com", Type: "A",
+			Level: "region", Region: "us", Fields: []string{"up"},
+		}, deps[0])
+		assert.Equal(t, "answer", deps[1].Level)
+		assert.Equal(t, []string{"1.1.1.1"}, deps[1].Answer)
+		assert.Equal(t, []string{"priority", "up"}, deps[1].Fields)
+	})
+}
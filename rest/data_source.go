@@ -2,7 +2,9 @@ package rest
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"sort"
 
 	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 )
@@ -13,8 +15,8 @@ type DataSourcesService service
 // List returns all connected data sources.
 //
 // NS1 API docs: https://ns1.com/api/#sources-get
-func (s *DataSourcesService) List() ([]*data.Source, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "data/sources", nil)
+func (s *DataSourcesService) List(opts ...RequestOption) ([]*data.Source, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "data/sources", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -124,3 +126,94 @@ func (s *DataSourcesService) Publish(dsID string, data interface{}) (*http.Respo
 
 	return resp, nil
 }
+
+// PublishFeed publishes meta to the feed with label feedLabel of the data
+// source sourceID, e.g. &data.Meta{Up: false} to fail over the answers whose
+// up metadata is bound to it. Only the fields set in meta are sent.
+//
+// NS1 API docs: https://ns1.com/api/#feed-post
+func (s *DataSourcesService) PublishFeed(sourceID, feedLabel string, meta *data.Meta) (*http.Response, error) {
+	return s.Publish(sourceID, map[string]*data.Meta{feedLabel: meta})
+}
+
+// PublishBatch publishes data to several feeds of the data source sourceID
+// in a single request, e.g. to flip many answers during a mass failover.
+// feeds maps feed IDs to the data to publish to each.
+//
+// All feed IDs are first checked against the source's feeds: if any is
+// unknown (ErrFeedMissing) or has no label to publish to, nothing is sent.
+// The returned map holds the result of every feed; since the API applies a
+// batch as a whole, all entries share the outcome of the request once it is
+// sent.
+//
+// NS1 API docs: https://ns1.com/api/#feed-post
+func (s *DataSourcesService) PublishBatch(sourceID string, feeds map[string]interface{}) (map[string]error, *http.Response, error) {
+	known, resp, err := s.client.DataFeeds.List(sourceID)
+	if err != nil {
+		return nil, resp, err
+	}
+	labels := make(map[string]string, len(known))
+	for _, f := range known {
+		label, _ := f.Config["label"].(string)
+		labels[f.ID] = label
+	}
+
+	results := make(map[string]error, len(feeds))
+	payload := make(map[string]interface{}, len(feeds))
+	ids := make([]string, 0, len(feeds))
+	for id := range feeds {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs MultiError
+	for _, id := range ids {
+		label, ok := labels[id]
+		switch {
+		case !ok:
+			results[id] = ErrFeedMissing
+		case label == "":
+			results[id] = fmt.Errorf("feed %s of data source %s has no label to publish to", id, sourceID)
+		default:
+			payload[label] = feeds[id]
+			continue
+		}
+		errs = append(errs, fmt.Errorf("feed %s: %w", id, results[id]))
+	}
+	if len(errs) > 0 {
+		return results, resp, errs
+	}
+
+	resp, err = s.Publish(sourceID, payload)
+	for id := range feeds {
+		results[id] = err
+	}
+	return results, resp, err
+}
+
+// PublishIPPrefixes publishes a new ip_prefixes list to the feed with label
+// feedLabel of the data source sourceID, updating every answer whose
+// ip_prefixes metadata is bound to it (see
+// RecordsService.BindAnswerMetaToFeed). All prefixes must be valid CIDRs;
+// otherwise nothing is published and a MultiError lists the invalid ones.
+func (s *DataSourcesService) PublishIPPrefixes(sourceID, feedLabel string, prefixes []string) (*http.Response, error) {
+	var errs MultiError
+	for _, p := range prefixes {
+		if _, _, err := net.ParseCIDR(p); err != nil {
+			errs = append(errs, fmt.Errorf("%s is not a valid CIDR block", p))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return s.Publish(sourceID, map[string]interface{}{
+		feedLabel: map[string]interface{}{"ip_prefixes": prefixes},
+	})
+}
+
+var (
+	// ErrFeedMissing is returned for feeds that are not connected to the
+	// data source.
+	ErrFeedMissing = missingError("feed does not exist")
+)
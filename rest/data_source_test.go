@@ -0,0 +1,88 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+func TestDataSource(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	feeds := []*data.Feed{
+		{ID: "f1", Name: "web1", Config: data.Config{"label": "web1"}},
+		{ID: "f2", Name: "web2", Config: data.Config{"label": "web2"}},
+		{ID: "f3", Name: "unlabeled", Config: data.Config{}},
+	}
+
+	t.Run("PublishBatch", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/src1", http.StatusOK, nil, nil, "", feeds))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/feed/src1", http.StatusOK, nil, nil,
+			json.RawMessage(`{"web1": {"up": false}, "web2": {"up": true}}`), json.RawMessage(`{}`)))
+
+		results, _, err := client.DataSources.PublishBatch("src1", map[string]interface{}{
+			"f1": map[string]interface{}{"up": false},
+			"f2": map[string]interface{}{"up": true},
+		})
+		require.Nil(t, err)
+		assert.Equal(t, map[string]error{"f1": nil, "f2": nil}, results)
+	})
+
+	t.Run("PublishBatch invalid feeds", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/src1", http.StatusOK, nil, nil, "", feeds))
+
+		results, _, err := client.DataSources.PublishBatch("src1", map[string]interface{}{
+			"f1":    map[string]interface{}{"up": false},
+			"f3":    map[string]interface{}{"up": false},
+			"other": map[string]interface{}{"up": false},
+		})
+		require.NotNil(t, err)
+		errs, ok := err.(api.MultiError)
+		require.True(t, ok)
+		assert.Len(t, errs, 2)
+		assert.Nil(t, results["f1"])
+		assert.NotNil(t, results["f3"])
+		assert.Equal(t, api.ErrFeedMissing, results["other"])
+	})
+
+	t.Run("PublishFeed", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/feed/src1", http.StatusOK, nil, nil,
+			json.RawMessage(`{"web1": {"up": false, "connections": 12}}`), json.RawMessage(`{}`)))
+
+		_, err := client.DataSources.PublishFeed("src1", "web1", &data.Meta{Up: false, Connections: 12})
+		require.Nil(t, err)
+	})
+
+	t.Run("PublishIPPrefixes", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/feed/src1", http.StatusOK, nil, nil,
+			json.RawMessage(`{"cdn": {"ip_prefixes": ["192.0.2.0/24", "2001:db8::/32"]}}`), json.RawMessage(`{}`)))
+
+		_, err := client.DataSources.PublishIPPrefixes("src1", "cdn", []string{"192.0.2.0/24", "2001:db8::/32"})
+		require.Nil(t, err)
+
+		_, err = client.DataSources.PublishIPPrefixes("src1", "cdn", []string{"192.0.2.0/24", "192.0.2.1", "nope"})
+		require.NotNil(t, err)
+		errs, ok := err.(api.MultiError)
+		require.True(t, ok)
+		assert.Len(t, errs, 2)
+	})
+}
@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// SourceTypes returns the available data source types and their config
+// schemas.
+//
+// NS1 API docs: https://ns1.com/api/#sourcetypes-get
+func (s *DataSourcesService) SourceTypes() ([]*data.SourceType, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "data/sourcetypes", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sts := []*data.SourceType{}
+	resp, err := s.client.Do(req, &sts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sts, resp, nil
+}
+
+// cachedSourceTypes returns the source types keyed by type, cached with the
+// same TTL as the monitoring catalogs (see SetCatalogTTL).
+func (s *DataSourcesService) cachedSourceTypes() (map[string]*data.SourceType, error) {
+	cc := s.client.catalogs
+	cc.mu.Lock()
+	sts := cc.sourceTypes
+	fresh := sts != nil && cc.fresh(cc.sourceTypesAt)
+	cc.mu.Unlock()
+
+	if fresh {
+		return sts, nil
+	}
+
+	list, _, err := s.SourceTypes()
+	if err != nil {
+		return nil, err
+	}
+	sts = make(map[string]*data.SourceType, len(list))
+	for _, st := range list {
+		sts[st.Type] = st
+	}
+
+	cc.mu.Lock()
+	cc.sourceTypes, cc.sourceTypesAt = sts, time.Now()
+	cc.mu.Unlock()
+
+	return sts, nil
+}
+
+// ValidateConfig checks a data source config against the schema of its
+// source type, client-side: every required key must be present, and values
+// must have the type the schema declares. Source types are fetched on first
+// use and cached. A MultiError listing every problem is returned, or nil.
+func (s *DataSourcesService) ValidateConfig(sourceType string, config map[string]interface{}) error {
+	sts, err := s.cachedSourceTypes()
+	if err != nil {
+		return err
+	}
+	st, ok := sts[sourceType]
+	if !ok {
+		return fmt.Errorf("unknown data source type %q", sourceType)
+	}
+
+	return validateConfigFields(st.Config, config)
+}
+
+// ValidateFeedConfig is like ValidateConfig, for the config of a feed of a
+// source of the given type.
+func (s *DataSourcesService) ValidateFeedConfig(sourceType string, config map[string]interface{}) error {
+	sts, err := s.cachedSourceTypes()
+	if err != nil {
+		return err
+	}
+	st, ok := sts[sourceType]
+	if !ok {
+		return fmt.Errorf("unknown data source type %q", sourceType)
+	}
+
+	return validateConfigFields(st.FeedConfig, config)
+}
+
+func validateConfigFields(fields []*data.ConfigField, config map[string]interface{}) error {
+	var errs MultiError
+
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.Name] = true
+		v, ok := config[f.Name]
+		if !ok || v == nil {
+			if f.Required {
+				errs = append(errs, fmt.Errorf("config key %q is required", f.Name))
+			}
+			continue
+		}
+		if !configValueHasType(v, f.Type) {
+			errs = append(errs, fmt.Errorf("config key %q must be of type %s, got %T", f.Name, f.Type, v))
+		}
+	}
+
+	var unknown []string
+	for k := range config {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		errs = append(errs, fmt.Errorf("config key %q is not accepted", k))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// configValueHasType reports whether v matches a schema type. Unknown schema
+// types are not checked.
+func configValueHasType(v interface{}, t string) bool {
+	k := reflect.ValueOf(v).Kind()
+	switch t {
+	case "string", "text", "password", "url":
+		return k == reflect.String
+	case "number", "int", "integer", "float":
+		switch k {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	case "bool", "boolean":
+		return k == reflect.Bool
+	case "list", "array":
+		return k == reflect.Slice || k == reflect.Array
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourcesService_ValidateConfig(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/monitoring/jobtypes":
+			w.Write([]byte(`{}`)) // nolint: errcheck
+			return
+		case "/monitoring/regions":
+			w.Write([]byte(`[]`)) // nolint: errcheck
+			return
+		}
+		hits++
+		w.Write([]byte(`[{
+			"sourcetype": "nsone_monitoring",
+			"name": "NS1 Monitoring",
+			"config": [],
+			"feed_config": [{"name": "jobid", "type": "string", "required": true}]
+		}, {
+			"sourcetype": "datadog",
+			"name": "Datadog",
+			"config": [
+				{"name": "api_key", "type": "string", "required": true},
+				{"name": "interval", "type": "number"},
+				{"name": "enabled", "type": "bool"}
+			]
+		}]`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	sts, _, err := c.DataSources.SourceTypes()
+	require.NoError(t, err)
+	assert.Len(t, sts, 2)
+	hits = 0
+
+	assert.Nil(t, c.DataSources.ValidateConfig("datadog", map[string]interface{}{"api_key": "k", "interval": 30}))
+	assert.Nil(t, c.DataSources.ValidateFeedConfig("nsone_monitoring", map[string]interface{}{"jobid": "j1"}))
+
+	err = c.DataSources.ValidateConfig("datadog", map[string]interface{}{"interval": "30", "enabled": true, "extra": 1})
+	require.NotNil(t, err)
+	errs, ok := err.(MultiError)
+	require.True(t, ok)
+	assert.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), `"api_key" is required`)
+	assert.Contains(t, errs[1].Error(), `"interval" must be of type number`)
+	assert.Contains(t, errs[2].Error(), `"extra" is not accepted`)
+
+	assert.NotNil(t, c.DataSources.ValidateConfig("nope", nil))
+	assert.Equal(t, 1, hits)
+
+	// Refreshing the catalogs drops the cached source types.
+	_, err = c.Jobs.RefreshCatalogs(context.Background())
+	require.Nil(t, err)
+	assert.Nil(t, c.DataSources.ValidateConfig("datadog", map[string]interface{}{"api_key": "k"}))
+	assert.Equal(t, 2, hits)
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dataset"
+)
+
+// DatasetsService handles 'datasets' endpoint.
+type DatasetsService service
+
+// List returns all datasets of the account.
+//
+// NS1 API docs: https://ns1.com/api/#datasets-get
+func (s *DatasetsService) List(opts ...RequestOption) ([]*dataset.Dataset, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "datasets", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dl := []*dataset.Dataset{}
+	resp, err := s.client.Do(req, &dl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return dl, resp, nil
+}
+
+// Get takes a dataset id and returns the dataset, including the status of
+// its reports.
+//
+// NS1 API docs: https://ns1.com/api/#datasets-id-get
+func (s *DatasetsService) Get(id string) (*dataset.Dataset, *http.Response, error) {
+	path := fmt.Sprintf("datasets/%s", id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var d dataset.Dataset
+	resp, err := s.client.Do(req, &d)
+	if err != nil {
+		return nil, resp, datasetError(err)
+	}
+
+	return &d, resp, nil
+}
+
+// Create takes a *Dataset and creates it, queueing the generation of its
+// first report. The dataset's ID and Reports are set from the response.
+//
+// NS1 API docs: https://ns1.com/api/#datasets-put
+func (s *DatasetsService) Create(d *dataset.Dataset) (*http.Response, error) {
+	req, err := s.client.NewRequest("PUT", "datasets", &d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update dataset fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &d)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Delete takes a dataset id and deletes the dataset and its reports.
+//
+// NS1 API docs: https://ns1.com/api/#datasets-id-delete
+func (s *DatasetsService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("datasets/%s", id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, datasetError(err)
+	}
+
+	return resp, nil
+}
+
+// DownloadReport writes the file of an available report of a dataset to w.
+//
+// NS1 API docs: https://ns1.com/api/#datasets-id-reports-reportid-get
+func (s *DatasetsService) DownloadReport(ctx context.Context, datasetID, reportID string, w io.Writer) (*http.Response, error) {
+	path := fmt.Sprintf("datasets/%s/reports/%s", datasetID, reportID)
+
+	req, err := s.client.NewRequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, w)
+	if err != nil {
+		return resp, datasetError(err)
+	}
+
+	return resp, nil
+}
+
+// WaitForReport polls a dataset every interval until its latest report is
+// done, and returns that report. An error is returned if the report failed,
+// and ctx.Err() if ctx is done first. Use DownloadReport to fetch the file.
+// The interval must be positive.
+func (s *DatasetsService) WaitForReport(ctx context.Context, datasetID string, interval time.Duration) (*dataset.Report, *http.Response, error) {
+	if interval <= 0 {
+		return nil, nil, fmt.Errorf("invalid polling interval %v", interval)
+	}
+	datasets := s.client.WithContext(ctx).Datasets
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d, resp, err := datasets.Get(datasetID)
+		if ctx.Err() != nil {
+			return nil, resp, ctx.Err()
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+		if n := len(d.Reports); n > 0 {
+			r := d.Reports[n-1]
+			switch r.Status {
+			case dataset.ReportStatusAvailable:
+				return r, resp, nil
+			case dataset.ReportStatusFailed:
+				return r, resp, fmt.Errorf("report %s of dataset %s failed", r.ID, datasetID)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, resp, ctx.Err()
+		}
+	}
+}
+
+// datasetError maps a 404 for a dataset or report to ErrDatasetMissing.
+func datasetError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrDatasetMissing
+	}
+	return err
+}
+
+var (
+	// ErrDatasetMissing bundles GET/DELETE error.
+	ErrDatasetMissing = missingError("dataset does not exist")
+)
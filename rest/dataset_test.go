@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dataset"
+)
+
+func TestDatasets(t *testing.T) {
+	polls := 0
+	var created []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /datasets":
+			created, _ = ioutil.ReadAll(r.Body)
+			w.Write([]byte(`{"id": "ds1", "name": "queries", "reports": [{"id": "r1", "status": "queued"}]}`))
+		case "GET /datasets/ds1":
+			polls++
+			status := "generating"
+			if polls >= 3 {
+				status = "available"
+			}
+			w.Write([]byte(`{"id": "ds1", "reports": [{"id": "r1", "status": "` + status + `"}]}`))
+		case "GET /datasets/ds2":
+			w.Write([]byte(`{"id": "ds2", "reports": [{"id": "r2", "status": "failed"}]}`))
+		case "GET /datasets/ds3":
+			w.Write([]byte(`{"id": "ds3", "reports": [{"id": "r3", "status": "queued"}]}`))
+		case "GET /datasets/ds1/reports/r1":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("zone,queries\nexample.com,42\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "dataset not found"}`))
+		}
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	cycles := 1
+	d := &dataset.Dataset{Name: "queries", Timeframe: &dataset.Timeframe{Aggregation: dataset.TimeframeAggregationMonthly, Cycles: &cycles}}
+	_, err := client.Datasets.Create(d)
+	require.Nil(t, err)
+	assert.Equal(t, "ds1", d.ID)
+	// Unset and read-only timestamps are left out.
+	assert.NotContains(t, string(created), "created_at")
+	assert.NotContains(t, string(created), `"from"`)
+
+	r, _, err := client.Datasets.WaitForReport(context.Background(), "ds1", time.Millisecond)
+	require.Nil(t, err)
+	assert.Equal(t, "r1", r.ID)
+	assert.Equal(t, 3, polls)
+
+	var buf bytes.Buffer
+	_, err = client.Datasets.DownloadReport(context.Background(), "ds1", r.ID, &buf)
+	require.Nil(t, err)
+	assert.Equal(t, "zone,queries\nexample.com,42\n", buf.String())
+
+	r, _, err = client.Datasets.WaitForReport(context.Background(), "ds2", time.Millisecond)
+	require.NotNil(t, err)
+	assert.Equal(t, dataset.ReportStatusFailed, r.Status)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = client.Datasets.WaitForReport(ctx, "ds3", 5*time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	_, _, err = client.Datasets.WaitForReport(context.Background(), "ds1", 0)
+	assert.NotNil(t, err)
+
+	_, _, err = client.Datasets.Get("missing")
+	assert.Equal(t, ErrDatasetMissing, err)
+}
@@ -1,6 +1,9 @@
 package rest
 
-import "gopkg.in/ns1/ns1-go.v2/rest/model/account"
+import (
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
 
 // ddiTeam wraps an NS1 /accounts/teams resource for DDI.
 // Used for internally mapping between DDI permissions to maintain backwards compatibility.
@@ -15,7 +18,7 @@ type ddiTeam struct {
 // Used for internally mapping between DDI permissions to maintain backwards compatibility.
 type ddiUser struct {
 	// Read-only fields
-	LastAccess float64 `json:"last_access"`
+	LastAccess *model.Time `json:"last_access,omitempty"`
 
 	Name              string                       `json:"name"`
 	Username          string                       `json:"username"`
@@ -32,9 +35,9 @@ type ddiUser struct {
 // Used for internally mapping between DDI permissions to maintain backwards compatibility.
 type ddiAPIKey struct {
 	// Read-only fields
-	ID         string `json:"id,omitempty"`
-	Key        string `json:"key,omitempty"`
-	LastAccess int    `json:"last_access,omitempty"`
+	ID         string      `json:"id,omitempty"`
+	Key        string      `json:"key,omitempty"`
+	LastAccess *model.Time `json:"last_access,omitempty"`
 
 	Name              string   `json:"name"`
 	TeamIDs           []string `json:"teams"`
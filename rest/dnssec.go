@@ -41,6 +41,60 @@ func (s *DNSSECService) Get(zone string) (*dns.ZoneDNSSEC, *http.Response, error
 	return &d, resp, nil
 }
 
+// IsEnabled takes a zone and reports whether DNSSEC is enabled on it, read
+// from the zone's dnssec flag. It is cheaper than Get as no key material is
+// fetched. ErrZoneMissing is returned if the zone does not exist.
+//
+// NS1 API docs: https://ns1.com/api/#zones-zone-get
+func (s *DNSSECService) IsEnabled(zone string) (bool, *http.Response, error) {
+	z, resp, err := s.client.Zones.Get(zone)
+	if err != nil {
+		return false, resp, err
+	}
+
+	return z.DNSSEC != nil && *z.DNSSEC, resp, nil
+}
+
+// Enable turns on DNSSEC for a zone. NS1 signs the zone and generates its
+// keys; the DS records to publish at the registrar are then returned by Get.
+//
+// NS1 API docs: https://ns1.com/api/#zones-post
+func (s *DNSSECService) Enable(zone string) (*http.Response, error) {
+	return s.set(zone, true)
+}
+
+// Disable turns off DNSSEC for a zone. Remove the zone's DS records from the
+// registrar first, or resolvers validating the zone will fail.
+//
+// NS1 API docs: https://ns1.com/api/#zones-post
+func (s *DNSSECService) Disable(zone string) (*http.Response, error) {
+	return s.set(zone, false)
+}
+
+// set updates only the zone's dnssec flag, leaving its other settings as
+// they are.
+func (s *DNSSECService) set(zone string, enabled bool) (*http.Response, error) {
+	path := fmt.Sprintf("zones/%s", zone)
+
+	req, err := s.client.NewRequest("POST", path, map[string]bool{"dnssec": enabled})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			if err.(*Error).Message == "zone not found" {
+				return resp, ErrZoneMissing
+			}
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
 var (
 	// ErrDNSECNotEnabled if DNSSEC is not enabled for the zone, regardless of
 	// account-level DNSSEC permission.
@@ -0,0 +1,66 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestDNSSEC(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("IsEnabled", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		enabled := true
+		require.Nil(t, mock.AddZoneGetTestCase("signed.zone", nil, nil, &dns.Zone{Zone: "signed.zone", DNSSEC: &enabled}))
+		require.Nil(t, mock.AddZoneGetTestCase("plain.zone", nil, nil, &dns.Zone{Zone: "plain.zone"}))
+		require.Nil(t, mock.AddTestCase(
+			http.MethodGet, "/zones/missing.zone", http.StatusNotFound,
+			nil, nil, "", `{"message": "zone not found"}`,
+		))
+
+		ok, _, err := client.DNSSEC.IsEnabled("signed.zone")
+		require.Nil(t, err)
+		require.True(t, ok)
+
+		ok, _, err = client.DNSSEC.IsEnabled("plain.zone")
+		require.Nil(t, err)
+		require.False(t, ok)
+
+		_, _, err = client.DNSSEC.IsEnabled("missing.zone")
+		require.Equal(t, api.ErrZoneMissing, err)
+	})
+
+	t.Run("Enable", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPost, "/zones/signed.zone", http.StatusOK,
+			nil, nil, map[string]bool{"dnssec": true}, `{"zone": "signed.zone", "dnssec": true}`,
+		))
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPost, "/zones/plain.zone", http.StatusOK,
+			nil, nil, map[string]bool{"dnssec": false}, `{"zone": "plain.zone", "dnssec": false}`,
+		))
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPost, "/zones/missing.zone", http.StatusNotFound,
+			nil, nil, map[string]bool{"dnssec": true}, `{"message": "zone not found"}`,
+		))
+
+		_, err := client.DNSSEC.Enable("signed.zone")
+		require.Nil(t, err)
+		_, err = client.DNSSEC.Disable("plain.zone")
+		require.Nil(t, err)
+		_, err = client.DNSSEC.Enable("missing.zone")
+		require.Equal(t, api.ErrZoneMissing, err)
+	})
+}
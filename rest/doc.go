@@ -1,2 +1,21 @@
 // Package rest defines the api services used to communicate with NS1.
+//
+// A Client groups the API by resource into services, all sharing the
+// client's NewRequest and Do plumbing (authentication, rate limiting,
+// retries and error handling):
+//
+//	client := rest.NewClient(http.DefaultClient, rest.SetAPIKey(key))
+//	zones, _, err := client.Zones.List()
+//	record, _, err := client.Records.Get("example.com", "www.example.com", "A")
+//
+// DNS is managed with Zones, ZoneVersions, Records, DNSSEC, TSIG, Views and
+// Search; traffic management data with DataSources and DataFeeds; monitoring
+// with Jobs (the monitoring jobs), Notifications and Alerts; Pulsar with
+// Applications and PulsarJobs; HTTP redirects with Redirects and
+// RedirectCerts; the account with APIKeys, Users, Teams, Whitelist (the IP
+// whitelist), Settings, Plan, Warnings, Stats, Activity (the activity log)
+// and Datasets (usage reports); and DDI deployments additionally with ACLs,
+// IPAM, ScopeGroup, Scope, Reservation, Lease and OptionDef. Each service
+// exposes the List, Get, Create, Update and Delete methods its endpoints
+// support.
 package rest
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+const defaultDifferParallelism = 4
+
+// DesiredZone is the desired configuration of a zone and its records, e.g.
+// loaded from files in a GitOps repository.
+type DesiredZone struct {
+	Zone    *dns.Zone
+	Records []*dns.Record
+}
+
+// DriftKind classifies a Drift.
+type DriftKind string
+
+const (
+	// DriftMissing is a desired resource that does not exist in NS1.
+	DriftMissing DriftKind = "missing"
+	// DriftExtra is a resource in NS1 that is not in the desired config.
+	DriftExtra DriftKind = "extra"
+	// DriftChanged is a resource whose NS1 config differs from the desired one.
+	DriftChanged DriftKind = "changed"
+)
+
+// Drift is a single difference between the desired config and NS1. For
+// changed resources, Fields lists the differing fields, From being the live
+// value and To the desired one.
+type Drift struct {
+	Kind   DriftKind       `json:"kind"`
+	Zone   string          `json:"zone"`
+	Domain string          `json:"domain,omitempty"` // empty for a zone
+	Type   string          `json:"type,omitempty"`   // empty for a zone
+	Fields []dns.FieldDiff `json:"fields,omitempty"`
+}
+
+func (d Drift) resource() string {
+	if d.Domain == "" {
+		return "zone " + d.Zone
+	}
+	return fmt.Sprintf("record %s %s", d.Domain, d.Type)
+}
+
+func (d Drift) String() string {
+	prefix := map[DriftKind]string{DriftMissing: "+", DriftExtra: "-", DriftChanged: "~"}[d.Kind]
+	lines := []string{fmt.Sprintf("%s %s (%s)", prefix, d.resource(), d.Kind)}
+	for _, f := range d.Fields {
+		lines = append(lines, "    "+f.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DriftReport is the result of Differ.Diff. It marshals to JSON for
+// machine consumption, and String renders it as a human-readable diff.
+type DriftReport struct {
+	Drifts []Drift `json:"drifts"`
+}
+
+// HasDrift reports whether any difference was found.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Drifts) > 0
+}
+
+func (r *DriftReport) String() string {
+	if !r.HasDrift() {
+		return "no drift"
+	}
+	lines := make([]string, len(r.Drifts))
+	for i, d := range r.Drifts {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Differ reports drift between a desired set of zones and records and the
+// live NS1 account, and plans and applies the changes that resolve it. Diff
+// and Plan only read from the API.
+type Differ struct {
+	client *Client
+
+	// Parallelism is the number of concurrent record reads, and of record
+	// changes of Apply. Use the same value with RateLimitStrategyConcurrent
+	// to stay within the rate limit.
+	Parallelism int
+
+	// Prune makes Plan delete the records of the desired zones that are not
+	// in the desired config, except the apex NS and other records NS1
+	// manages itself.
+	Prune bool
+}
+
+// NewDiffer returns a Differ reading from the given client.
+func NewDiffer(c *Client) *Differ {
+	return &Differ{client: c, Parallelism: defaultDifferParallelism}
+}
+
+// Diff compares the desired zones and their records against NS1 and returns
+// a report of missing, extra and changed resources. Zone and record fields
+// left unset in the desired config are not compared. Only the desired zones
+// are inspected; other zones of the account are not reported as extra.
+// A desired zone without a Zone, or with a nil record, is an error, returned
+// before any request.
+func (d *Differ) Diff(desired []*DesiredZone) (*DriftReport, error) {
+	for i, dz := range desired {
+		if dz == nil || dz.Zone == nil {
+			return nil, fmt.Errorf("desired zone %d has no zone", i)
+		}
+		for j, r := range dz.Records {
+			if r == nil {
+				return nil, fmt.Errorf("desired zone %s: record %d is nil", dz.Zone.Zone, j)
+			}
+		}
+	}
+
+	report := &DriftReport{Drifts: []Drift{}}
+	for _, dz := range desired {
+		drifts, err := d.diffZone(dz)
+		if err != nil {
+			return nil, err
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	return report, nil
+}
+
+// EstimateCalls returns an estimate of the number of API requests Diff
+// issues for desired: one per zone, and one per desired record of a zone
+// that exists, e.g. to compare against Client.QuotaRemaining before a large
+// run. It is not an upper bound: zones whose records span several pages
+// (with FollowPagination) take one more request per extra page, which is
+// not known in advance.
+func (d *Differ) EstimateCalls(desired []*DesiredZone) int {
+	calls := 0
+	for _, dz := range desired {
+		calls += 1 + len(dz.Records)
+	}
+	return calls
+}
+
+func (d *Differ) diffZone(dz *DesiredZone) ([]Drift, error) {
+	name := dz.Zone.Zone
+
+	live, _, err := d.client.Zones.Get(name)
+	if err == ErrZoneMissing {
+		drifts := []Drift{{Kind: DriftMissing, Zone: name}}
+		for _, r := range dz.Records {
+			drifts = append(drifts, Drift{Kind: DriftMissing, Zone: name, Domain: r.Domain, Type: r.Type})
+		}
+		return drifts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []Drift
+	if fields := desiredFields(dns.DiffZones(live, dz.Zone)); len(fields) > 0 {
+		drifts = append(drifts, Drift{Kind: DriftChanged, Zone: name, Fields: fields})
+	}
+
+	key := func(domain, t string) string {
+		return strings.ToLower(strings.TrimSuffix(domain, ".")) + " " + strings.ToUpper(t)
+	}
+	existing := map[string]bool{}
+	for _, zr := range live.Records {
+		existing[key(zr.Domain, zr.Type)] = true
+	}
+
+	var present []*dns.Record
+	wanted := map[string]bool{}
+	for _, r := range dz.Records {
+		k := key(r.Domain, r.Type)
+		wanted[k] = true
+		if existing[k] {
+			present = append(present, r)
+		} else {
+			drifts = append(drifts, Drift{Kind: DriftMissing, Zone: name, Domain: r.Domain, Type: r.Type})
+		}
+	}
+	for _, zr := range live.Records {
+		if !wanted[key(zr.Domain, zr.Type)] {
+			drifts = append(drifts, Drift{Kind: DriftExtra, Zone: name, Domain: zr.Domain, Type: zr.Type})
+		}
+	}
+
+	changed := make([]*Drift, len(present))
+	errs := make([]error, len(present))
+	parallel(len(present), d.Parallelism, func(i int) {
+		r := present[i]
+		lr, _, err := d.client.Records.Get(name, r.Domain, r.Type)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		if fields := desiredFields(dns.DiffRecords(lr, r)); len(fields) > 0 {
+			changed[i] = &Drift{Kind: DriftChanged, Zone: name, Domain: r.Domain, Type: r.Type, Fields: fields}
+		}
+	})
+	for i := range present {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if changed[i] != nil {
+			drifts = append(drifts, *changed[i])
+		}
+	}
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].Domain != drifts[j].Domain {
+			return drifts[i].Domain < drifts[j].Domain
+		}
+		return drifts[i].Type < drifts[j].Type
+	})
+	return drifts, nil
+}
+
+// desiredFields drops the differences for fields that are unset in the
+// desired config. Answers missing from it are kept, as the desired answers
+// are the complete list.
+func desiredFields(diffs []dns.FieldDiff) []dns.FieldDiff {
+	var out []dns.FieldDiff
+	for _, f := range diffs {
+		removedAnswer := strings.HasPrefix(f.Field, "answers[") && strings.HasSuffix(f.Field, "]")
+		if f.To != nil || removedAnswer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
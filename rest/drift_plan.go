@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// Plan is the set of changes that brings NS1 to the desired config, made by
+// Differ.Plan from a DriftReport and applied by Differ.Apply. String renders
+// it as a human-readable list of changes, for review before applying.
+type Plan struct {
+	// Report is the drift the plan resolves.
+	Report *DriftReport
+
+	// ZoneCreates and ZoneUpdates are the desired configs of the missing
+	// and changed zones.
+	ZoneCreates []*dns.Zone
+	ZoneUpdates []*dns.Zone
+
+	// Records are the changes of the records: the desired configs of the
+	// missing and changed records, and the extra records to delete if the
+	// Differ prunes.
+	Records []RecordChange
+}
+
+// Empty reports whether the plan makes no change.
+func (p *Plan) Empty() bool {
+	return len(p.ZoneCreates) == 0 && len(p.ZoneUpdates) == 0 && len(p.Records) == 0
+}
+
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	var lines []string
+	for _, z := range p.ZoneCreates {
+		lines = append(lines, "create zone "+z.Zone)
+	}
+	for _, z := range p.ZoneUpdates {
+		lines = append(lines, "update zone "+z.Zone)
+	}
+	for _, c := range p.Records {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Plan compares the desired zones and their records against NS1, as Diff
+// does, and returns the changes that resolve the drift: missing zones and
+// records are created and changed ones updated with their desired config.
+// Extra records are only deleted if Prune is set, and never the records NS1
+// manages itself at the zone apex (NS, SOA and the DNSSEC keys). Like Diff,
+// it only reads from the API, and rejects desired zones without a Zone.
+func (d *Differ) Plan(desired []*DesiredZone) (*Plan, error) {
+	report, err := d.Diff(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]*DesiredZone{}
+	records := map[string]*dns.Record{}
+	for _, dz := range desired {
+		zones[dz.Zone.Zone] = dz
+		for _, r := range dz.Records {
+			records[planKey(dz.Zone.Zone, r.Domain, r.Type)] = r
+		}
+	}
+
+	p := &Plan{Report: report}
+	for _, drift := range report.Drifts {
+		if drift.Domain == "" {
+			z := zones[drift.Zone].Zone
+			switch drift.Kind {
+			case DriftMissing:
+				p.ZoneCreates = append(p.ZoneCreates, z)
+			case DriftChanged:
+				p.ZoneUpdates = append(p.ZoneUpdates, z)
+			}
+			continue
+		}
+
+		switch drift.Kind {
+		case DriftMissing:
+			p.Records = append(p.Records, RecordChange{Op: RecordCreate, Record: planRecord(drift, records)})
+		case DriftChanged:
+			p.Records = append(p.Records, RecordChange{Op: RecordUpdate, Record: planRecord(drift, records)})
+		case DriftExtra:
+			if d.Prune && !serverManaged(drift) {
+				r := &dns.Record{Zone: drift.Zone, Domain: drift.Domain, Type: drift.Type}
+				p.Records = append(p.Records, RecordChange{Op: RecordDelete, Record: r})
+			}
+		}
+	}
+	return p, nil
+}
+
+// serverManaged reports whether the extra record of a drift is one NS1
+// maintains itself, like the apex NS record, which is never pruned.
+func serverManaged(drift Drift) bool {
+	if !strings.EqualFold(strings.TrimSuffix(drift.Domain, "."), drift.Zone) {
+		return false
+	}
+	switch strings.ToUpper(drift.Type) {
+	case "NS", "SOA", "DNSKEY", "CDS", "CDNSKEY":
+		return true
+	}
+	return false
+}
+
+// planKey identifies a record of the desired config.
+func planKey(zone, domain, t string) string {
+	return zone + " " + strings.ToLower(strings.TrimSuffix(domain, ".")) + " " + strings.ToUpper(t)
+}
+
+// planRecord returns a copy of the desired record of a drift, with its zone
+// set.
+func planRecord(drift Drift, records map[string]*dns.Record) *dns.Record {
+	r := *records[planKey(drift.Zone, drift.Domain, drift.Type)]
+	r.Zone = drift.Zone
+	return &r
+}
+
+// Apply makes the changes of a plan with ctx: zones are created and updated
+// first, one at a time, then the records are deleted, and finally created
+// and updated, each batch with Records.Bulk at Parallelism requests at a time
+// through the client's rate limiting. Deleting first lets a plan replace a
+// record with one of a conflicting type, e.g. an A record with a CNAME.
+//
+// Apply stops at the first zone that fails, as its records would fail too;
+// record changes are independent, and their failures are returned together
+// in a MultiError. Once ctx is done the remaining changes are not made.
+func (d *Differ) Apply(ctx context.Context, p *Plan) error {
+	for _, z := range p.ZoneCreates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := d.client.WithContext(ctx).Zones.Create(z); err != nil {
+			return fmt.Errorf("create zone %s: %w", z.Zone, err)
+		}
+	}
+	for _, z := range p.ZoneUpdates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := d.client.WithContext(ctx).Zones.Update(z); err != nil {
+			return fmt.Errorf("update zone %s: %w", z.Zone, err)
+		}
+	}
+
+	var deletes, others []RecordChange
+	for _, c := range p.Records {
+		if c.Op == RecordDelete {
+			deletes = append(deletes, c)
+		} else {
+			others = append(others, c)
+		}
+	}
+
+	var failed MultiError
+	for _, batch := range [][]RecordChange{deletes, others} {
+		if len(batch) == 0 {
+			continue
+		}
+		if _, err := d.client.Records.Bulk(ctx, batch, d.Parallelism); err != nil {
+			failed = append(failed, err.(MultiError)...)
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestDiffer(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	liveZone := json.RawMessage(`{"zone":"example.com","ttl":3600,"serial":42,
+		"records":[
+			{"domain":"www.example.com","type":"A","short_answers":["1.2.3.4"]},
+			{"domain":"api.example.com","type":"A","short_answers":["1.2.3.5"]},
+			{"domain":"old.example.com","type":"CNAME","short_answers":["www.example.com"]}
+		]}`)
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com", http.StatusOK, nil, nil, "", liveZone))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/www.example.com/A", http.StatusOK, nil, nil, "",
+		json.RawMessage(`{"id":"r1","zone":"example.com","domain":"www.example.com","type":"A","ttl":3600,"answers":[{"id":"a1","answer":["1.2.3.4"]}]}`)))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/api.example.com/A", http.StatusOK, nil, nil, "",
+		json.RawMessage(`{"id":"r2","zone":"example.com","domain":"api.example.com","type":"A","ttl":3600,"answers":[{"id":"a2","answer":["1.2.3.5"]}]}`)))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/missing.com", http.StatusNotFound, nil, nil, "", `{"message": "zone not found"}`))
+
+	zone := dns.NewZone("example.com")
+	zone.TTL = 3600
+
+	www := dns.NewRecord("example.com", "www", "A")
+	www.TTL = 3600
+	www.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+	api1 := dns.NewRecord("example.com", "api", "A")
+	api1.AddAnswer(dns.NewAv4Answer("9.9.9.9"))
+	mail := dns.NewRecord("example.com", "mail", "MX")
+	mail.AddAnswer(dns.NewMXAnswer(10, "mx.example.com"))
+
+	missingRec := dns.NewRecord("missing.com", "www", "A")
+
+	desired := []*api.DesiredZone{
+		{Zone: zone, Records: []*dns.Record{www, api1, mail}},
+		{Zone: dns.NewZone("missing.com"), Records: []*dns.Record{missingRec}},
+	}
+	differ := api.NewDiffer(client)
+	require.Equal(t, 6, differ.EstimateCalls(desired))
+
+	report, err := differ.Diff(desired)
+	require.Nil(t, err)
+	require.True(t, client.RequestStats().Attempts <= 6)
+	require.True(t, report.HasDrift())
+	require.Equal(t, []api.Drift{
+		{Kind: api.DriftChanged, Zone: "example.com", Domain: "api.example.com", Type: "A", Fields: []dns.FieldDiff{{
+			Field: "answers",
+			From:  []interface{}{map[string]interface{}{"answer": []interface{}{"1.2.3.5"}}},
+			To:    []interface{}{map[string]interface{}{"answer": []interface{}{"9.9.9.9"}}},
+		}}},
+		{Kind: api.DriftMissing, Zone: "example.com", Domain: "mail.example.com", Type: "MX"},
+		{Kind: api.DriftExtra, Zone: "example.com", Domain: "old.example.com", Type: "CNAME"},
+		{Kind: api.DriftMissing, Zone: "missing.com"},
+		{Kind: api.DriftMissing, Zone: "missing.com", Domain: "www.missing.com", Type: "A"},
+	}, report.Drifts)
+
+	require.Equal(t, `~ record api.example.com A (changed)
+    answers: [{"answer":["1.2.3.5"]}] -> [{"answer":["9.9.9.9"]}]
++ record mail.example.com MX (missing)
+- record old.example.com CNAME (extra)
++ zone missing.com (missing)
++ record www.missing.com A (missing)`, report.String())
+
+	b, err := json.Marshal(report)
+	require.Nil(t, err)
+	require.Contains(t, string(b), `{"kind":"extra","zone":"example.com","domain":"old.example.com","type":"CNAME"}`)
+
+	// A desired zone without its Zone is rejected, rather than panicking.
+	noZone := []*api.DesiredZone{{Zone: zone}, {Records: []*dns.Record{www}}}
+	_, err = differ.Diff(noZone)
+	require.EqualError(t, err, "desired zone 1 has no zone")
+	_, err = differ.Plan(noZone)
+	require.EqualError(t, err, "desired zone 1 has no zone")
+	_, err = differ.Diff([]*api.DesiredZone{{Zone: zone, Records: []*dns.Record{www, nil}}})
+	require.EqualError(t, err, "desired zone example.com: record 1 is nil")
+}
+
+func TestDiffer_Plan(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	liveZone := json.RawMessage(`{"zone":"example.com","ttl":3600,
+		"records":[
+			{"domain":"example.com","type":"NS","short_answers":["dns1.p01.nsone.net","dns2.p01.nsone.net"]},
+			{"domain":"api.example.com","type":"A","short_answers":["1.2.3.5"]},
+			{"domain":"old.example.com","type":"CNAME","short_answers":["api.example.com"]}
+		]}`)
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com", http.StatusOK, nil, nil, "", liveZone))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/api.example.com/A", http.StatusOK, nil, nil, "",
+		json.RawMessage(`{"zone":"example.com","domain":"api.example.com","type":"A","answers":[{"answer":["1.2.3.5"]}]}`)))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/missing.com", http.StatusNotFound, nil, nil, "", `{"message": "zone not found"}`))
+
+	zone := dns.NewZone("example.com")
+	api1 := dns.NewRecord("example.com", "api", "A")
+	api1.AddAnswer(dns.NewAv4Answer("9.9.9.9"))
+	mail := dns.NewRecord("example.com", "mail", "MX")
+	mail.AddAnswer(dns.NewMXAnswer(10, "mx.example.com"))
+	missing := dns.NewZone("missing.com")
+	www := dns.NewRecord("missing.com", "www", "A")
+	www.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+
+	desired := []*api.DesiredZone{
+		{Zone: zone, Records: []*dns.Record{api1, mail}},
+		{Zone: missing, Records: []*dns.Record{www}},
+	}
+	differ := api.NewDiffer(client)
+
+	plan, err := differ.Plan(desired)
+	require.Nil(t, err)
+	require.Equal(t, `create zone missing.com
+update api.example.com A
+create mail.example.com MX
+create www.missing.com A`, plan.String())
+
+	differ.Prune = true
+	plan, err = differ.Plan(desired)
+	require.Nil(t, err)
+	// The apex NS record is reported, but not deleted.
+	require.Contains(t, plan.Report.Drifts, api.Drift{Kind: api.DriftExtra, Zone: "example.com", Domain: "example.com", Type: "NS"})
+	require.Equal(t, []*dns.Zone{missing}, plan.ZoneCreates)
+	require.Empty(t, plan.ZoneUpdates)
+	require.Equal(t, []api.RecordChange{
+		{Op: api.RecordUpdate, Record: api1},
+		{Op: api.RecordCreate, Record: mail},
+		{Op: api.RecordDelete, Record: &dns.Record{Zone: "example.com", Domain: "old.example.com", Type: "CNAME"}},
+		{Op: api.RecordCreate, Record: www},
+	}, plan.Records)
+
+	mock.ClearTestCases()
+	require.Nil(t, mock.AddTestCase(http.MethodPut, "/zones/missing.com", http.StatusOK, nil, nil, missing, missing))
+	require.Nil(t, mock.AddTestCase(http.MethodDelete, "/zones/example.com/old.example.com/CNAME", http.StatusOK, nil, nil, "", ""))
+	require.Nil(t, mock.AddTestCase(http.MethodPost, "/zones/example.com/api.example.com/A", http.StatusOK, nil, nil, api1, api1))
+	require.Nil(t, mock.AddTestCase(http.MethodPut, "/zones/example.com/mail.example.com/MX", http.StatusOK, nil, nil, mail, mail))
+	require.Nil(t, mock.AddTestCase(http.MethodPut, "/zones/missing.com/www.missing.com/A", http.StatusInternalServerError, nil, nil, www,
+		`{"message": "internal error"}`))
+
+	err = differ.Apply(context.Background(), plan)
+	require.IsType(t, api.MultiError{}, err)
+	require.Len(t, err.(api.MultiError), 1)
+	require.Contains(t, err.Error(), "create www.missing.com A")
+	require.True(t, mock.AssertExpectations())
+
+	require.Nil(t, differ.Apply(context.Background(), &api.Plan{}))
+	require.True(t, (&api.Plan{}).Empty())
+}
@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultAPIVersion is the version of the NS1 API the client uses by
+// default, the last segment of the default endpoint's path.
+const DefaultAPIVersion = "v1"
+
+// apiVersionSegment matches an API version path segment, such as v1 or v2.
+var apiVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// ErrInvalidEndpoint is matched (with errors.Is) by the errors of endpoints
+// that are not valid API base URLs, see ParseEndpoint.
+var ErrInvalidEndpoint = errors.New("ns1: invalid endpoint")
+
+// ddiPaths are the first path segments of the DDI requests, sent to the
+// DDIEndpoint if one is set.
+var ddiPaths = map[string]bool{"ipam": true, "dhcp": true, "acls": true}
+
+// ParseEndpoint validates and normalizes an API base URL, e.g. of a private
+// deployment taken from configuration: it must be an absolute http or https
+// URL with a host (and any port), and no query or fragment. The scheme and
+// host are lowercased and a slash is added to the end of the path, as by
+// SetEndpoint. The error matches ErrInvalidEndpoint.
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidEndpoint, endpoint, err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, fmt.Errorf("%w %q: scheme must be http or https", ErrInvalidEndpoint, endpoint)
+	case u.Hostname() == "":
+		return nil, fmt.Errorf("%w %q: no host", ErrInvalidEndpoint, endpoint)
+	case u.RawQuery != "" || u.Fragment != "":
+		return nil, fmt.Errorf("%w %q: query and fragment are not allowed", ErrInvalidEndpoint, endpoint)
+	}
+	addSlash(u)
+	return u, nil
+}
+
+// SetDDIEndpoint sends the DDI requests (IPAM, DHCP and ACLs) to endpoint
+// rather than the Endpoint, for deployments serving them from another base
+// URL. The endpoint is validated by ParseEndpoint; if it is invalid,
+// NewRequest fails with its error until a valid one is set. An empty
+// endpoint sends them to the Endpoint again.
+func SetDDIEndpoint(endpoint string) func(*Client) {
+	return func(c *Client) {
+		if endpoint == "" {
+			c.DDIEndpoint, c.ddiEndpointErr = nil, nil
+			return
+		}
+		u, err := ParseEndpoint(endpoint)
+		if err != nil {
+			c.ddiEndpointErr = err
+			return
+		}
+		c.DDIEndpoint, c.ddiEndpointErr = u, nil
+	}
+}
+
+// endpointFor returns the endpoint to resolve the request path rel against.
+func (c Client) endpointFor(rel *url.URL) *url.URL {
+	if c.DDIEndpoint != nil && !rel.IsAbs() && !strings.HasPrefix(rel.Path, "/") {
+		if first := strings.SplitN(rel.Path, "/", 2)[0]; ddiPaths[first] {
+			return c.DDIEndpoint
+		}
+	}
+	return c.Endpoint
+}
+
+// EndpointUS is the base URL of the NS1 API in the US, the default.
+const EndpointUS = defaultEndpoint
+
+// regionEndpoints maps the known API regions to their base URL. Only regions
+// NS1 publishes an endpoint for are listed; use SetEndpoint for private or
+// dedicated deployments.
+var regionEndpoints = map[string]string{
+	"us": EndpointUS,
+}
+
+// EndpointForRegion returns the API base URL for a region code (case
+// insensitive). An empty region falls back to the US default, and an error
+// is returned for unknown regions.
+func EndpointForRegion(region string) (string, error) {
+	if region == "" {
+		return EndpointUS, nil
+	}
+	if endpoint, ok := regionEndpoints[strings.ToLower(region)]; ok {
+		return endpoint, nil
+	}
+
+	known := make([]string, 0, len(regionEndpoints))
+	for r := range regionEndpoints {
+		known = append(known, r)
+	}
+	sort.Strings(known)
+	return "", fmt.Errorf("unknown NS1 API region %q, must be one of %s", region, strings.Join(known, ", "))
+}
+
+// SetRegion points the client at the API endpoint of the given region, see
+// EndpointForRegion. The endpoint is left unchanged if the region is
+// unknown.
+func (c *Client) SetRegion(region string) error {
+	endpoint, err := EndpointForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	c.lock()
+	c.Endpoint, c.endpointErr = u, nil
+	c.unlock()
+	return nil
+}
+
+// SetAPIVersion sets the API version of the client's endpoint, e.g. "v2" to
+// use a newer API surface of the same host, leaving the rest of the endpoint
+// as set by SetEndpoint or SetRegion (so apply it after them). The
+// endpoint's last path segment is replaced if it is a version (v followed by
+// digits), or else the version is appended:
+//
+//	SetEndpoint("https://api.nsone.net/v1/"), SetAPIVersion("v2") // https://api.nsone.net/v2/
+//	SetEndpoint("https://gw.example.com/ns1"), SetAPIVersion("v1") // https://gw.example.com/ns1/v1/
+//
+// An empty version removes the version segment, for gateways mounting the
+// API without one. Requests are built by resolving their relative path, such
+// as "zones", against the resulting endpoint, which always ends in a slash;
+// paths with a leading slash are relative to the host instead.
+func SetAPIVersion(version string) func(*Client) {
+	version = strings.Trim(version, "/")
+	return func(c *Client) {
+		if c.Endpoint == nil {
+			return
+		}
+		u := *c.Endpoint
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if segments[0] == "" {
+			segments = nil
+		}
+		if n := len(segments); n > 0 && apiVersionSegment.MatchString(segments[n-1]) {
+			segments = segments[:n-1]
+		}
+		if version != "" {
+			segments = append(segments, version)
+		}
+
+		u.Path = "/"
+		if len(segments) > 0 {
+			u.Path += strings.Join(segments, "/") + "/"
+		}
+		u.RawPath = ""
+		c.Endpoint = &u
+	}
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointForRegion(t *testing.T) {
+	for _, region := range []string{"", "us", "US"} {
+		e, err := EndpointForRegion(region)
+		assert.Nil(t, err)
+		assert.Equal(t, EndpointUS, e)
+	}
+
+	_, err := EndpointForRegion("mars")
+	assert.NotNil(t, err)
+}
+
+func TestClient_SetRegion(t *testing.T) {
+	c := NewClient(nil, SetEndpoint("https://example.com/v1/"))
+	assert.NotNil(t, c.SetRegion("mars"))
+	assert.Equal(t, "https://example.com/v1/", c.Endpoint.String())
+
+	assert.Nil(t, c.SetRegion("us"))
+	assert.Equal(t, EndpointUS, c.Endpoint.String())
+}
+
+func TestClient_SetAPIVersion(t *testing.T) {
+	cases := []struct {
+		endpoint string
+		version  string
+		want     string
+	}{
+		{"https://api.nsone.net/v1/", "v2", "https://api.nsone.net/v2/zones"},
+		{"https://api.nsone.net/v1", "v2", "https://api.nsone.net/v2/zones"},
+		{"https://api.nsone.net", "v1", "https://api.nsone.net/v1/zones"},
+		{"https://api.nsone.net/", "/v1/", "https://api.nsone.net/v1/zones"},
+		{"https://gw.example.com/ns1", "v1", "https://gw.example.com/ns1/v1/zones"},
+		{"https://gw.example.com/ns1/v1/", "v2", "https://gw.example.com/ns1/v2/zones"},
+		{"https://gw.example.com/ns1/v1/", "", "https://gw.example.com/ns1/zones"},
+		{"https://api.nsone.net/v1/", "", "https://api.nsone.net/zones"},
+	}
+	for _, tc := range cases {
+		c := NewClient(nil, SetEndpoint(tc.endpoint), SetAPIVersion(tc.version))
+		req, err := c.NewRequest("GET", "zones", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.want, req.URL.String(), "%s + %q", tc.endpoint, tc.version)
+	}
+
+	c := NewClient(nil, SetAPIVersion(DefaultAPIVersion))
+	assert.Equal(t, EndpointUS, c.Endpoint.String())
+}
+
+func TestClient_SetEndpointTrailingSlash(t *testing.T) {
+	for _, endpoint := range []string{"https://example.com/v1", "https://example.com/v1/"} {
+		c := NewClient(nil, SetEndpoint(endpoint))
+		req, err := c.NewRequest("GET", "zones/example.com", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://example.com/v1/zones/example.com", req.URL.String())
+
+		req, err = c.NewRequest("GET", "/v2/zones", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://example.com/v2/zones", req.URL.String())
+	}
+
+	c := NewClient(nil, SetEndpoint("https://example.com"))
+	assert.Equal(t, "https://example.com/", c.Endpoint.String())
+}
+
+func TestParseEndpoint(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"https://api.nsone.net/v1/":         "https://api.nsone.net/v1/",
+		" HTTPS://DDI.Example.com:8443/v1 ": "https://ddi.example.com:8443/v1/",
+		"http://10.0.0.5":                   "http://10.0.0.5/",
+	} {
+		u, err := ParseEndpoint(endpoint)
+		require.Nil(t, err, endpoint)
+		assert.Equal(t, want, u.String())
+	}
+
+	for _, endpoint := range []string{"", "api.nsone.net/v1", "ftp://api.nsone.net/", "https:///v1", "https://api.nsone.net/v1?x=1", "https://%zz"} {
+		_, err := ParseEndpoint(endpoint)
+		assert.True(t, errors.Is(err, ErrInvalidEndpoint), endpoint)
+	}
+}
+
+func TestClient_SetEndpointInvalid(t *testing.T) {
+	c := NewClient(nil, SetEndpoint("https://%zz/v1"))
+	assert.Equal(t, EndpointUS, c.Endpoint.String())
+	_, err := c.NewRequest("GET", "zones", nil)
+	assert.True(t, errors.Is(err, ErrInvalidEndpoint))
+
+	SetEndpoint("https://example.com/v1")(c)
+	_, err = c.NewRequest("GET", "zones", nil)
+	assert.Nil(t, err)
+}
+
+func TestClient_SetDDIEndpoint(t *testing.T) {
+	c := NewClient(nil, SetEndpoint("https://dns.example.com/v1/"), SetDDIEndpoint("https://ddi.example.com:8443/v1"))
+	for path, want := range map[string]string{
+		"zones/example.com":       "https://dns.example.com/v1/zones/example.com",
+		"ipam/address/1":          "https://ddi.example.com:8443/v1/ipam/address/1",
+		"dhcp/scope":              "https://ddi.example.com:8443/v1/dhcp/scope",
+		"acls/internal":           "https://ddi.example.com:8443/v1/acls/internal",
+		"ipamish":                 "https://dns.example.com/v1/ipamish",
+		"/v2/dhcp/scope":          "https://dns.example.com/v2/dhcp/scope",
+		"https://other.test/ipam": "https://other.test/ipam",
+	} {
+		req, err := c.NewRequest("GET", path, nil)
+		require.Nil(t, err, path)
+		assert.Equal(t, want, req.URL.String(), path)
+	}
+
+	SetDDIEndpoint("not a url")(c)
+	_, err := c.NewRequest("GET", "zones", nil)
+	assert.True(t, errors.Is(err, ErrInvalidEndpoint))
+
+	SetDDIEndpoint("")(c)
+	req, err := c.NewRequest("GET", "ipam/address/1", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "https://dns.example.com/v1/ipam/address/1", req.URL.String())
+}
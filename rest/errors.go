@@ -0,0 +1,201 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrAlreadyExists matches, via errors.Is, any error returned when
+	// creating a resource that already exists: the per-resource sentinels
+	// (ErrZoneExists, ErrRecordExists, ...) as well as an *Error for a 409
+	// Conflict or an "... already exists" message.
+	ErrAlreadyExists = errors.New("resource already exists")
+	// ErrNotFound matches, via errors.Is, any error returned for a resource
+	// that does not exist: the per-resource sentinels (ErrZoneMissing,
+	// ErrRecordMissing, ...) as well as an *Error for a 404 Not Found or a
+	// "... not found" message.
+	ErrNotFound = errors.New("resource not found")
+	// ErrServiceUnavailable matches, via errors.Is, an *Error for a 503
+	// Service Unavailable returned during NS1 maintenance, i.e. with a
+	// Retry-After header or a message mentioning maintenance. Unlike other
+	// 5xx errors it is expected to last for the maintenance window; see
+	// Error.RetryAfter for when to try again.
+	ErrServiceUnavailable = errors.New("service unavailable for maintenance")
+	// ErrRateLimited matches, via errors.Is, an *Error for a 429 Too Many
+	// Requests, see RateLimit and RetryPolicy.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrAuthFailed matches, via errors.Is, an *Error for a 401
+	// Unauthorized, i.e. a missing, invalid or revoked API key.
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrPermissionDenied matches, via errors.Is, an *Error for a 403
+	// Forbidden, i.e. an API key lacking the permission for the request.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrInvalidRequest matches, via errors.Is, an *Error for a 400 Bad
+	// Request, e.g. a failed validation; the error's Message and Details
+	// hold the reasons the API gave.
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrNoRateLimitStrategy is returned by Do for a client created with
+	// RequireRateLimitStrategy that has no rate limit strategy set.
+	ErrNoRateLimitStrategy = errors.New("ns1: no rate limit strategy set, see RequireRateLimitStrategy")
+	// ErrRequestTimeout matches, via errors.Is, the error returned by Do
+	// when an attempt takes longer than the client's Timeout, see SetTimeout.
+	ErrRequestTimeout = errors.New("ns1: request timed out")
+)
+
+// timeoutError is the error of an attempt cut short by the client's Timeout.
+type timeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("ns1: request timed out after %s: %v", e.timeout, e.err)
+}
+
+// Is makes the error match ErrRequestTimeout.
+func (e *timeoutError) Is(target error) bool { return target == ErrRequestTimeout }
+
+// Unwrap returns the error the attempt failed with.
+func (e *timeoutError) Unwrap() error { return e.err }
+
+// classError is a sentinel error that also matches a broader class of
+// errors (ErrAlreadyExists or ErrNotFound), so errors.Is works for both while
+// callers comparing against the sentinel with == keep working.
+type classError struct {
+	msg   string
+	class error
+}
+
+func (e *classError) Error() string { return e.msg }
+
+func (e *classError) Is(target error) bool { return target == e.class }
+
+func existsError(msg string) error { return &classError{msg: msg, class: ErrAlreadyExists} }
+
+func missingError(msg string) error { return &classError{msg: msg, class: ErrNotFound} }
+
+// Is reports whether the API error belongs to the class of target, one of
+// ErrAlreadyExists, ErrNotFound, ErrServiceUnavailable, ErrRateLimited,
+// ErrAuthFailed, ErrPermissionDenied or ErrInvalidRequest, for use with
+// errors.Is.
+func (re *Error) Is(target error) bool {
+	exists := re.statusCode() == http.StatusConflict || strings.HasSuffix(re.Message, "already exists")
+	switch target {
+	case ErrRateLimited:
+		return re.statusCode() == http.StatusTooManyRequests
+	case ErrAuthFailed:
+		return re.statusCode() == http.StatusUnauthorized
+	case ErrPermissionDenied:
+		return re.statusCode() == http.StatusForbidden
+	case ErrInvalidRequest:
+		return re.statusCode() == http.StatusBadRequest
+	case ErrServiceUnavailable:
+		if re.statusCode() != http.StatusServiceUnavailable {
+			return false
+		}
+		_, retry := re.RetryAfter()
+		return retry || strings.Contains(strings.ToLower(re.Message), "maintenance")
+	case ErrAlreadyExists:
+		return exists
+	case ErrNotFound:
+		// The API also reports duplicates with a 404, so the message wins.
+		return !exists && (re.statusCode() == http.StatusNotFound || strings.HasSuffix(re.Message, "not found"))
+	}
+	return false
+}
+
+// RetryAfter returns the time the response's Retry-After header suggests
+// trying again at, if it has a valid one. An HTTP date is taken relative to
+// the response's Date header, so a skewed local clock does not shift it.
+func (re *Error) RetryAfter() (time.Time, bool) {
+	if re.Resp == nil {
+		return time.Time{}, false
+	}
+	return parseRetryAfter(re.Resp.Header, time.Now())
+}
+
+// parseRetryAfter interprets the Retry-After header of a response received
+// at now, given either as a delay in seconds or as an HTTP date.
+func parseRetryAfter(h http.Header, now time.Time) (time.Time, bool) {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return time.Time{}, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(secs) * time.Second), true
+	}
+	at, err := http.ParseTime(v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if date, err := http.ParseTime(h.Get("Date")); err == nil {
+		return now.Add(at.Sub(date)), true
+	}
+	return at, true
+}
+
+func (re *Error) statusCode() int {
+	if re.Resp == nil {
+		return 0
+	}
+	return re.Resp.StatusCode
+}
+
+// ContentTypeError is returned by Do for a successful response whose body
+// is not JSON and could not be decoded into v. Pass an io.Writer (e.g. a
+// *bytes.Buffer) as v to read such bodies, or nil to ignore them.
+type ContentTypeError struct {
+	Resp        *http.Response
+	ContentType string
+	// Body is the response body, already read.
+	Body []byte
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("%v %v: unexpected %s response, pass an io.Writer to read it",
+		e.Resp.Request.Method, e.Resp.Request.URL, e.ContentType)
+}
+
+// DecodeError is returned by Do for a successful response whose JSON body
+// could not be decoded into v, e.g. because it was truncated or is not of
+// the expected shape. It wraps the error of the JSON decoder, for
+// errors.As, and keeps the body (its first MiB) for debugging. Do returns
+// no response with it, as the body is consumed.
+type DecodeError struct {
+	Resp *http.Response
+	// Body is the response body, already read.
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Resp == nil || e.Resp.Request == nil {
+		return fmt.Sprintf("decoding response: %v", e.Err)
+	}
+	return fmt.Sprintf("%v %v: decoding response: %v", e.Resp.Request.Method, e.Resp.Request.URL, e.Err)
+}
+
+// Unwrap returns the error of the JSON decoder.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects several errors, e.g. every problem found by
+// RecordsService.ValidateAll.
+type MultiError []error
+
+func (me MultiError) Error() string {
+	msgs := make([]string, len(me))
+	for i, err := range me {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d error(s): %s", len(me), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,151 @@
+package rest_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestErrorClasses(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("Zone Exists", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		zone := &dns.Zone{Zone: "dup.zone"}
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPut, "/zones/dup.zone", http.StatusConflict,
+			nil, nil, zone, `{"message": "zone already exists"}`,
+		))
+
+		_, err := client.Zones.Create(zone)
+		require.Equal(t, api.ErrZoneExists, err)
+		require.True(t, errors.Is(err, api.ErrAlreadyExists))
+		require.False(t, errors.Is(err, api.ErrNotFound))
+	})
+
+	t.Run("Record Exists", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		rec := dns.NewRecord("dup.zone", "www", "A")
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPut, "/zones/dup.zone/www.dup.zone/A", http.StatusConflict,
+			nil, nil, rec, `{"message": "record already exists"}`,
+		))
+
+		_, err := client.Records.Create(rec)
+		require.Equal(t, api.ErrRecordExists, err)
+		require.True(t, errors.Is(err, api.ErrAlreadyExists))
+	})
+
+	t.Run("Conflict Without Known Message", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		src := &data.Source{Name: "dup", Type: "nsone_v1"}
+		require.Nil(t, mock.AddTestCase(
+			http.MethodPut, "/data/sources", http.StatusConflict,
+			nil, nil, src, `{"message": "duplicate"}`,
+		))
+
+		_, err := client.DataSources.Create(src)
+		var restErr *api.Error
+		require.True(t, errors.As(err, &restErr))
+		require.True(t, errors.Is(err, api.ErrAlreadyExists))
+		require.Equal(t, http.StatusConflict, restErr.Resp.StatusCode)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(
+			http.MethodGet, "/zones/gone.zone", http.StatusNotFound,
+			nil, nil, "", `{"message": "zone not found"}`,
+		))
+
+		_, _, err := client.Zones.Get("gone.zone")
+		require.Equal(t, api.ErrZoneMissing, err)
+		require.True(t, errors.Is(err, api.ErrNotFound))
+		require.False(t, errors.Is(err, api.ErrAlreadyExists))
+	})
+	t.Run("Service Unavailable", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(
+			http.MethodGet, "/zones/maint.zone", http.StatusServiceUnavailable,
+			nil, http.Header{"Retry-After": []string{"120"}}, "", `{"message": "service unavailable"}`,
+		))
+
+		before := time.Now()
+		_, _, err := client.Zones.Get("maint.zone")
+		require.True(t, errors.Is(err, api.ErrServiceUnavailable))
+		require.False(t, errors.Is(err, api.ErrNotFound))
+
+		var restErr *api.Error
+		require.True(t, errors.As(err, &restErr))
+		at, ok := restErr.RetryAfter()
+		require.True(t, ok)
+		require.WithinDuration(t, before.Add(2*time.Minute), at, 5*time.Second)
+	})
+
+	t.Run("Service Unavailable Without Retry-After", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(
+			http.MethodGet, "/zones/maint.zone", http.StatusServiceUnavailable,
+			nil, nil, "", `{"message": "scheduled maintenance in progress"}`,
+		))
+		require.Nil(t, mock.AddTestCase(
+			http.MethodGet, "/zones/busy.zone", http.StatusServiceUnavailable,
+			nil, nil, "", `{"message": "upstream unavailable"}`,
+		))
+
+		_, _, err := client.Zones.Get("maint.zone")
+		require.True(t, errors.Is(err, api.ErrServiceUnavailable))
+		_, ok := err.(*api.Error).RetryAfter()
+		require.False(t, ok)
+
+		// A 503 without a maintenance indicator is an ordinary, transient
+		// error.
+		_, _, err = client.Zones.Get("busy.zone")
+		require.Error(t, err)
+		require.False(t, errors.Is(err, api.ErrServiceUnavailable))
+	})
+	t.Run("Status Classes", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		classes := map[int]error{
+			http.StatusBadRequest:      api.ErrInvalidRequest,
+			http.StatusUnauthorized:    api.ErrAuthFailed,
+			http.StatusForbidden:       api.ErrPermissionDenied,
+			http.StatusTooManyRequests: api.ErrRateLimited,
+		}
+		all := []error{api.ErrInvalidRequest, api.ErrAuthFailed, api.ErrPermissionDenied, api.ErrRateLimited, api.ErrNotFound}
+		for status, class := range classes {
+			zone := fmt.Sprintf("status%d.zone", status)
+			require.Nil(t, mock.AddTestCase(
+				http.MethodGet, "/zones/"+zone, status,
+				nil, nil, "", `{"message": "nope", "errors": [{"field": "ttl", "message": "must be positive"}]}`,
+			))
+
+			_, _, err := client.Zones.Get(zone)
+			for _, other := range all {
+				require.Equal(t, other == class, errors.Is(err, other), "%d %v", status, other)
+			}
+			var restErr *api.Error
+			require.True(t, errors.As(err, &restErr))
+			require.Contains(t, restErr.Details, "errors")
+		}
+	})
+}
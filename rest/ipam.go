@@ -15,15 +15,15 @@ type IPAMService service
 // networks.
 //
 // NS1 API docs: https://ns1.com/api#getview-a-list-of-root-addresses
-func (s *IPAMService) ListAddrs() ([]ipam.Address, *http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "ipam/address", nil)
+func (s *IPAMService) ListAddrs(opts ...RequestOption) ([]ipam.Address, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "ipam/address", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	addrs := []ipam.Address{}
 	var resp *http.Response
-	if s.client.FollowPagination {
+	if s.client.snapshot().FollowPagination {
 		resp, err = s.client.DoWithPagination(req, &addrs, s.nextAddrs)
 	} else {
 		resp, err = s.client.Do(req, &addrs)
@@ -68,7 +68,7 @@ func (s *IPAMService) GetChildren(addrID int) ([]*ipam.Address, *http.Response,
 
 	addrs := []*ipam.Address{}
 	var resp *http.Response
-	if s.client.FollowPagination {
+	if s.client.snapshot().FollowPagination {
 		resp, err = s.client.DoWithPagination(req, &addrs, s.nextAddrs)
 	} else {
 		resp, err = s.client.Do(req, &addrs)
@@ -233,12 +233,18 @@ func (s *IPAMService) nextAddrs(v *interface{}, uri string) (*http.Response, err
 	if err != nil {
 		return resp, err
 	}
-	addrList, ok := (*v).(*[]*ipam.Address)
-	if !ok {
+	switch addrList := (*v).(type) {
+	case *[]*ipam.Address:
+		*addrList = append(*addrList, addrs...)
+	case *[]ipam.Address:
+		// ListAddrs collects addresses by value.
+		for _, a := range addrs {
+			*addrList = append(*addrList, *a)
+		}
+	default:
 		return nil, fmt.Errorf(
-			"incorrect value for v, expected value of type *[]ipam.Address, got: %T", v,
+			"incorrect value for v, expected value of type *[]ipam.Address, got: %T", *v,
 		)
 	}
-	*addrList = append(*addrList, addrs...)
 	return resp, nil
 }
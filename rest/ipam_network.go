@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/ipam"
+)
+
+// ListNetworks returns all IPAM networks.
+//
+// NS1 API docs: https://ns1.com/api#getview-a-list-of-networks
+func (s *IPAMService) ListNetworks(opts ...RequestOption) ([]*ipam.Network, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "ipam/network", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nets := []*ipam.Network{}
+	resp, err := s.client.Do(req, &nets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return nets, resp, nil
+}
+
+// GetNetwork returns the network with the given ID.
+//
+// NS1 API docs: https://ns1.com/api#getview-a-network
+func (s *IPAMService) GetNetwork(id int) (*ipam.Network, *http.Response, error) {
+	reqPath := fmt.Sprintf("ipam/network/%d", id)
+	req, err := s.client.NewRequest(http.MethodGet, reqPath, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	net := &ipam.Network{}
+	resp, err := s.client.Do(req, net)
+	if err != nil {
+		return nil, resp, networkError(err)
+	}
+
+	return net, resp, nil
+}
+
+// CreateNetwork creates a network. The Name field is required.
+//
+// NS1 API docs: https://ns1.com/api#putcreate-a-network
+func (s *IPAMService) CreateNetwork(net *ipam.Network) (*ipam.Network, *http.Response, error) {
+	if net.Name == "" {
+		return nil, nil, errors.New("the Name field is required")
+	}
+
+	req, err := s.client.NewRequest(http.MethodPut, "ipam/network", net)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respNet := &ipam.Network{}
+	resp, err := s.client.Do(req, respNet)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return respNet, resp, nil
+}
+
+// EditNetwork updates an existing network. The ID field is required.
+//
+// NS1 API docs: https://ns1.com/api#postedit-a-network
+func (s *IPAMService) EditNetwork(net *ipam.Network) (*ipam.Network, *http.Response, error) {
+	if net.ID == 0 {
+		return nil, nil, errors.New("the ID field is required")
+	}
+
+	reqPath := fmt.Sprintf("ipam/network/%d", net.ID)
+	req, err := s.client.NewRequest(http.MethodPost, reqPath, net)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respNet := &ipam.Network{}
+	resp, err := s.client.Do(req, respNet)
+	if err != nil {
+		return nil, resp, networkError(err)
+	}
+
+	return respNet, resp, nil
+}
+
+// DeleteNetwork removes a network and all of its addresses.
+//
+// NS1 API docs: https://ns1.com/api#deletedelete-a-network
+func (s *IPAMService) DeleteNetwork(id int) (*http.Response, error) {
+	reqPath := fmt.Sprintf("ipam/network/%d", id)
+	req, err := s.client.NewRequest(http.MethodDelete, reqPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, networkError(err)
+	}
+
+	return resp, nil
+}
+
+// SearchAddrs returns the addresses matching the given query parameters,
+// e.g. SetIntParam("network", 1) and SetStringParam("status", "assigned").
+// All pages of results are returned when FollowPagination is set.
+//
+// NS1 API docs: https://ns1.com/api#getsearch-addresses
+func (s *IPAMService) SearchAddrs(opts ...func(*url.Values)) ([]*ipam.Address, *http.Response, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(&v)
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, "ipam/address/search", nil, WithParams(v))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addrs := []*ipam.Address{}
+	var resp *http.Response
+	if s.client.snapshot().FollowPagination {
+		resp, err = s.client.DoWithPagination(req, &addrs, s.nextAddrs)
+	} else {
+		resp, err = s.client.Do(req, &addrs)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return addrs, resp, nil
+}
+
+// networkError maps a 404 for an IPAM network to ErrNetworkMissing.
+func networkError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrNetworkMissing
+	}
+	return err
+}
+
+var (
+	// ErrNetworkMissing bundles GET/POST/DELETE error.
+	ErrNetworkMissing = missingError("network does not exist")
+)
@@ -19,6 +19,32 @@ func TestIPAMAddrs(t *testing.T) {
 	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
 
 	t.Run("List", func(t *testing.T) {
+		t.Run("Next Page", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			client.FollowPagination = true
+			header := http.Header{}
+			header.Set("Link", `<https://`+mock.Address+`/v1/ipam/address?after=2>; rel="next"`)
+
+			err := mock.AddTestCase(http.MethodGet, "/ipam/address", http.StatusOK, nil, header, "",
+				[]ipam.Address{{Name: "a"}, {Name: "b"}})
+			if err != nil {
+				t.Fatalf("error adding test case: %v", err)
+			}
+			err = mock.AddTestCase(http.MethodGet, "/ipam/address?after=2", http.StatusOK, nil, nil, "",
+				[]ipam.Address{{Name: "c"}})
+			if err != nil {
+				t.Fatalf("error adding test case: %v", err)
+			}
+
+			respAddrs, _, err := client.IPAM.ListAddrs()
+			if err != nil {
+				t.Fatalf("error listing IPAM addresses: %v", err)
+			}
+			if len(respAddrs) != 3 || respAddrs[2].Name != "c" {
+				t.Errorf("wrong addresses: got=%v", respAddrs)
+			}
+		})
 		t.Run("Pagination", func(t *testing.T) {
 			defer mock.ClearTestCases()
 
@@ -85,6 +111,33 @@ func TestIPAMAddrs(t *testing.T) {
 		})
 	})
 
+	t.Run("Search", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		client.FollowPagination = true
+		header := http.Header{}
+		header.Set("Link", `<https://`+mock.Address+`/v1/ipam/address/search?after=1&network=1>; rel="next"`)
+
+		err := mock.AddTestCase(http.MethodGet, "/ipam/address/search?network=1", http.StatusOK, nil, header, "",
+			[]ipam.Address{{Name: "a"}})
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+		err = mock.AddTestCase(http.MethodGet, "/ipam/address/search?after=1&network=1", http.StatusOK, nil, nil, "",
+			[]ipam.Address{{Name: "b"}})
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+
+		respAddrs, _, err := client.IPAM.SearchAddrs(api.SetIntParam("network", 1))
+		if err != nil {
+			t.Fatalf("error searching IPAM addresses: %v", err)
+		}
+		if len(respAddrs) != 2 || respAddrs[1].Name != "b" {
+			t.Errorf("wrong addresses: got=%v", respAddrs)
+		}
+	})
+
 	t.Run("GetSubnet", func(t *testing.T) {
 		defer mock.ClearTestCases()
 
@@ -343,3 +396,76 @@ func TestIPAMAddrs(t *testing.T) {
 		}
 	})
 }
+
+func TestIPAMNetworks(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	if err != nil {
+		t.Fatalf("Error creating mock service: %v", err)
+	}
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("Create", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		if _, _, err := client.IPAM.CreateNetwork(&ipam.Network{}); err == nil {
+			t.Errorf("expected error for missing Name")
+		}
+
+		net := &ipam.Network{Name: "office"}
+		err := mock.AddTestCase(http.MethodPut, "/ipam/network", http.StatusCreated, nil, nil, net,
+			ipam.Network{ID: 1, Name: "office"})
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+
+		respNet, _, err := client.IPAM.CreateNetwork(net)
+		if err != nil {
+			t.Fatalf("error creating network: %v", err)
+		}
+		if respNet.ID != 1 {
+			t.Errorf("wrong network ID: want=1, got=%d", respNet.ID)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		err := mock.AddTestCase(http.MethodGet, "/ipam/network", http.StatusOK, nil, nil, "",
+			[]ipam.Network{{ID: 1, Name: "office"}, {ID: 2, Name: "lab"}})
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+
+		nets, _, err := client.IPAM.ListNetworks()
+		if err != nil {
+			t.Fatalf("error listing networks: %v", err)
+		}
+		if len(nets) != 2 {
+			t.Errorf("wrong length: want=2, got=%d", len(nets))
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		err := mock.AddTestCase(http.MethodGet, "/ipam/network/9", http.StatusNotFound, nil, nil, "",
+			`{"message": "network not found"}`)
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+		err = mock.AddTestCase(http.MethodDelete, "/ipam/network/9", http.StatusNotFound, nil, nil, "",
+			`{"message": "network not found"}`)
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+
+		if _, _, err := client.IPAM.GetNetwork(9); err != api.ErrNetworkMissing {
+			t.Errorf("wrong error: want=%v, got=%v", api.ErrNetworkMissing, err)
+		}
+		if _, err := client.IPAM.DeleteNetwork(9); err != api.ErrNetworkMissing {
+			t.Errorf("wrong error: want=%v, got=%v", api.ErrNetworkMissing, err)
+		}
+	})
+}
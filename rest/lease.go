@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dhcp"
+)
+
+// LeaseService handles the 'lease' endpoints.
+type LeaseService service
+
+// List returns the current DHCP leases, optionally filtered with query
+// parameters such as WithParam("scopeGroupId", "1") or
+// WithParam("address", "10.0.0.5").
+//
+// NS1 API docs: https://ns1.com/api#getlist-leases
+func (s *LeaseService) List(opts ...RequestOption) ([]dhcp.Lease, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "dhcp/lease", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ls := make([]dhcp.Lease, 0)
+	resp, err := s.client.Do(req, &ls)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ls, resp, nil
+}
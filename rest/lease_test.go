@@ -0,0 +1,44 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dhcp"
+)
+
+func TestDHCPLease(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	if err != nil {
+		t.Fatalf("Error creating mock service: %v", err)
+	}
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		leases := []dhcp.Lease{
+			{Address: "10.0.0.5", Mac: "00:11:22:33:44:55", Hostname: "printer"},
+			{Address: "10.0.0.6"},
+		}
+		err := mock.AddTestCase(http.MethodGet, "/dhcp/lease?scopeGroupId=1", http.StatusOK, nil, nil, "", leases)
+		if err != nil {
+			t.Fatalf("error adding test case: %v", err)
+		}
+
+		respLeases, _, err := client.Lease.List(api.WithParam("scopeGroupId", "1"))
+		if err != nil {
+			t.Fatalf("error listing leases: %v", err)
+		}
+		if len(respLeases) != len(leases) {
+			t.Errorf("wrong length: want=%d, got=%d", len(leases), len(respLeases))
+		}
+		if respLeases[0].Hostname != "printer" {
+			t.Errorf("wrong hostname: want=%q, got=%q", "printer", respLeases[0].Hostname)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateObserver is implemented by SharedLimiters that tune themselves to the
+// rate limit headers: Do calls Observe with the RateLimit of each response
+// carrying them, or a Retry-After.
+type RateObserver interface {
+	Observe(RateLimit)
+}
+
+// TokenBucketLimiter is a SharedLimiter for the goroutines sharing one
+// Client. It holds a token bucket sized and refilled like the account's
+// quota as reported by the X-Ratelimit-* headers (Limit requests per
+// Period), and each request takes a token before it is sent, blocking until
+// one is available. The bucket never holds more tokens than the latest
+// response reported remaining, so together the goroutines stay within the
+// limit however many there are.
+//
+// Until a response reports the rate limit, requests are not limited. A
+// response with a Retry-After (typically a 429) holds back all goroutines
+// until then, even if the rate limit is not known. It is safe for concurrent
+// use; see Client.RateLimitStrategyTokenBucket.
+type TokenBucketLimiter struct {
+	mu     sync.Mutex
+	known  bool
+	tokens float64
+	limit  float64 // bucket capacity
+	rate   float64 // tokens per second
+	last   time.Time
+	until  time.Time // no tokens are handed out before, per Retry-After
+}
+
+// NewTokenBucketLimiter returns a TokenBucketLimiter that is not limiting
+// until it observes a rate limit.
+func NewTokenBucketLimiter() *TokenBucketLimiter {
+	return &TokenBucketLimiter{}
+}
+
+// RateLimitStrategyTokenBucket makes the client's requests take tokens from
+// a TokenBucketLimiter shared by all goroutines using the client, in place
+// of per-goroutine sleeps after each response. The RateLimitFunc is reset to
+// its no-op default.
+func (c *Client) RateLimitStrategyTokenBucket() {
+	c.lock()
+	defer c.unlock()
+	c.SharedLimiter = NewTokenBucketLimiter()
+	c.RateLimitFunc = defaultRateLimitFunc
+	c.RateLimitContextFunc = nil
+}
+
+// Acquire takes a token, waiting for the bucket to refill if it is empty or
+// for a Retry-After to pass. It returns ctx.Err() if ctx is done first.
+func (l *TokenBucketLimiter) Acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if wait := time.Until(l.until); wait > 0 {
+			l.mu.Unlock()
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		if !l.known {
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+		l.refill(time.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Observe re-tunes the bucket to the reported rate limit, and holds it back
+// until rl.RetryAfter has passed if set.
+func (l *TokenBucketLimiter) Observe(rl RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if rl.RetryAfter > 0 {
+		if until := now.Add(rl.RetryAfter); until.After(l.until) {
+			l.until = until
+		}
+	}
+	if rl.Limit <= 0 || rl.Period <= 0 {
+		return
+	}
+
+	if l.known {
+		l.refill(now)
+	} else {
+		l.tokens, l.last, l.known = float64(rl.Remaining), now, true
+	}
+	l.limit = float64(rl.Limit)
+	l.rate = float64(rl.Limit) / float64(rl.Period)
+	if remaining := float64(rl.Remaining); l.tokens > remaining {
+		l.tokens = remaining
+	}
+	if l.tokens > l.limit {
+		l.tokens = l.limit
+	}
+}
+
+// refill adds the tokens accrued since the last refill; l.mu must be held.
+func (l *TokenBucketLimiter) refill(now time.Time) {
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.limit {
+		l.tokens = l.limit
+	}
+	l.last = now
+}
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	l := NewTokenBucketLimiter()
+
+	// Not limiting before a rate limit is known.
+	for i := 0; i < 100; i++ {
+		require.Nil(t, l.Acquire(context.Background()))
+	}
+
+	// 5 requests left, refilling at 100 per second: 25 requests take about
+	// 200ms whatever the number of goroutines.
+	l.Observe(RateLimit{Limit: 100, Remaining: 5, Period: 1})
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, l.Acquire(context.Background()))
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	assert.True(t, elapsed >= 180*time.Millisecond, elapsed)
+	assert.True(t, elapsed < 2*time.Second, elapsed)
+
+	// An empty bucket can't be waited on past the context.
+	l.Observe(RateLimit{Limit: 1, Remaining: 0, Period: 60})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Acquire(ctx))
+}
+
+func TestClient_RateLimitStrategyTokenBucket(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		remaining = 3
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if remaining == 0 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		remaining--
+		w.Header().Set(headerRateLimit, "3")
+		w.Header().Set(headerRateRemaining, strconv.Itoa(remaining))
+		w.Header().Set(headerRatePeriod, "60")
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	c.RateLimitStrategyTokenBucket()
+
+	get := func(ctx context.Context) error {
+		req, err := c.NewRequestWithContext(ctx, "GET", "zones", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		return err
+	}
+
+	// The first response tunes the bucket: with 2 requests left for the
+	// next 60s, further ones block instead of getting a 429.
+	require.Nil(t, get(context.Background()))
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			errs[i] = get(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	var ok, blocked int
+	for _, err := range errs {
+		switch err {
+		case nil:
+			ok++
+		case context.DeadlineExceeded:
+			blocked++
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	assert.Equal(t, 2, ok)
+	assert.Equal(t, 2, blocked)
+	assert.Equal(t, int64(0), c.RequestStats().RateLimited)
+}
+
+func TestTokenBucketLimiter_RetryAfter(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			// No rate limit headers, just a Retry-After.
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	c.RateLimitStrategyTokenBucket()
+
+	req, err := c.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	_, err = c.Do(req, nil)
+	require.NotNil(t, err)
+
+	// Other goroutines are held back until the Retry-After has passed.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, c.SharedLimiter.Acquire(ctx))
+
+	start := time.Now()
+	require.Nil(t, c.SharedLimiter.Acquire(context.Background()))
+	assert.True(t, time.Since(start) < time.Second)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, calls)
+}
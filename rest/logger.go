@@ -0,0 +1,151 @@
+package rest
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger is the interface the client logs through, satisfied by *log.Logger
+// and the printf-style loggers of most logging libraries (e.g. a zap
+// SugaredLogger's Infof adapted to Printf).
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// stdLogger is the default Logger, printing to the standard logger.
+type stdLogger struct{}
+
+func (stdLogger) Printf(format string, args ...interface{}) { log.Printf(format, args...) }
+
+// nopLogger discards all output.
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// LeveledLogger is a structured Logger with levels, taking a message and
+// alternating keys and values, like the sugared loggers of zap and similar
+// libraries. When the client's Logger implements it, Do also logs each
+// round trip with the method, url, status, duration and the rate limit
+// headers as fields: at Debug level, Warn for a 429 response and Error when
+// the request failed in transit. Headers and bodies are never logged, so
+// neither are the API key and payloads. See NewLeveledLogger to use a
+// printf-style Logger.
+type LeveledLogger interface {
+	Logger
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LogLevel is the severity of a LeveledLogger message.
+type LogLevel int
+
+// The LogLevels, from the most verbose.
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// String returns the lowercase name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// NewLeveledLogger returns a LeveledLogger printing messages of min level
+// or above to l as a line of the level, message and key=value fields, e.g.
+//
+//	client := rest.NewClient(nil, rest.SetLogger(rest.NewLeveledLogger(logger, rest.LogDebug)))
+//
+// A nil l logs to the standard logger.
+func NewLeveledLogger(l Logger, min LogLevel) LeveledLogger {
+	if l == nil {
+		l = stdLogger{}
+	}
+	return &printfLeveledLogger{l: l, min: min}
+}
+
+// printfLeveledLogger is a LeveledLogger over a printf-style Logger.
+type printfLeveledLogger struct {
+	l   Logger
+	min LogLevel
+}
+
+func (p *printfLeveledLogger) Printf(format string, args ...interface{}) {
+	p.l.Printf(format, args...)
+}
+
+func (p *printfLeveledLogger) Debug(msg string, kvs ...interface{}) { p.log(LogDebug, msg, kvs) }
+func (p *printfLeveledLogger) Info(msg string, kvs ...interface{})  { p.log(LogInfo, msg, kvs) }
+func (p *printfLeveledLogger) Warn(msg string, kvs ...interface{})  { p.log(LogWarn, msg, kvs) }
+func (p *printfLeveledLogger) Error(msg string, kvs ...interface{}) { p.log(LogError, msg, kvs) }
+
+func (p *printfLeveledLogger) log(level LogLevel, msg string, kvs []interface{}) {
+	if level < p.min {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i < len(kvs); i += 2 {
+		if i+1 < len(kvs) {
+			fmt.Fprintf(&b, " %v=%v", kvs[i], kvs[i+1])
+		} else {
+			fmt.Fprintf(&b, " %v", kvs[i])
+		}
+	}
+	p.l.Printf("%s", b.String())
+}
+
+// SetLogger sets a Client instances' Logger. A nil logger silences the
+// client.
+func SetLogger(l Logger) func(*Client) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	return func(c *Client) { c.Logger = l }
+}
+
+// logf logs through the client's Logger, or the standard logger if unset.
+func (c Client) logf(format string, args ...interface{}) {
+	if c.Logger == nil {
+		stdLogger{}.Printf(format, args...)
+		return
+	}
+	// A panicking Logger drops the message; there is nowhere left to log it.
+	defer func() { recover() }()
+	c.Logger.Printf(format, args...)
+}
+
+// logRoundTrip logs an attempt of Do to a LeveledLogger.
+func (c Client) logRoundTrip(l LeveledLogger, req *http.Request, resp *http.Response, took time.Duration, err error) {
+	kvs := []interface{}{"method", req.Method, "url", req.URL.String(), "duration", took}
+	if err != nil {
+		l.Error("ns1: request failed", append(kvs, "error", err)...)
+		return
+	}
+	kvs = append(kvs, "status", resp.StatusCode)
+	if resp.Header.Get(headerRateRemaining) != "" {
+		rl := parseRate(resp)
+		kvs = append(kvs, "ratelimit_limit", rl.Limit, "ratelimit_remaining", rl.Remaining, "ratelimit_period", rl.Period)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.Warn("ns1: rate limited", kvs...)
+		return
+	}
+	l.Debug("ns1: request", kvs...)
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type linesLogger struct{ lines []string }
+
+func (l *linesLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestNewLeveledLogger(t *testing.T) {
+	out := &linesLogger{}
+	l := NewLeveledLogger(out, LogInfo)
+	l.Debug("hidden")
+	l.Info("zone created", "zone", "example.com", "odd")
+	l.Error("failed", "status", 500)
+	assert.Equal(t, []string{
+		"[info] zone created zone=example.com odd",
+		"[error] failed status=500",
+	}, out.lines)
+}
+
+func TestClient_LeveledLogger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "9")
+		w.Header().Set(headerRatePeriod, "1")
+		if r.URL.Path == "/busy" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	out := &linesLogger{}
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), SetAPIKey("secret"),
+		SetLogger(NewLeveledLogger(out, LogDebug)))
+	do := func(path string) {
+		req, err := client.NewRequest("PUT", path, map[string]string{"password": "hunter2"})
+		require.Nil(t, err)
+		client.Do(req, nil) // nolint: errcheck
+	}
+
+	do("zones")
+	do("busy")
+	require.Len(t, out.lines, 2)
+	assert.True(t, strings.HasPrefix(out.lines[0], "[debug] ns1: request method=PUT url="+ts.URL+"/zones duration="), out.lines[0])
+	assert.Contains(t, out.lines[0], "status=200 ratelimit_limit=10 ratelimit_remaining=9 ratelimit_period=1")
+	assert.True(t, strings.HasPrefix(out.lines[1], "[warn] ns1: rate limited method=PUT"), out.lines[1])
+	for _, line := range out.lines {
+		assert.NotContains(t, line, "secret")
+		assert.NotContains(t, line, "hunter2")
+	}
+
+	out.lines = nil
+	SetMiddleware(func(Doer) Doer {
+		return DoerFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("offline") })
+	})(client)
+	do("zones")
+	require.Len(t, out.lines, 1)
+	assert.True(t, strings.HasPrefix(out.lines[0], "[error] ns1: request failed"), out.lines[0])
+	assert.True(t, strings.HasSuffix(out.lines[0], "error=offline"), out.lines[0])
+
+	// A printf-style Logger only gets the client's own messages.
+	plain := &linesLogger{}
+	SetLogger(plain)(client)
+	SetMiddleware()(client)
+	do("zones")
+	assert.Empty(t, plain.lines)
+}
@@ -0,0 +1,192 @@
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RequestStats is a snapshot of the request counters of a Client, for tuning
+// the rate limiting and retry configuration. See Client.RequestStats.
+type RequestStats struct {
+	// Attempts is the number of HTTP requests sent, including retries.
+	Attempts int64
+	// Retries is the number of attempts that were retries of an earlier
+	// failed attempt.
+	Retries int64
+	// RateLimited is the number of 429 Too Many Requests responses.
+	RateLimited int64
+
+	// RateLimitWait is the total time spent waiting on the SharedLimiter and
+	// in the RateLimitFunc.
+	RateLimitWait time.Duration
+	// BackoffTime is the total time spent backing off between retries.
+	BackoffTime time.Duration
+}
+
+// requestCounters holds the counters behind RequestStats. It is shared by
+// pointer so copies of a Client (the snapshot each request reads its
+// configuration from, or one made by WithContext) update the same counters,
+// and is safe for concurrent use.
+type requestCounters struct {
+	attempts      int64
+	retries       int64
+	rateLimited   int64
+	rateLimitWait int64 // nanoseconds
+	backoff       int64 // nanoseconds
+
+	// Request latencies, a *latencyHistogram once MetricsHandler is called.
+	latency atomic.Value
+}
+
+func (rc *requestCounters) attempt(retry bool) {
+	if rc == nil {
+		return
+	}
+	atomic.AddInt64(&rc.attempts, 1)
+	if retry {
+		atomic.AddInt64(&rc.retries, 1)
+	}
+}
+
+func (rc *requestCounters) rateLimitResponse() {
+	if rc == nil {
+		return
+	}
+	atomic.AddInt64(&rc.rateLimited, 1)
+}
+
+func (rc *requestCounters) waited(d time.Duration) {
+	if rc == nil {
+		return
+	}
+	atomic.AddInt64(&rc.rateLimitWait, int64(d))
+}
+
+func (rc *requestCounters) backedOff(d time.Duration) {
+	if rc == nil {
+		return
+	}
+	atomic.AddInt64(&rc.backoff, int64(d))
+}
+
+func (rc *requestCounters) snapshot() RequestStats {
+	if rc == nil {
+		return RequestStats{}
+	}
+	return RequestStats{
+		Attempts:      atomic.LoadInt64(&rc.attempts),
+		Retries:       atomic.LoadInt64(&rc.retries),
+		RateLimited:   atomic.LoadInt64(&rc.rateLimited),
+		RateLimitWait: time.Duration(atomic.LoadInt64(&rc.rateLimitWait)),
+		BackoffTime:   time.Duration(atomic.LoadInt64(&rc.backoff)),
+	}
+}
+
+func (rc *requestCounters) reset() {
+	if rc == nil {
+		return
+	}
+	atomic.StoreInt64(&rc.attempts, 0)
+	atomic.StoreInt64(&rc.retries, 0)
+	atomic.StoreInt64(&rc.rateLimited, 0)
+	atomic.StoreInt64(&rc.rateLimitWait, 0)
+	atomic.StoreInt64(&rc.backoff, 0)
+}
+
+// RequestStats returns a snapshot of the client's request counters since it
+// was created or last reset. It is safe to call concurrently with requests.
+func (c *Client) RequestStats() RequestStats {
+	return c.counters.snapshot()
+}
+
+// ResetRequestStats zeroes the client's request counters.
+func (c *Client) ResetRequestStats() {
+	c.counters.reset()
+}
+
+// latencyBuckets are the upper bounds of the request duration histogram, in
+// seconds.
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// latencyHistogram is a cumulative-on-export histogram of request durations,
+// safe for concurrent use.
+type latencyHistogram struct {
+	counts []int64 // per bucket, the last one for +Inf
+	count  int64
+	sum    int64 // nanoseconds
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	i := 0
+	for i < len(latencyBuckets) && d.Seconds() > latencyBuckets[i] {
+		i++
+	}
+	atomic.AddInt64(&h.counts[i], 1)
+	atomic.AddInt64(&h.count, 1)
+	atomic.AddInt64(&h.sum, int64(d))
+}
+
+// histogram returns the latency histogram, nil until MetricsHandler enables
+// it.
+func (rc *requestCounters) histogram() *latencyHistogram {
+	if rc == nil {
+		return nil
+	}
+	h, _ := rc.latency.Load().(*latencyHistogram)
+	return h
+}
+
+// MetricsHandler returns an http.Handler serving the client's request
+// counters, a histogram of request durations, and the rate limit of the
+// latest response in the Prometheus text format, to be scraped e.g. at
+// /metrics. Durations are only measured once MetricsHandler has been
+// called, so clients that don't use it pay nothing for the histogram. Copies
+// of the client share the metrics.
+func (c *Client) MetricsHandler() http.Handler {
+	if c.counters != nil && c.counters.histogram() == nil {
+		c.counters.latency.Store(&latencyHistogram{counts: make([]int64, len(latencyBuckets)+1)})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(c.metrics())
+	})
+}
+
+// metrics renders the client's metrics in the Prometheus text format.
+func (c *Client) metrics() []byte {
+	var buf bytes.Buffer
+	metric := func(name, typ, help string, value interface{}) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, typ, name, value)
+	}
+
+	stats := c.RequestStats()
+	metric("ns1_requests_total", "counter", "HTTP requests sent, including retries.", stats.Attempts)
+	metric("ns1_request_retries_total", "counter", "HTTP requests that were retries.", stats.Retries)
+	metric("ns1_rate_limited_total", "counter", "429 Too Many Requests responses.", stats.RateLimited)
+	metric("ns1_rate_limit_wait_seconds_total", "counter", "Time spent waiting on rate limiting.", stats.RateLimitWait.Seconds())
+	metric("ns1_backoff_seconds_total", "counter", "Time spent backing off between retries.", stats.BackoffTime.Seconds())
+
+	if h := c.counters.histogram(); h != nil {
+		const name = "ns1_request_duration_seconds"
+		fmt.Fprintf(&buf, "# HELP %s Duration of HTTP requests.\n# TYPE %s histogram\n", name, name)
+		var cumulative int64
+		for i, le := range latencyBuckets {
+			cumulative += atomic.LoadInt64(&h.counts[i])
+			fmt.Fprintf(&buf, "%s_bucket{le=\"%s\"} %d\n", name, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		cumulative += atomic.LoadInt64(&h.counts[len(latencyBuckets)])
+		fmt.Fprintf(&buf, "%s_bucket{le=\"+Inf\"} %d\n", name, cumulative)
+		fmt.Fprintf(&buf, "%s_sum %v\n", name, time.Duration(atomic.LoadInt64(&h.sum)).Seconds())
+		fmt.Fprintf(&buf, "%s_count %d\n", name, atomic.LoadInt64(&h.count))
+	}
+
+	if rl, ok := c.LastRateLimit(); ok {
+		metric("ns1_rate_limit_remaining", "gauge", "Requests remaining in the rate limit period, as of the latest response.", rl.Remaining)
+		metric("ns1_rate_limit_limit", "gauge", "Requests allowed per rate limit period, as of the latest response.", rl.Limit)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RequestStats(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"message": "rate limit exceeded"}`)) // nolint: errcheck
+			return
+		}
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRateLimitFunc(func(RateLimit) {
+		time.Sleep(time.Millisecond)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			path := "ok"
+			if i%4 == 0 {
+				path = "limited"
+			}
+			req, _ := c.NewRequest("GET", path, nil)
+			c.Do(req, nil) // nolint: errcheck
+		}(i)
+	}
+	wg.Wait()
+
+	stats := c.RequestStats()
+	assert.Equal(t, int64(20), stats.Attempts)
+	assert.Equal(t, int64(0), stats.Retries)
+	assert.Equal(t, int64(5), stats.RateLimited)
+	assert.True(t, stats.RateLimitWait >= 20*time.Millisecond)
+
+	c.ResetRequestStats()
+	assert.Equal(t, RequestStats{}, c.RequestStats())
+
+	// A zero Client has no counters but reports zero stats.
+	assert.Equal(t, RequestStats{}, (&Client{}).RequestStats())
+}
+
+func TestClient_MetricsHandler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "100")
+		w.Header().Set(headerRateRemaining, "42")
+		w.Header().Set(headerRatePeriod, "100")
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		client.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+		return rec.Body.String()
+	}
+
+	out := scrape()
+	assert.Contains(t, out, "# TYPE ns1_requests_total counter\nns1_requests_total 0\n")
+	assert.Contains(t, out, "ns1_request_duration_seconds_count 0\n")
+	assert.NotContains(t, out, "ns1_rate_limit_remaining")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest("GET", "zones", nil)
+			client.Do(req, nil) // nolint: errcheck
+			scrape()
+		}()
+	}
+	wg.Wait()
+
+	out = scrape()
+	assert.Contains(t, out, "ns1_requests_total 5\n")
+	assert.Contains(t, out, "ns1_request_retries_total 0\n")
+	assert.Contains(t, out, `ns1_request_duration_seconds_bucket{le="+Inf"} 5`+"\n")
+	assert.Contains(t, out, "ns1_request_duration_seconds_count 5\n")
+	assert.Contains(t, out, "# TYPE ns1_rate_limit_remaining gauge\nns1_rate_limit_remaining 42\n")
+	assert.Contains(t, out, "ns1_rate_limit_limit 100\n")
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		assert.True(t, strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "ns1_"), line)
+	}
+}
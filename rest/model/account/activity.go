@@ -0,0 +1,25 @@
+package account
+
+import (
+	"encoding/json"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+)
+
+// Activity wraps an NS1 /account/activity resource, an entry of the
+// account's activity log: a change made to a resource, and by whom.
+type Activity struct {
+	ID        string     `json:"id"`
+	Timestamp model.Time `json:"timestamp"`
+	Action    string     `json:"action"`
+
+	ResourceID   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+	// Resource is the resource as of the change, its shape depending on
+	// ResourceType.
+	Resource json.RawMessage `json:"resource,omitempty"`
+
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name"`
+	UserType string `json:"user_type"`
+}
@@ -1,11 +1,17 @@
 package account
 
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
 // APIKey wraps an NS1 /account/apikeys resource
 type APIKey struct {
 	// Read-only fields
-	ID         string `json:"id,omitempty"`
-	Key        string `json:"key,omitempty"`
-	LastAccess int    `json:"last_access,omitempty"`
+	ID         string      `json:"id,omitempty"`
+	LastAccess *model.Time `json:"last_access,omitempty"`
+
+	// Key is the secret token itself. NS1 only returns it in the response to
+	// the request that creates the key; it is never exposed by Get or List,
+	// so callers must store it when the key is created.
+	Key string `json:"key,omitempty"`
 
 	Name              string         `json:"name"`
 	TeamIDs           []string       `json:"teams"`
@@ -13,3 +19,9 @@ type APIKey struct {
 	IPWhitelist       []string       `json:"ip_whitelist"`
 	IPWhitelistStrict bool           `json:"ip_whitelist_strict"`
 }
+
+// String returns the keys' name, so that printing or logging an APIKey never
+// leaks the secret.
+func (k APIKey) String() string {
+	return k.Name
+}
@@ -1,6 +1,10 @@
 package account
 
-// PermissionsMap wraps a User's "permissions" attribute
+import "strings"
+
+// PermissionsMap wraps a User's "permissions" attribute. The same map is
+// used for the permissions of Teams and APIKeys; users and keys that belong
+// to teams get the union of their own and their teams' permissions.
 type PermissionsMap struct {
 	DNS        PermissionsDNS        `json:"dns"`
 	Data       PermissionsData       `json:"data"`
@@ -24,6 +28,66 @@ type PermissionsDNS struct {
 	RecordsDeny         []PermissionsRecord `json:"records_deny"`
 }
 
+// ZoneAllowed reports whether the zone allow/deny lists permit access to
+// zone: with ZonesAllowByDefault every zone not in ZonesDeny is permitted,
+// otherwise only the zones in ZonesAllow are. The ViewZones and ManageZones
+// flags are not considered, see CanViewZone and CanManageZone.
+func (p PermissionsDNS) ZoneAllowed(zone string) bool {
+	if p.ZonesAllowByDefault {
+		return !containsZone(p.ZonesDeny, zone)
+	}
+	return containsZone(p.ZonesAllow, zone)
+}
+
+// CanViewZone reports whether zone may be read.
+func (p PermissionsDNS) CanViewZone(zone string) bool {
+	return (p.ViewZones || p.ManageZones) && p.ZoneAllowed(zone)
+}
+
+// CanManageZone reports whether zone and its records may be modified.
+func (p PermissionsDNS) CanManageZone(zone string) bool {
+	return p.ManageZones && p.ZoneAllowed(zone)
+}
+
+// CanManageRecord reports whether a record of zone may be modified: a record
+// in RecordsDeny may not, one in RecordsAllow may even in a zone that is not
+// allowed, and others follow CanManageZone. ManageZones is required in all
+// cases.
+func (p PermissionsDNS) CanManageRecord(zone, domain, recordType string) bool {
+	if !p.ManageZones || containsRecord(p.RecordsDeny, zone, domain, recordType) {
+		return false
+	}
+	return containsRecord(p.RecordsAllow, zone, domain, recordType) || p.ZoneAllowed(zone)
+}
+
+func containsRecord(records []PermissionsRecord, zone, domain, recordType string) bool {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for _, r := range records {
+		if strings.ToLower(strings.TrimSuffix(r.Zone, ".")) != zone {
+			continue
+		}
+		if r.RecordType != "" && !strings.EqualFold(r.RecordType, recordType) {
+			continue
+		}
+		rd := strings.ToLower(strings.TrimSuffix(r.Domain, "."))
+		if domain == rd || (r.Subdomains && strings.HasSuffix(domain, "."+rd)) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsZone(zones []string, zone string) bool {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	for _, z := range zones {
+		if strings.ToLower(strings.TrimSuffix(z, ".")) == zone {
+			return true
+		}
+	}
+	return false
+}
+
 // PermissionsData wraps a User's "permissions.data" attribute
 type PermissionsData struct {
 	PushToDatafeeds   bool `json:"push_to_datafeeds"`
@@ -85,6 +149,9 @@ type AuthTag struct {
 	Value string `json:"value"`
 }
 
+// PermissionsRecord selects the records listed in
+// "permissions.dns.records_allow" and "permissions.dns.records_deny",
+// optionally including the subdomains of Domain.
 type PermissionsRecord struct {
 	Domain     string `json:"domain"`
 	Subdomains bool   `json:"include_subdomains"`
@@ -0,0 +1,41 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsDNSZones(t *testing.T) {
+	p := PermissionsDNS{ViewZones: true, ManageZones: true, ZonesAllowByDefault: true, ZonesDeny: []string{"secret.com"}}
+	assert.True(t, p.CanManageZone("example.com"))
+	assert.False(t, p.CanViewZone("Secret.com."))
+
+	p = PermissionsDNS{ViewZones: true, ZonesAllow: []string{"example.com"}}
+	assert.True(t, p.CanViewZone("example.com"))
+	assert.False(t, p.CanManageZone("example.com"))
+	assert.False(t, p.CanViewZone("other.com"))
+
+	p = PermissionsDNS{ZonesAllowByDefault: true}
+	assert.True(t, p.ZoneAllowed("example.com"))
+	assert.False(t, p.CanViewZone("example.com"))
+}
+
+func TestPermissionsDNSRecords(t *testing.T) {
+	p := PermissionsDNS{
+		ManageZones: true,
+		ZonesAllow:  []string{"example.com"},
+		RecordsDeny: []PermissionsRecord{{Zone: "example.com", Domain: "example.com", RecordType: "NS"}},
+		RecordsAllow: []PermissionsRecord{
+			{Zone: "other.com", Domain: "dev.other.com", Subdomains: true},
+		},
+	}
+	assert.True(t, p.CanManageRecord("example.com", "www.example.com", "A"))
+	assert.False(t, p.CanManageRecord("example.com", "example.com.", "ns"))
+	assert.True(t, p.CanManageRecord("example.com", "example.com", "A"))
+	assert.True(t, p.CanManageRecord("other.com", "api.dev.other.com", "A"))
+	assert.False(t, p.CanManageRecord("other.com", "www.other.com", "A"))
+
+	p.ManageZones = false
+	assert.False(t, p.CanManageRecord("example.com", "www.example.com", "A"))
+}
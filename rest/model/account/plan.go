@@ -0,0 +1,14 @@
+package account
+
+// Plan represents an account's billing plan.
+type Plan struct {
+	Type   string `json:"type,omitempty"`
+	Period string `json:"period,omitempty"`
+
+	// Included holds the quantities the plan includes, keyed by item,
+	// e.g. "queries" and "records".
+	Included map[string]float64 `json:"included,omitempty"`
+	// Overage holds the price per unit beyond the included quantities,
+	// keyed by item.
+	Overage map[string]float64 `json:"overage,omitempty"`
+}
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+)
+
+// Usage periods accepted by the 'stats/usage' endpoints.
+const (
+	UsagePeriod1h  = "1h"
+	UsagePeriod24h = "24h"
+	UsagePeriod30d = "30d"
+)
+
+// Usage represents the query usage of an account, zone or record over a
+// period, as returned by the 'stats/usage' endpoints.
+type Usage struct {
+	Zone   string `json:"zone,omitempty"`
+	Domain string `json:"domain,omitempty"`
+	Type   string `json:"rectype,omitempty"`
+
+	Period  string `json:"period,omitempty"`
+	Queries int64  `json:"queries"`
+
+	// Graph is the query count over the period, one point per interval.
+	Graph []UsagePoint `json:"graph,omitempty"`
+}
+
+// UsagePoint is the number of queries in the interval ending at Time. The
+// API sends it as a [timestamp, queries] pair.
+type UsagePoint struct {
+	Time    model.Time
+	Queries int64
+}
+
+// MarshalJSON encodes the point as a [timestamp, queries] pair.
+func (p UsagePoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Time, p.Queries})
+}
+
+// UnmarshalJSON parses a point from a [timestamp, queries] pair.
+func (p *UsagePoint) UnmarshalJSON(buf []byte) error {
+	tmp := []interface{}{&p.Time, &p.Queries}
+	if err := json.Unmarshal(buf, &tmp); err != nil {
+		return err
+	}
+	if l := len(tmp); l != 2 {
+		return fmt.Errorf("wrong number of fields in UsagePoint: %d != 2", l)
+	}
+	return nil
+}
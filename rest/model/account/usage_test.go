@@ -0,0 +1,27 @@
+package account
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+)
+
+func TestUsageGraph(t *testing.T) {
+	in := []byte(`{"zone": "example.com", "period": "1h", "queries": 42, "graph": [[1500000000, 40], [1500000300, 2]]}`)
+
+	var u Usage
+	require.NoError(t, json.Unmarshal(in, &u))
+	assert.Equal(t, []UsagePoint{
+		{Time: model.Unix(1500000000), Queries: 40},
+		{Time: model.Unix(1500000300), Queries: 2},
+	}, u.Graph)
+
+	out, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(in), string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"graph": [[1500000000]]}`), &u))
+}
@@ -1,10 +1,12 @@
 package account
 
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
 // User wraps an NS1 /account/users resource
 type User struct {
-	// Read-only fields
-	LastAccess float64 `json:"last_access"`
-	Created    float64 `json:"created"`
+	// Read-only fields, nil in request bodies
+	LastAccess *model.Time `json:"last_access,omitempty"`
+	Created    *model.Time `json:"created,omitempty"`
 
 	Name                 string               `json:"name"`
 	Username             string               `json:"username"`
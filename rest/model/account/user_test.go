@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
 )
 
 func TestUnmarshalUsers(t *testing.T) {
@@ -45,8 +46,8 @@ func TestUnmarshalUsers(t *testing.T) {
 				Username:             "apiexample",
 				Name:                 "API Example",
 				Email:                "support@nsone.net",
-				LastAccess:           1376325771.0,
-				Created:              1376325771.0,
+				LastAccess:           unixTime(1376325771),
+				Created:              unixTime(1376325771),
 				Notify:               NotificationSettings{true},
 				TeamIDs:              []string{},
 				IPWhitelist:          []string{"1.1.1.1", "2.2.2.2"},
@@ -108,12 +109,11 @@ func TestUnmarshalUsers(t *testing.T) {
     "username": "newuser"
   }`),
 			User{
-				Username:   "newuser",
-				Name:       "New User",
-				Email:      "newuser@example.com",
-				TeamIDs:    []string{"520422919f782d37dffb588a"},
-				LastAccess: 0.0,
-				Notify:     NotificationSettings{true},
+				Username: "newuser",
+				Name:     "New User",
+				Email:    "newuser@example.com",
+				TeamIDs:  []string{"520422919f782d37dffb588a"},
+				Notify:   NotificationSettings{true},
 				Permissions: PermissionsMap{
 					DNS: PermissionsDNS{
 						ViewZones:           true,
@@ -164,3 +164,19 @@ func pointerBool(b bool) *bool {
 func pointerString(s string) *string {
 	return &s
 }
+
+func unixTime(sec int64) *model.Time {
+	t := model.Unix(sec)
+	return &t
+}
+
+func TestMarshalUserReadOnly(t *testing.T) {
+	b, err := json.Marshal(User{Username: "newuser"})
+	require.Nil(t, err)
+	require.NotContains(t, string(b), "last_access")
+	require.NotContains(t, string(b), `"created"`)
+
+	b, err = json.Marshal(APIKey{Name: "key"})
+	require.Nil(t, err)
+	require.NotContains(t, string(b), "last_access")
+}
@@ -0,0 +1,10 @@
+package account
+
+// WhitelistEntry wraps an NS1 /account/whitelist resource: a named list of
+// IP addresses and CIDR ranges allowed to use the API and portal, which
+// teams, users and API keys can be restricted to.
+type WhitelistEntry struct {
+	ID     string   `json:"id,omitempty"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
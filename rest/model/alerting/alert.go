@@ -0,0 +1,76 @@
+package alerting
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// Alert types and their subtypes.
+const (
+	TypeZone    = "zone"
+	TypeAccount = "account"
+
+	// SubtypeTransferFailed alerts when a secondary zone fails to transfer
+	// from its primary.
+	SubtypeTransferFailed = "transfer_failed"
+
+	// SubtypeRecordUsage and SubtypeQueryUsage alert when the account's
+	// record count or monthly queries reach a percentage of the plan.
+	SubtypeRecordUsage = "record_usage"
+	SubtypeQueryUsage  = "query_usage"
+)
+
+// Alert wraps an NS1 /alerting/v1/alerts resource.
+type Alert struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+
+	// Type and Subtype select the condition alerted on, e.g. TypeZone and
+	// SubtypeTransferFailed.
+	Type    string `json:"type"`
+	Subtype string `json:"subtype"`
+
+	// Settings of the condition, depending on the subtype.
+	Data *Data `json:"data,omitempty"`
+
+	// The notifier lists (monitoring notify lists) to alert.
+	NotifierListIDs []string `json:"notifier_list_ids"`
+
+	// The zones a zone alert watches.
+	ZoneNames []string `json:"zone_names"`
+
+	// Set by NS1.
+	CreatedAt *model.Time `json:"created_at,omitempty"`
+	CreatedBy string      `json:"created_by,omitempty"`
+	UpdatedAt *model.Time `json:"updated_at,omitempty"`
+	UpdatedBy string      `json:"updated_by,omitempty"`
+}
+
+// Data holds the settings of an Alert's condition.
+type Data struct {
+	// The percentage of the plan's limit at which a usage alert fires.
+	AlertAtPercent int `json:"alert_at_percent,omitempty"`
+}
+
+// NewZoneAlert returns an alert on the zone transfers of the given zones
+// failing, notifying the given notifier lists.
+func NewZoneAlert(name string, zones, notifierListIDs []string) *Alert {
+	return &Alert{
+		Name:            name,
+		Type:            TypeZone,
+		Subtype:         SubtypeTransferFailed,
+		ZoneNames:       zones,
+		NotifierListIDs: notifierListIDs,
+	}
+}
+
+// NewUsageAlert returns an account alert of the given usage subtype,
+// SubtypeRecordUsage or SubtypeQueryUsage, firing at percent of the plan's
+// limit and notifying the given notifier lists.
+func NewUsageAlert(name, subtype string, percent int, notifierListIDs []string) *Alert {
+	return &Alert{
+		Name:            name,
+		Type:            TypeAccount,
+		Subtype:         subtype,
+		Data:            &Data{AlertAtPercent: percent},
+		ZoneNames:       []string{},
+		NotifierListIDs: notifierListIDs,
+	}
+}
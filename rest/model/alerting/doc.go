@@ -0,0 +1,3 @@
+// Package alerting contains definitions for NS1 alerts, the conditions of
+// zones and of the account usage that are notified to notifier lists.
+package alerting
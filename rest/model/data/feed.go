@@ -29,6 +29,11 @@ type Feed struct {
 	Config Config `json:"config,omitempty"`
 	Data   Meta   `json:"data,omitempty"`
 
+	// Destinations are the records, regions or answers whose metadata
+	// references the feed. They are read-only, and maintained by NS1 from
+	// those references.
+	Destinations []Destination `json:"destinations,omitempty"`
+
 	SourceID string
 }
 
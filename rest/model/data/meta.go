@@ -16,6 +16,22 @@ type FeedPtr struct {
 	FeedID string `json:"feed,omitempty"`
 }
 
+// FeedIDOf returns the feed id if the metadata value v is a feed pointer,
+// either as built by callers (FeedPtr) or as decoded from the API
+// ({"feed": "<id>"}).
+func FeedIDOf(v interface{}) (string, bool) {
+	switch p := v.(type) {
+	case FeedPtr:
+		return p.FeedID, p.FeedID != ""
+	case *FeedPtr:
+		return p.FeedID, p != nil && p.FeedID != ""
+	case map[string]interface{}:
+		id, ok := p["feed"].(string)
+		return id, ok && id != ""
+	}
+	return "", false
+}
+
 // PulsarMeta is currently only used for validation
 type PulsarMeta struct {
 	JobID     string  `json:"job_id,omitempty"`
@@ -36,6 +52,11 @@ type PulsarMeta struct {
 //      - Meta <- highest precedence in filter chain
 //      - ...
 //    - ...
+//
+// Marshalling needs no custom MarshalJSON to be deterministic: encoding/json
+// writes map keys in sorted order (for map values such as Subdivisions, as
+// well as Regions and Config), and slices such as a record's answers keep
+// their order, so equal values always produce byte-identical JSON.
 type Meta struct {
 	// STATUS
 
@@ -64,7 +85,7 @@ type Meta struct {
 
 	// GEOGRAPHICAL
 
-	// Must be between -180.0 and +180.0 where negative
+	// Must be between -90.0 and +90.0 where negative
 	// indicates South and positive indicates North.
 	// e.g., the longitude of the datacenter where a server resides.
 	// float64 or FeedPtr.
@@ -173,6 +194,64 @@ func (meta *Meta) StringMap() map[string]interface{} {
 	return m
 }
 
+// FeedRefs returns the metadata fields whose value is a feed pointer, as a
+// map of field name (e.g. "up") to feed id.
+func (meta *Meta) FeedRefs() map[string]string {
+	refs := map[string]string{}
+	if meta == nil {
+		return refs
+	}
+	v := reflect.Indirect(reflect.ValueOf(meta))
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+		if fv.IsNil() {
+			continue
+		}
+		if id, ok := FeedIDOf(fv.Interface()); ok {
+			refs[strings.Split(t.Field(i).Tag.Get("json"), ",")[0]] = id
+		}
+	}
+	return refs
+}
+
+// SetFeed points the metadata field with the given JSON name (e.g. "up" or
+// "ip_prefixes") at a data feed, so the feed provides its value. List-valued
+// fields take the same feed pointer; the feed then publishes the list.
+func (meta *Meta) SetFeed(field, feedID string) error {
+	v := reflect.Indirect(reflect.ValueOf(meta))
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if strings.Split(t.Field(i).Tag.Get("json"), ",")[0] == field {
+			v.Field(i).Set(reflect.ValueOf(FeedPtr{FeedID: feedID}))
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown meta field %q", field)
+}
+
+// GeoCoords returns the latitude and longitude set as numbers, e.g. for the
+// geotarget_latlong filter. ok is false if either is unset or a feed
+// pointer.
+func (meta *Meta) GeoCoords() (lat, lon float64, ok bool) {
+	if meta == nil {
+		return 0, 0, false
+	}
+	lat, latOK := coordinate(meta.Latitude)
+	lon, lonOK := coordinate(meta.Longitude)
+	return lat, lon, latOK && lonOK
+}
+
+func coordinate(v interface{}) (float64, bool) {
+	switch c := v.(type) {
+	case float64:
+		return c, true
+	case int:
+		return float64(c), true
+	}
+	return 0, false
+}
+
 // FormatInterface takes an interface of types: string, bool, int, float64, []string, map[string]interface{} and FeedPtr, and returns a string representation of said interface
 func FormatInterface(i interface{}) string {
 	switch v := i.(type) {
@@ -318,13 +397,28 @@ type metaValidation struct {
 	checkFuncs []func(v reflect.Value) error
 }
 
-// validateLatLong makes sure that the given lat/long is within the range 180.0 to -180.0
-func validateLatLong(v reflect.Value) error {
-	if v.Kind() == reflect.Float64 {
-		f := v.Interface().(float64)
-		if f < -180.0 || f > 180.0 {
-			return fmt.Errorf("latitude/longitude values must be between -180.0 and 180.0, got %f", f)
-		}
+// validateLatitude makes sure that the given latitude is within the range 90.0 to -90.0
+func validateLatitude(v reflect.Value) error {
+	return validateCoordinate("latitude", 90.0, v)
+}
+
+// validateLongitude makes sure that the given longitude is within the range 180.0 to -180.0
+func validateLongitude(v reflect.Value) error {
+	return validateCoordinate("longitude", 180.0, v)
+}
+
+func validateCoordinate(name string, max float64, v reflect.Value) error {
+	var f float64
+	switch v.Kind() {
+	case reflect.Float64:
+		f = v.Float()
+	case reflect.Int:
+		f = float64(v.Int())
+	default:
+		return nil
+	}
+	if f < -max || f > max {
+		return fmt.Errorf("%s values must be between %.1f and %.1f, got %f", name, -max, max, f)
 	}
 	return nil
 }
@@ -524,8 +618,8 @@ var validationMap = map[string]metaValidation{
 			return validatePositiveNumber("LoadAvg", v)
 		})},
 	"Pulsar":     {kinds(reflect.String, reflect.Slice), checkFuncs(validatePulsar)},
-	"Latitude":   {kinds(reflect.Float64, reflect.Int), checkFuncs(validateLatLong)},
-	"Longitude":  {kinds(reflect.Float64, reflect.Int), checkFuncs(validateLatLong)},
+	"Latitude":   {kinds(reflect.Float64, reflect.Int), checkFuncs(validateLatitude)},
+	"Longitude":  {kinds(reflect.Float64, reflect.Int), checkFuncs(validateLongitude)},
 	"Georegion":  {kinds(reflect.String, reflect.Slice), checkFuncs(validateGeoregion)},
 	"Country":    {kinds(reflect.String, reflect.Slice), checkFuncs(validateCountryStateProvince)},
 	"USState":    {kinds(reflect.String, reflect.Slice), checkFuncs(validateCountryStateProvince)},
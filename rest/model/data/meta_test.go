@@ -330,3 +330,48 @@ func TestMeta_Validate(t *testing.T) {
 		t.Fatal("expected 4 errors, but there were", len(errs), ":", errs)
 	}
 }
+
+func TestMeta_SetFeed(t *testing.T) {
+	m := &Meta{}
+	if err := m.SetFeed("ip_prefixes", "f1"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m.IPPrefixes, FeedPtr{FeedID: "f1"}) {
+		t.Errorf("IPPrefixes = %v", m.IPPrefixes)
+	}
+	if errs := m.Validate(); len(errs) != 0 {
+		t.Errorf("a feed-bound list should validate, got %v", errs)
+	}
+	if refs := m.FeedRefs(); !reflect.DeepEqual(refs, map[string]string{"ip_prefixes": "f1"}) {
+		t.Errorf("FeedRefs = %v", refs)
+	}
+	if err := m.SetFeed("nope", "f1"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestMeta_GeoCoords(t *testing.T) {
+	m := &Meta{Latitude: 40.7128, Longitude: -74.006}
+	if lat, lon, ok := m.GeoCoords(); !ok || lat != 40.7128 || lon != -74.006 {
+		t.Fatal("expected 40.7128, -74.006, got", lat, lon, ok)
+	}
+	if errs := m.Validate(); len(errs) > 0 {
+		t.Fatal("there should be 0 errors, but there were", len(errs), ":", errs)
+	}
+
+	m.Longitude = FeedPtr{FeedID: "12345678"}
+	if _, _, ok := m.GeoCoords(); ok {
+		t.Fatal("a feed pointer is not a coordinate")
+	}
+
+	m = &Meta{Latitude: 91.0, Longitude: 179}
+	errs := m.Validate()
+	if len(errs) != 1 {
+		t.Fatal("expected 1 error, but there were", len(errs), ":", errs)
+	}
+	m = &Meta{Latitude: -90, Longitude: -181.5}
+	errs = m.Validate()
+	if len(errs) != 1 {
+		t.Fatal("expected 1 error, but there were", len(errs), ":", errs)
+	}
+}
@@ -0,0 +1,23 @@
+package data
+
+// SourceType wraps an element of the NS1 /data/sourcetypes resource, which
+// describes a kind of data source and the config its sources and feeds take.
+type SourceType struct {
+	Type string `json:"sourcetype"`
+	Name string `json:"name"`
+	Desc string `json:"desc,omitempty"`
+
+	// Config keys accepted by sources of this type.
+	Config []*ConfigField `json:"config,omitempty"`
+	// Config keys accepted by feeds of sources of this type.
+	FeedConfig []*ConfigField `json:"feed_config,omitempty"`
+}
+
+// ConfigField describes a key of a source or feed config.
+type ConfigField struct {
+	Name     string      `json:"name"`
+	Desc     string      `json:"desc,omitempty"`
+	Type     string      `json:"type"`
+	Required bool        `json:"required,omitempty"`
+	Default  interface{} `json:"default,omitempty"`
+}
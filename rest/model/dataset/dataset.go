@@ -0,0 +1,106 @@
+package dataset
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// DatatypeType is the metric a dataset reports.
+type DatatypeType string
+
+// Dataset metrics.
+const (
+	DatatypeTypeNumQueries       DatatypeType = "num_queries"
+	DatatypeTypeNumEBOTResponses DatatypeType = "num_ebot_response"
+	DatatypeTypeNumNXDResponses  DatatypeType = "num_nxd_response"
+	DatatypeTypeZeroQueries      DatatypeType = "zero_queries"
+)
+
+// DatatypeScope is what the dataset's metric is reported for.
+type DatatypeScope string
+
+// Dataset scopes.
+const (
+	DatatypeScopeAccount       DatatypeScope = "account"
+	DatatypeScopeZoneSingle    DatatypeScope = "zone_single"
+	DatatypeScopeZoneEach      DatatypeScope = "zone_each"
+	DatatypeScopeRecordSingle  DatatypeScope = "record_single"
+	DatatypeScopeRecordEach    DatatypeScope = "record_each"
+	DatatypeScopeNetworkSingle DatatypeScope = "network_single"
+	DatatypeScopeNetworkEach   DatatypeScope = "network_each"
+	DatatypeScopeTopNZones     DatatypeScope = "top_n_zones"
+	DatatypeScopeTopNRecords   DatatypeScope = "top_n_records"
+)
+
+// TimeframeAggregation is the period each row of a report covers.
+type TimeframeAggregation string
+
+// Timeframe aggregations.
+const (
+	TimeframeAggregationDaily         TimeframeAggregation = "daily"
+	TimeframeAggregationMonthly       TimeframeAggregation = "monthly"
+	TimeframeAggregationBillingPeriod TimeframeAggregation = "billing_period"
+)
+
+// RepeatsEvery is how often a repeating dataset generates a report.
+type RepeatsEvery string
+
+// Repeat intervals.
+const (
+	RepeatsEveryWeek  RepeatsEvery = "week"
+	RepeatsEveryMonth RepeatsEvery = "month"
+	RepeatsEveryYear  RepeatsEvery = "year"
+)
+
+// ExportType is the file format of a dataset's reports.
+type ExportType string
+
+// Report file formats.
+const (
+	ExportTypeCSV  ExportType = "csv"
+	ExportTypeJSON ExportType = "json"
+	ExportTypeXLSX ExportType = "xlsx"
+)
+
+// Dataset wraps an NS1 /datasets resource, the definition of a report and
+// the reports generated from it so far.
+type Dataset struct {
+	// Read-only fields
+	ID        string      `json:"id,omitempty"`
+	Reports   []*Report   `json:"reports,omitempty"`
+	CreatedAt *model.Time `json:"created_at,omitempty"`
+	UpdatedAt *model.Time `json:"updated_at,omitempty"`
+
+	Name            string     `json:"name"`
+	Datatype        *Datatype  `json:"datatype"`
+	Repeat          *Repeat    `json:"repeat,omitempty"`
+	Timeframe       *Timeframe `json:"timeframe"`
+	ExportType      ExportType `json:"export_type"`
+	RecipientEmails []string   `json:"recipient_emails,omitempty"`
+}
+
+// Datatype says what a dataset reports. Data holds the scope's parameters,
+// e.g. the "zone" of a DatatypeScopeZoneSingle dataset.
+type Datatype struct {
+	Type  DatatypeType      `json:"type"`
+	Scope DatatypeScope     `json:"scope"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// Repeat makes a dataset generate a report every RepeatsEvery from Start,
+// stopping after EndAfterN reports if it is set.
+type Repeat struct {
+	Start        model.Time   `json:"start"`
+	RepeatsEvery RepeatsEvery `json:"repeats_every"`
+	EndAfterN    int          `json:"end_after_n,omitempty"`
+}
+
+// Timeframe is the period a report covers: from From to To, or the last
+// Cycles periods of the aggregation.
+type Timeframe struct {
+	Aggregation TimeframeAggregation `json:"aggregation"`
+	Cycles      *int                 `json:"cycles,omitempty"`
+	From        *model.Time          `json:"from,omitempty"`
+	To          *model.Time          `json:"to,omitempty"`
+}
+
+func (d Dataset) String() string {
+	return d.Name
+}
@@ -0,0 +1,3 @@
+// Package dataset contains definitions for NS1 datasets, the reports of
+// query usage generated for download.
+package dataset
@@ -0,0 +1,29 @@
+package dataset
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// ReportStatus is the generation status of a report.
+type ReportStatus string
+
+// Report statuses.
+const (
+	ReportStatusQueued     ReportStatus = "queued"
+	ReportStatusGenerating ReportStatus = "generating"
+	ReportStatusAvailable  ReportStatus = "available"
+	ReportStatusFailed     ReportStatus = "failed"
+)
+
+// Report is a file generated from a Dataset, downloadable once available.
+type Report struct {
+	ID        string       `json:"id"`
+	Status    ReportStatus `json:"status"`
+	Start     model.Time   `json:"start,omitempty"`
+	End       model.Time   `json:"end,omitempty"`
+	CreatedAt model.Time   `json:"created_at,omitempty"`
+}
+
+// Done reports whether the report is no longer being generated, whether it
+// is available or failed.
+func (r *Report) Done() bool {
+	return r.Status == ReportStatusAvailable || r.Status == ReportStatusFailed
+}
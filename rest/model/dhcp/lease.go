@@ -0,0 +1,20 @@
+package dhcp
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// Lease is an address handed out by a DHCP server of a scope group.
+type Lease struct {
+	Address      string `json:"address"`
+	Mac          string `json:"mac,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	Hostname     string `json:"hostname,omitempty"`
+	State        string `json:"state,omitempty"`
+	IDScope      *int   `json:"scope_id,omitempty"`
+	IDScopeGroup *int   `json:"scope_group_id,omitempty"`
+	DHCPv6       bool   `json:"dhcpv6,omitempty"`
+
+	// ValidLifetimeSecs is how long the lease is valid from Start.
+	ValidLifetimeSecs int        `json:"valid_lifetime_secs,omitempty"`
+	Start             model.Time `json:"start,omitempty"`
+	Expires           model.Time `json:"expires,omitempty"`
+}
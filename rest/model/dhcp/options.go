@@ -0,0 +1,189 @@
+package dhcp
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strings"
+)
+
+// Well-known option names.
+const (
+	OptionRouters           = "dhcpv4/routers"
+	OptionDomainNameServers = "dhcpv4/domain-name-servers"
+	OptionDomainName        = "dhcpv4/domain-name"
+	OptionBootFileName      = "dhcpv4/boot-file-name"
+	OptionDNSServersV6      = "dhcpv6/dns-servers"
+	OptionDomainSearchV6    = "dhcpv6/domain-search"
+)
+
+// NewOption returns an option with the given name and value.
+func NewOption(name string, value interface{}) Option {
+	return Option{Name: name, Value: value}
+}
+
+// RoutersOption returns the dhcpv4/routers option for the given IPv4
+// addresses.
+func RoutersOption(ips ...string) (Option, error) {
+	return addressesOption(OptionRouters, ips, false)
+}
+
+// DomainNameServersOption returns the dhcpv4/domain-name-servers option for
+// the given IPv4 addresses.
+func DomainNameServersOption(ips ...string) (Option, error) {
+	return addressesOption(OptionDomainNameServers, ips, false)
+}
+
+// DNSServersV6Option returns the dhcpv6/dns-servers option for the given
+// IPv6 addresses.
+func DNSServersV6Option(ips ...string) (Option, error) {
+	return addressesOption(OptionDNSServersV6, ips, true)
+}
+
+// DomainNameOption returns the dhcpv4/domain-name option.
+func DomainNameOption(domain string) Option {
+	return NewOption(OptionDomainName, domain)
+}
+
+// BootFileNameOption returns the dhcpv4/boot-file-name option.
+func BootFileNameOption(path string) Option {
+	return NewOption(OptionBootFileName, path)
+}
+
+func addressesOption(name string, ips []string, v6 bool) (Option, error) {
+	if len(ips) == 0 {
+		return Option{}, fmt.Errorf("%s: at least one address is required", name)
+	}
+	t := SchemaType(SchemaTypeIPv4Address)
+	if v6 {
+		t = SchemaTypeIPv6Address
+	}
+	for _, ip := range ips {
+		if err := checkValue(t, ip); err != nil {
+			return Option{}, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return NewOption(name, ips), nil
+}
+
+// ValidateValue checks an option value against the schema of its option
+// definition, as the API would: arrays must hold values of the Items type,
+// and records one value per field, in order. Values may be of the types the
+// JSON decoder produces, or Go strings, bools and integers.
+func (s OptionDefSchema) ValidateValue(value interface{}) error {
+	switch s.Type {
+	case SchemaTypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			if ss, isStrings := value.([]string); isStrings {
+				items = make([]interface{}, len(ss))
+				for i, v := range ss {
+					items[i] = v
+				}
+				ok = true
+			}
+		}
+		if !ok {
+			return fmt.Errorf("value must be a list, got %T", value)
+		}
+		if s.Items == nil {
+			return nil
+		}
+		for i, v := range items {
+			if err := checkValue(SchemaType(*s.Items), v); err != nil {
+				return fmt.Errorf("item %d: %v", i, err)
+			}
+		}
+		return nil
+	case SchemaTypeRecord:
+		fields, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("value must be a list of the record's fields, got %T", value)
+		}
+		if len(fields) != len(s.Fields) {
+			return fmt.Errorf("record has %d fields, got %d values", len(s.Fields), len(fields))
+		}
+		for i, f := range s.Fields {
+			if err := checkValue(SchemaType(f.Type), fields[i]); err != nil {
+				return fmt.Errorf("field %s: %v", f.Name, err)
+			}
+		}
+		return nil
+	}
+	return checkValue(s.Type, value)
+}
+
+// checkValue checks a scalar value against its schema type. Unknown types
+// are not checked.
+func checkValue(t SchemaType, v interface{}) error {
+	switch t {
+	case SchemaTypeEmpty:
+		if v != nil {
+			return fmt.Errorf("value must be empty, got %v", v)
+		}
+	case SchemaTypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("value must be a boolean, got %T", v)
+		}
+	case SchemaTypeString, SchemaTypeBinary, SchemaTypeTuple, SchemaTypePSID:
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("value must be a string, got %T", v)
+		}
+	case SchemaTypeFQDN:
+		s, ok := v.(string)
+		if !ok || s == "" || strings.Contains(s, "..") {
+			return fmt.Errorf("value must be a domain name, got %v", v)
+		}
+	case SchemaTypeIPv4Address:
+		s, _ := v.(string)
+		if ip := net.ParseIP(s); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("value must be an IPv4 address, got %v", v)
+		}
+	case SchemaTypeIPv6Address:
+		s, _ := v.(string)
+		if ip := net.ParseIP(s); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("value must be an IPv6 address, got %v", v)
+		}
+	case SchemaTypeIPv6Prefix:
+		s, _ := v.(string)
+		if ip, _, err := net.ParseCIDR(s); err != nil || ip.To4() != nil {
+			return fmt.Errorf("value must be an IPv6 prefix, got %v", v)
+		}
+	case SchemaTypeInt8:
+		return checkInt(v, math.MinInt8, math.MaxInt8)
+	case SchemaTypeInt16:
+		return checkInt(v, math.MinInt16, math.MaxInt16)
+	case SchemaTypeInt32:
+		return checkInt(v, math.MinInt32, math.MaxInt32)
+	case SchemaTypeUint8:
+		return checkInt(v, 0, math.MaxUint8)
+	case SchemaTypeUint16:
+		return checkInt(v, 0, math.MaxUint16)
+	case SchemaTypeUint32:
+		return checkInt(v, 0, math.MaxUint32)
+	}
+	return nil
+}
+
+func checkInt(v interface{}, min, max float64) error {
+	var f float64
+	switch n := v.(type) {
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	case uint32:
+		f = float64(n)
+	case float64:
+		if n != math.Trunc(n) {
+			return fmt.Errorf("value must be an integer, got %v", n)
+		}
+		f = n
+	default:
+		return fmt.Errorf("value must be an integer, got %T", v)
+	}
+	if f < min || f > max {
+		return fmt.Errorf("value %v is out of range [%v, %v]", v, min, max)
+	}
+	return nil
+}
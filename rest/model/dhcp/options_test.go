@@ -0,0 +1,47 @@
+package dhcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionHelpers(t *testing.T) {
+	o, err := RoutersOption("10.0.0.1", "10.0.0.2")
+	require.NoError(t, err)
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "dhcpv4/routers", "value": ["10.0.0.1", "10.0.0.2"]}`, string(b))
+
+	_, err = RoutersOption("2001:db8::1")
+	assert.Error(t, err)
+	_, err = DNSServersV6Option("10.0.0.1")
+	assert.Error(t, err)
+	_, err = DomainNameServersOption()
+	assert.Error(t, err)
+
+	assert.Equal(t, Option{Name: OptionDomainName, Value: "example.com"}, DomainNameOption("example.com"))
+}
+
+func TestOptionDefSchema_ValidateValue(t *testing.T) {
+	items := "uint16"
+	list := OptionDefSchema{Type: SchemaTypeArray, Items: &items}
+	assert.NoError(t, list.ValidateValue([]interface{}{float64(1), 80}))
+	assert.Error(t, list.ValidateValue([]interface{}{float64(70000)}))
+	assert.Error(t, list.ValidateValue("80"))
+
+	record := OptionDefSchema{Type: SchemaTypeRecord, Fields: []OptionDefSchemaItems{
+		{Name: "server", Type: ItemTypeIPv4Address},
+		{Name: "enabled", Type: ItemTypeBoolean},
+	}}
+	assert.NoError(t, record.ValidateValue([]interface{}{"10.0.0.1", true}))
+	assert.Error(t, record.ValidateValue([]interface{}{"10.0.0.1"}))
+	assert.Error(t, record.ValidateValue([]interface{}{"10.0.0.1", "yes"}))
+
+	assert.NoError(t, OptionDefSchema{Type: SchemaTypeInt8}.ValidateValue(-5))
+	assert.Error(t, OptionDefSchema{Type: SchemaTypeUint8}.ValidateValue(-5))
+	assert.Error(t, OptionDefSchema{Type: SchemaTypeUint32}.ValidateValue(1.5))
+	assert.NoError(t, OptionDefSchema{Type: SchemaTypeIPv6Prefix}.ValidateValue("2001:db8::/64"))
+}
@@ -0,0 +1,19 @@
+package dns
+
+// ACL wraps an NS1 /acls/{aclname} resource (DDI only), a named list of the
+// clients a View's read or update ACLs match.
+type ACL struct {
+	Name        string `json:"acl_name"`
+	Description string `json:"description,omitempty"`
+
+	// Src lists the IP addresses and CIDR prefixes of matched clients.
+	Src []string `json:"src"`
+}
+
+// NewACL returns an ACL matching the given sources.
+func NewACL(name string, src ...string) *ACL {
+	if src == nil {
+		src = []string{}
+	}
+	return &ACL{Name: name, Src: src}
+}
@@ -72,6 +72,31 @@ func (a *Answer) SetRegion(name string) {
 	a.RegionName = name
 }
 
+// ErrAnswerTTL is returned by Answer.SetTTL: NS1 has no per-answer or
+// per-region TTL, every answer is served with its record's TTL.
+var ErrAnswerTTL = errors.New("NS1 does not support per-answer TTLs, set the record's TTL instead")
+
+// SetTTL always fails with ErrAnswerTTL, as NS1 only supports TTLs at the
+// record level. For faster failover, lower the record's TTL ahead of time
+// (see RecordsService.LowerTTLAndWait), or serve the answers that need a
+// shorter TTL from a separate record.
+func (a *Answer) SetTTL(ttl int) error {
+	return ErrAnswerTTL
+}
+
+// WithGeoCoords sets the answer's latitude and longitude metadata, used by
+// the geotarget_latlong filter to steer to the nearest answer, and returns
+// the answer. Latitude must be in -90..90 and longitude in -180..180, which
+// Record.Validate checks.
+func (a *Answer) WithGeoCoords(lat, lon float64) *Answer {
+	if a.Meta == nil {
+		a.Meta = &data.Meta{}
+	}
+	a.Meta.Latitude = lat
+	a.Meta.Longitude = lon
+	return a
+}
+
 // NewAnswer creates a generic Answer with given rdata.
 func NewAnswer(rdata []string) *Answer {
 	return &Answer{
@@ -112,6 +137,23 @@ func NewCNAMEAnswer(name string) *Answer {
 	}
 }
 
+// NewPTRAnswer creates an Answer for PTR record.
+func NewPTRAnswer(host string) *Answer {
+	return &Answer{
+		Meta:  &data.Meta{},
+		Rdata: []string{host},
+	}
+}
+
+// NewDNAMEAnswer creates an Answer for DNAME record. DNAME is commonly used
+// to delegate a reverse zone (or part of one) to another zone.
+func NewDNAMEAnswer(target string) *Answer {
+	return &Answer{
+		Meta:  &data.Meta{},
+		Rdata: []string{target},
+	}
+}
+
 // NewTXTAnswer creates an Answer for TXT record.
 func NewTXTAnswer(text string) *Answer {
 	return &Answer{
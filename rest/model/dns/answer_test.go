@@ -100,3 +100,32 @@ func TestPrepareURLFWDAnswer(t *testing.T) {
 		})
 	}
 }
+
+func TestAnswer_WithGeoCoords(t *testing.T) {
+	a := NewAv4Answer("1.2.3.4").WithGeoCoords(37.774929, -122.419416)
+
+	b, err := json.Marshal(a)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"answer":["1.2.3.4"],"meta":{"latitude":37.774929,"longitude":-122.419416}}`, string(b))
+
+	var decoded Answer
+	assert.Nil(t, json.Unmarshal(b, &decoded))
+	lat, lon, ok := decoded.Meta.GeoCoords()
+	assert.True(t, ok)
+	assert.Equal(t, 37.774929, lat)
+	assert.Equal(t, -122.419416, lon)
+
+	r := NewRecord("example.com", "www.example.com", "A")
+	r.AddAnswer(a)
+	assert.Empty(t, r.Validate())
+	r.AddAnswer(NewAv4Answer("1.2.3.5").WithGeoCoords(95, 0))
+	assert.Len(t, r.Validate(), 1)
+
+	assert.NotNil(t, (&Answer{}).WithGeoCoords(0, 0).Meta)
+}
+
+func TestAnswer_SetTTL(t *testing.T) {
+	a := NewAv4Answer("1.2.3.4")
+	assert.Equal(t, ErrAnswerTTL, a.SetTTL(30))
+	assert.Equal(t, NewAv4Answer("1.2.3.4"), a)
+}
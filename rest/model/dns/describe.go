@@ -0,0 +1,180 @@
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// Describe renders the record as a multi-line, human-readable summary: its
+// metadata, filter chain in order, regions and answers, with feed bindings
+// shown as feed(<id>). The output only depends on the record's contents
+// (metadata keys and regions are sorted), so it can be used in tests and
+// diffs.
+func (r *Record) Describe() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s", r.Domain, r.Type)
+	var attrs []string
+	if r.Zone != "" {
+		attrs = append(attrs, "zone "+r.Zone)
+	}
+	if r.TTL != 0 {
+		attrs = append(attrs, fmt.Sprintf("ttl %d", r.TTL))
+	}
+	if r.Link != "" {
+		attrs = append(attrs, "link to "+r.Link)
+	}
+	if len(attrs) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(attrs, ", "))
+	}
+	b.WriteString("\n")
+
+	if meta := describeMeta(r.Meta); meta != "" {
+		fmt.Fprintf(&b, "  meta: %s\n", meta)
+	}
+
+	if len(r.Filters) > 0 {
+		b.WriteString("  filters:\n")
+		for i, f := range r.Filters {
+			fmt.Fprintf(&b, "    %d. %s", i+1, f.Type)
+			if len(f.Config) > 0 {
+				config, _ := json.Marshal(f.Config)
+				fmt.Fprintf(&b, " %s", config)
+			}
+			if f.Disabled {
+				b.WriteString(" (disabled)")
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.Regions) > 0 {
+		b.WriteString("  regions:\n")
+		names := make([]string, 0, len(r.Regions))
+		for name := range r.Regions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			region := r.Regions[name]
+			fmt.Fprintf(&b, "    %s", name)
+			if meta := describeMeta(&region.Meta); meta != "" {
+				fmt.Fprintf(&b, ": %s", meta)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(r.Answers) > 0 {
+		b.WriteString("  answers:\n")
+		for _, a := range r.Answers {
+			fmt.Fprintf(&b, "    %s", strings.Join(a.Rdata, " "))
+			if a.RegionName != "" {
+				fmt.Fprintf(&b, " [region %s]", a.RegionName)
+			}
+			if meta := describeMeta(a.Meta); meta != "" {
+				fmt.Fprintf(&b, " %s", meta)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// Describe renders the zone as a multi-line, human-readable summary: its SOA
+// settings, primary/secondary configuration and record summaries, in the
+// same stable style as Record.Describe.
+func (z *Zone) Describe() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "zone %s", z.Zone)
+	if z.Link != nil {
+		fmt.Fprintf(&b, " (link to %s)", *z.Link)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  soa: ttl %d, nx_ttl %d, refresh %d, retry %d, expiry %d, serial %d\n",
+		z.TTL, z.NxTTL, z.Refresh, z.Retry, z.Expiry, z.Serial)
+	if z.DNSSEC != nil && *z.DNSSEC {
+		b.WriteString("  dnssec: enabled\n")
+	}
+
+	if z.Primary != nil && z.Primary.Enabled {
+		b.WriteString("  primary: enabled\n")
+		for _, s := range z.Primary.Secondaries {
+			fmt.Fprintf(&b, "    secondary %s", s.IP)
+			if s.Port != 0 {
+				fmt.Fprintf(&b, ":%d", s.Port)
+			}
+			if s.Notify {
+				b.WriteString(" (notify)")
+			}
+			b.WriteString("\n")
+		}
+	}
+	if z.Secondary != nil && z.Secondary.Enabled {
+		fmt.Fprintf(&b, "  secondary of %s", z.Secondary.PrimaryIP)
+		if z.Secondary.PrimaryPort != 0 {
+			fmt.Fprintf(&b, ":%d", z.Secondary.PrimaryPort)
+		}
+		if z.Secondary.Status != "" {
+			fmt.Fprintf(&b, " (%s)", z.Secondary.Status)
+		}
+		b.WriteString("\n")
+	}
+
+	if meta := describeMeta(z.Meta); meta != "" {
+		fmt.Fprintf(&b, "  meta: %s\n", meta)
+	}
+
+	if len(z.Records) > 0 {
+		b.WriteString("  records:\n")
+		for _, zr := range z.Records {
+			fmt.Fprintf(&b, "    %s %s", zr.Domain, zr.Type)
+			if len(zr.ShortAns) > 0 {
+				fmt.Fprintf(&b, " %s", strings.Join(zr.ShortAns, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// describeMeta renders the set metadata fields as key=value pairs, sorted by
+// key, showing feed bindings as feed(<id>).
+func describeMeta(meta *data.Meta) string {
+	if meta == nil {
+		return ""
+	}
+	feeds := meta.FeedRefs()
+
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return ""
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		if id, ok := feeds[k]; ok {
+			pairs[i] = fmt.Sprintf("%s=feed(%s)", k, id)
+		} else {
+			pairs[i] = fmt.Sprintf("%s=%s", k, fields[k])
+		}
+	}
+	return strings.Join(pairs, " ")
+}
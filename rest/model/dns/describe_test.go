@@ -0,0 +1,63 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+func TestRecordDescribe(t *testing.T) {
+	r := NewRecord("example.com", "www.example.com", "A")
+	r.TTL = 300
+	r.Meta.Up = true
+	r.Regions = data.Regions{
+		"us-west": {Meta: data.Meta{Georegion: []string{"US-WEST"}}},
+		"us-east": {Meta: data.Meta{Georegion: []string{"US-EAST"}}},
+	}
+	primary := NewAv4Answer("1.1.1.1")
+	primary.SetRegion("us-east")
+	primary.Meta.Up = data.FeedPtr{FeedID: "f1"}
+	primary.Meta.Priority = 1
+	r.AddAnswer(primary)
+	r.AddAnswer(NewAv4Answer("2.2.2.2"))
+	r.AddFilter(filter.NewUp())
+	r.AddFilter(filter.NewSelFirstN(1))
+	shuffle := filter.NewShuffle()
+	shuffle.Disable()
+	r.AddFilter(shuffle)
+
+	expected := `www.example.com A (zone example.com, ttl 300)
+  meta: up=true
+  filters:
+    1. up
+    2. select_first_n {"N":1}
+    3. shuffle (disabled)
+  regions:
+    us-east: georegion=["US-EAST"]
+    us-west: georegion=["US-WEST"]
+  answers:
+    1.1.1.1 [region us-east] priority=1 up=feed(f1)
+    2.2.2.2
+`
+	assert.Equal(t, expected, r.Describe())
+	assert.Equal(t, r.Describe(), r.Describe())
+}
+
+func TestZoneDescribe(t *testing.T) {
+	z := NewZone("example.com")
+	z.TTL, z.NxTTL, z.Refresh, z.Retry, z.Expiry, z.Serial = 3600, 60, 43200, 7200, 1209600, 42
+	z.MakePrimary(ZoneSecondaryServer{IP: "192.0.2.1", Port: 53, Notify: true})
+	z.Records = []*ZoneRecord{{Domain: "www.example.com", Type: "A", ShortAns: []string{"1.1.1.1", "2.2.2.2"}}}
+
+	expected := `zone example.com
+  soa: ttl 3600, nx_ttl 60, refresh 43200, retry 7200, expiry 1209600, serial 42
+  primary: enabled
+    secondary 192.0.2.1:53 (notify)
+  records:
+    www.example.com A 1.1.1.1, 2.2.2.2
+`
+	assert.Equal(t, expected, z.Describe())
+}
@@ -0,0 +1,307 @@
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldDiff describes a top-level field that differs between two versions of
+// a resource. From and To hold the JSON form of the field's values, nil
+// meaning unset or empty.
+type FieldDiff struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Field, diffValue(d.From), diffValue(d.To))
+}
+
+func diffValue(v interface{}) string {
+	if v == nil {
+		return "(unset)"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// Fields set by NS1 rather than by the user, cleared by StripServerManaged.
+// Dotted paths apply to nested objects, and to each element of nested
+// lists.
+var (
+	recordServerManagedFields = []string{"id", "local_tags", "answers.id"}
+	zoneServerManagedFields   = []string{
+		"id", "dns_servers", "network_pools", "pool", "local_tags",
+		"serial", "records", "secondary.expired", "secondary.last_xfr",
+		"secondary.status", "secondary.error",
+	}
+)
+
+// Server-managed and identifying fields that are not compared.
+var (
+	recordIgnoredFields = append([]string{"zone", "domain", "type"}, recordServerManagedFields...)
+	zoneIgnoredFields   = append([]string{"zone"}, zoneServerManagedFields...)
+)
+
+// DiffRecords compares two versions of a record and returns the fields that
+// differ, sorted by field name. Server-managed fields (ids, local tags) and
+// the record's identity (zone, domain, type) are ignored, and unset fields
+// compare equal to empty ones. Answer order is significant.
+//
+// When both versions share answers, answer changes are reported per answer,
+// keyed by rdata: "answers[1.2.3.4].meta.weight: 50 -> 80" for a changed
+// field of a kept answer, "answers[5.6.7.8]" for an added or removed one,
+// and "answers.order" if kept answers were reordered. Otherwise (or if rdata
+// is repeated) the answers are reported as a whole.
+func DiffRecords(from, to *Record) []FieldDiff {
+	// Alias avoids the URLFWD specific marshalling, so answers compare as
+	// strings on both sides.
+	type Alias Record
+	diffs := diffFields((*Alias)(from), (*Alias)(to), recordIgnoredFields)
+	for i, d := range diffs {
+		if d.Field != "answers" {
+			continue
+		}
+		if answerDiffs, ok := diffAnswers(d.From, d.To); ok {
+			diffs = append(diffs[:i], append(answerDiffs, diffs[i+1:]...)...)
+			sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+		}
+		break
+	}
+	return diffs
+}
+
+// diffAnswers breaks a difference of the normalized answers lists down to
+// the answers and answer fields that differ. It returns false if the lists
+// have no answer in common, or repeat an rdata.
+func diffAnswers(from, to interface{}) ([]FieldDiff, bool) {
+	fromKeys, fromAnswers, ok := answersByRdata(from)
+	if !ok {
+		return nil, false
+	}
+	toKeys, toAnswers, ok := answersByRdata(to)
+	if !ok {
+		return nil, false
+	}
+
+	var fromKept, toKept []string
+	for _, k := range fromKeys {
+		if _, ok := toAnswers[k]; ok {
+			fromKept = append(fromKept, k)
+		}
+	}
+	if len(fromKept) == 0 {
+		return nil, false
+	}
+	for _, k := range toKeys {
+		if _, ok := fromAnswers[k]; ok {
+			toKept = append(toKept, k)
+		}
+	}
+
+	var diffs []FieldDiff
+	if !reflect.DeepEqual(fromKept, toKept) {
+		diffs = append(diffs, FieldDiff{Field: "answers.order", From: fromKept, To: toKept})
+	}
+	for _, k := range fromKeys {
+		if _, ok := toAnswers[k]; !ok {
+			diffs = append(diffs, FieldDiff{Field: "answers[" + k + "]", From: fromAnswers[k]})
+		}
+	}
+	for _, k := range toKeys {
+		fa, ok := fromAnswers[k]
+		if !ok {
+			diffs = append(diffs, FieldDiff{Field: "answers[" + k + "]", To: toAnswers[k]})
+			continue
+		}
+		for _, d := range diffFlattened(flatten(fa, ""), flatten(toAnswers[k], "")) {
+			d.Field = "answers[" + k + "]." + d.Field
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs, true
+}
+
+// answersByRdata indexes a normalized answers list by rdata, joined by
+// spaces, without the rdata itself. It returns false for anything but a list
+// of answers with distinct rdata.
+func answersByRdata(v interface{}) ([]string, map[string]map[string]interface{}, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, nil, false
+	}
+	keys := make([]string, 0, len(list))
+	answers := make(map[string]map[string]interface{}, len(list))
+	for _, e := range list {
+		a, ok := e.(map[string]interface{})
+		if !ok {
+			return nil, nil, false
+		}
+		rdata, _ := a["answer"].([]interface{})
+		parts := make([]string, len(rdata))
+		for i, p := range rdata {
+			parts[i] = fmt.Sprint(p)
+		}
+		k := strings.Join(parts, " ")
+		if _, dup := answers[k]; dup {
+			return nil, nil, false
+		}
+
+		rest := make(map[string]interface{}, len(a))
+		for f, fv := range a {
+			if f != "answer" {
+				rest[f] = fv
+			}
+		}
+		keys = append(keys, k)
+		answers[k] = rest
+	}
+	return keys, answers, true
+}
+
+// flatten maps the leaves of nested objects to their dotted paths.
+func flatten(m map[string]interface{}, prefix string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			for nk, nv := range flatten(nested, prefix+k+".") {
+				out[nk] = nv
+			}
+		} else {
+			out[prefix+k] = v
+		}
+	}
+	return out
+}
+
+// diffFlattened compares two flattened objects, sorted by path.
+func diffFlattened(a, b map[string]interface{}) []FieldDiff {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var diffs []FieldDiff
+	for k := range keys {
+		if !reflect.DeepEqual(a[k], b[k]) {
+			diffs = append(diffs, FieldDiff{Field: k, From: a[k], To: b[k]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// RecordsEqual reports whether two records have the same configuration, as
+// defined by DiffRecords.
+func RecordsEqual(a, b *Record) bool {
+	return len(DiffRecords(a, b)) == 0
+}
+
+// DiffZones compares two versions of a zone's configuration and returns the
+// fields that differ, sorted by field name. Server-managed fields (ids,
+// serial, dns servers, records summary, transfer status) are ignored.
+func DiffZones(from, to *Zone) []FieldDiff {
+	return diffFields(from, to, zoneIgnoredFields)
+}
+
+func diffFields(from, to interface{}, ignore []string) []FieldDiff {
+	a, b := normalizedFields(from, ignore), normalizedFields(to, ignore)
+
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	var diffs []FieldDiff
+	for k := range keys {
+		if !reflect.DeepEqual(a[k], b[k]) {
+			diffs = append(diffs, FieldDiff{Field: k, From: a[k], To: b[k]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// normalizedFields returns the JSON form of v as a map with the ignored
+// fields and all empty values removed.
+func normalizedFields(v interface{}, ignore []string) map[string]interface{} {
+	m := map[string]interface{}{}
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return m
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return m
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return m
+	}
+
+	for _, path := range ignore {
+		deletePath(m, strings.Split(path, "."))
+	}
+	for k, val := range m {
+		if val = pruneEmpty(val); val == nil {
+			delete(m, k)
+		} else {
+			m[k] = val
+		}
+	}
+	return m
+}
+
+func deletePath(v interface{}, path []string) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(t, path[0])
+			return
+		}
+		deletePath(t[path[0]], path[1:])
+	case []interface{}:
+		for _, e := range t {
+			deletePath(e, path)
+		}
+	}
+}
+
+// pruneEmpty recursively drops nulls, empty lists and empty objects, and
+// returns nil if nothing is left.
+func pruneEmpty(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if e = pruneEmpty(e); e == nil {
+				delete(t, k)
+			} else {
+				t[k] = e
+			}
+		}
+		if len(t) == 0 {
+			return nil
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			return nil
+		}
+		for i, e := range t {
+			// Keep list positions; an emptied element becomes null.
+			t[i] = pruneEmpty(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+func TestDiffRecords(t *testing.T) {
+	a := NewRecord("example.com", "www", "A")
+	a.AddAnswer(NewAv4Answer("1.2.3.4"))
+	a.TTL = 300
+
+	// Server-managed fields and nil vs empty values are not differences.
+	b := &Record{
+		ID:        "5e3d91f01c56932156905bf7",
+		Zone:      "example.com",
+		Domain:    "www.example.com",
+		Type:      "A",
+		TTL:       300,
+		Answers:   []*Answer{{ID: "abc", Rdata: []string{"1.2.3.4"}}},
+		LocalTags: []string{"x"},
+	}
+	assert.Empty(t, DiffRecords(a, b))
+	assert.True(t, RecordsEqual(a, b))
+
+	b.TTL = 60
+	b.AddFilter(filter.NewShuffle())
+	b.Answers[0].Meta = nil
+	diffs := DiffRecords(a, b)
+	assert.Len(t, diffs, 2)
+	assert.Equal(t, "filters", diffs[0].Field)
+	assert.Nil(t, diffs[0].From)
+	assert.Equal(t, "ttl", diffs[1].Field)
+	assert.Equal(t, "ttl: 300 -> 60", diffs[1].String())
+
+	// Answer order is significant.
+	a.AddAnswer(NewAv4Answer("5.6.7.8"))
+	c := NewRecord("example.com", "www", "A")
+	c.TTL = 300
+	c.AddAnswer(NewAv4Answer("5.6.7.8"))
+	c.AddAnswer(NewAv4Answer("1.2.3.4"))
+	assert.False(t, RecordsEqual(a, c))
+
+	assert.Len(t, DiffRecords(nil, c), 2)
+}
+
+func TestDiffRecordsAnswers(t *testing.T) {
+	a := NewRecord("example.com", "www", "A")
+	a.AddAnswer(NewAv4Answer("1.2.3.4"))
+	a.AddAnswer(NewAv4Answer("5.6.7.8"))
+	a.Answers[0].Meta.Weight = 50
+	a.Answers[0].Meta.Note = "blue"
+
+	b := NewRecord("example.com", "www", "A")
+	b.AddAnswer(NewAv4Answer("9.9.9.9"))
+	b.AddAnswer(NewAv4Answer("1.2.3.4"))
+	b.Answers[1].Meta.Weight = 80
+	b.Answers[1].Meta.Note = "green"
+
+	diffs := DiffRecords(a, b)
+	fields := make([]string, len(diffs))
+	for i, d := range diffs {
+		fields[i] = d.String()
+	}
+	assert.Equal(t, []string{
+		`answers[1.2.3.4].meta.note: "blue" -> "green"`,
+		`answers[1.2.3.4].meta.weight: 50 -> 80`,
+		`answers[5.6.7.8]: {} -> (unset)`,
+		`answers[9.9.9.9]: (unset) -> {}`,
+	}, fields)
+
+	// Reordering kept answers is reported on its own.
+	b.Answers = append([]*Answer{NewAv4Answer("5.6.7.8")}, b.Answers...)
+	diffs = DiffRecords(a, b)
+	assert.Len(t, diffs, 4)
+	assert.Equal(t, "answers.order", diffs[0].Field)
+	assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, diffs[0].From)
+	assert.Equal(t, []string{"5.6.7.8", "1.2.3.4"}, diffs[0].To)
+
+	// Without answers in common, the answers are reported as a whole.
+	c := NewRecord("example.com", "www", "A")
+	c.AddAnswer(NewAv4Answer("10.0.0.1"))
+	diffs = DiffRecords(a, c)
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, "answers", diffs[0].Field)
+}
+
+func TestDiffZones(t *testing.T) {
+	a := NewZone("example.com")
+	a.TTL = 3600
+	a.Refresh = 43200
+
+	b := NewZone("example.com")
+	b.ID = "zone-id"
+	b.Serial = 1234
+	b.DNSServers = []string{"dns1.p01.nsone.net"}
+	b.TTL = 3600
+	b.Refresh = 7200
+
+	diffs := DiffZones(a, b)
+	assert.Len(t, diffs, 1)
+	assert.Equal(t, FieldDiff{Field: "refresh", From: 43200.0, To: 7200.0}, diffs[0])
+}
@@ -3,6 +3,7 @@ package dns
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ZoneDNSSEC wraps an NS1 /zone/{zone}/dnssec resource
@@ -33,6 +34,31 @@ type Key struct {
 	PublicKey string
 }
 
+// String returns the key in presentation format, the RDATA of its DNSKEY or
+// DS record.
+func (k *Key) String() string {
+	return strings.Join([]string{k.Flags, k.Protocol, k.Algorithm, k.PublicKey}, " ")
+}
+
+// DS is a delegation signer record, as published at the registrar.
+type DS struct {
+	KeyTag     string
+	Algorithm  string
+	DigestType string
+	Digest     string
+}
+
+// DSRecords returns the delegation's DS records. NS1 lists them in DS as
+// Keys whose fields are, in order, the key tag, algorithm, digest type and
+// digest.
+func (d *Delegation) DSRecords() []DS {
+	ds := make([]DS, 0, len(d.DS))
+	for _, k := range d.DS {
+		ds = append(ds, DS{KeyTag: k.Flags, Algorithm: k.Protocol, DigestType: k.Algorithm, Digest: k.PublicKey})
+	}
+	return ds
+}
+
 func (d ZoneDNSSEC) String() string {
 	return fmt.Sprintf("%s", d.Zone)
 }
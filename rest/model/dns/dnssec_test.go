@@ -83,4 +83,10 @@ func TestUnmarshalDNSSEC(t *testing.T) {
 	assert.Equal(t, "13", k.Protocol)
 	assert.Equal(t, "2", k.Algorithm)
 	assert.Equal(t, "150ae338f365a05e53cb781aedd1b54bf5f27f6a837441292ccf03ca26ad0fb3", k.PublicKey)
+
+	assert.Equal(t, []DS{{
+		KeyTag: "48553", Algorithm: "13", DigestType: "2",
+		Digest: "150ae338f365a05e53cb781aedd1b54bf5f27f6a837441292ccf03ca26ad0fb3",
+	}}, delegation.DSRecords())
+	assert.Equal(t, "48553 13 2 150ae338f365a05e53cb781aedd1b54bf5f27f6a837441292ccf03ca26ad0fb3", k.String())
 }
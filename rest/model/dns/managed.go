@@ -0,0 +1,36 @@
+package dns
+
+// StripServerManaged clears the fields of the record that NS1 manages: its
+// id, local tags and answer ids. Records read back from the API can then be
+// compared with desired ones, or written elsewhere, without these fields
+// getting in the way. DiffRecords ignores them already.
+func (r *Record) StripServerManaged() {
+	r.ID = ""
+	r.LocalTags = nil
+	for _, a := range r.Answers {
+		if a != nil {
+			a.ID = ""
+		}
+	}
+}
+
+// StripServerManaged clears the fields of the zone that NS1 manages: its id,
+// assigned DNS servers and pools, local tags, serial, records summary and
+// the secondary zone transfer status. User config such as the TSIG key and
+// the networks of the primary's secondaries is kept. DiffZones ignores the
+// cleared fields already.
+func (z *Zone) StripServerManaged() {
+	z.ID = ""
+	z.DNSServers = nil
+	z.NetworkPools = nil
+	z.Pool = ""
+	z.LocalTags = nil
+	z.Serial = 0
+	z.Records = nil
+	if s := z.Secondary; s != nil {
+		s.Expired = false
+		s.LastXfr = nil
+		s.Status = ""
+		s.Error = nil
+	}
+}
@@ -0,0 +1,83 @@
+package dns
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hasPath reports whether the dotted path is set to a non-zero value in the
+// normalized JSON v, in any element of nested lists. Timestamps encode their
+// zero value as 0, so a zero value counts as unset.
+func hasPath(v interface{}, path []string) bool {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		e, ok := t[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) > 1 {
+			return hasPath(e, path[1:])
+		}
+		return e != nil && e != float64(0) && e != "" && e != false
+	case []interface{}:
+		for _, e := range t {
+			if hasPath(e, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestStripServerManaged(t *testing.T) {
+	t.Run("Record", func(t *testing.T) {
+		var r Record
+		require.Nil(t, json.Unmarshal([]byte(`{"id":"r1","zone":"example.com","domain":"www.example.com","type":"A",
+			"ttl":300,"local_tags":["t"],"answers":[{"id":"a1","answer":["1.2.3.4"]}]}`), &r))
+		before := normalizedFields(&r, nil)
+		for _, f := range recordServerManagedFields {
+			assert.True(t, hasPath(before, strings.Split(f, ".")), f)
+		}
+		orig := r
+		orig.Answers = []*Answer{{ID: "a1", Rdata: []string{"1.2.3.4"}}}
+
+		r.StripServerManaged()
+		after := normalizedFields(&r, nil)
+		for _, f := range recordServerManagedFields {
+			assert.False(t, hasPath(after, strings.Split(f, ".")), f)
+		}
+		assert.Equal(t, 300, r.TTL)
+		assert.Equal(t, "www.example.com", r.Domain)
+		assert.Empty(t, DiffRecords(&orig, &r))
+	})
+
+	t.Run("Zone", func(t *testing.T) {
+		var z Zone
+		require.Nil(t, json.Unmarshal([]byte(`{"id":"z1","zone":"example.com","ttl":3600,"serial":42,
+			"dns_servers":["dns1.p01.nsone.net"],"network_pools":["p01"],"pool":"p01","local_tags":["t"],
+			"records":[{"domain":"www.example.com","type":"A"}],
+			"primary":{"enabled":true,"secondaries":[{"ip":"1.1.1.1","notify":true,"networks":[0]}]},
+			"secondary":{"enabled":true,"primary_ip":"2.2.2.2","expired":true,"last_xfr":1600000000,
+				"status":"ok","error":"none","tsig":{"enabled":true,"name":"k","key":"secret"}}}`), &z))
+		before := normalizedFields(&z, nil)
+		for _, f := range zoneServerManagedFields {
+			assert.True(t, hasPath(before, strings.Split(f, ".")), f)
+		}
+
+		z.StripServerManaged()
+		after := normalizedFields(&z, nil)
+		for _, f := range zoneServerManagedFields {
+			assert.False(t, hasPath(after, strings.Split(f, ".")), f)
+		}
+		assert.Equal(t, 3600, z.TTL)
+		assert.Equal(t, "2.2.2.2", z.Secondary.PrimaryIP)
+		assert.Equal(t, "k", z.Secondary.TSIG.Name)
+		assert.Equal(t, "secret", z.Secondary.TSIG.Key)
+		assert.Equal(t, "1.1.1.1", z.Primary.Secondaries[0].IP)
+		assert.Equal(t, []int{0}, z.Primary.Secondaries[0].NetworkIDs)
+	})
+}
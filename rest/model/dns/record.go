@@ -3,8 +3,12 @@ package dns
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
 )
@@ -18,7 +22,7 @@ type Record struct {
 	Domain          string `json:"domain"`
 	Type            string `json:"type"`
 	Link            string `json:"link,omitempty"`
-	TTL             int    `json:"ttl,omitempty"`
+	TTL             int    `json:"ttl,omitempty"` // applies to all answers, see Answer.SetTTL
 	UseClientSubnet *bool  `json:"use_client_subnet,omitempty"`
 
 	// Answers must all be of the same type as the record.
@@ -36,6 +40,10 @@ type Record struct {
 
 	// Read-only fields
 	LocalTags []string `json:"local_tags,omitempty"` // Only relevant for DDI
+
+	// The DDI networks this variant of the record applies to. Empty means
+	// all networks.
+	NetworkIDs []int `json:"networks,omitempty"` // Only relevant for DDI
 }
 
 func (r Record) String() string {
@@ -77,6 +85,110 @@ func (r *Record) AddAnswer(ans *Answer) {
 	r.Answers = append(r.Answers, ans)
 }
 
+// SetRegionMeta sets the shared metadata of the named region, replacing any
+// previous region metadata. NS1 applies it to every answer of the region,
+// below the answers' own metadata in precedence, so e.g. a georegion set here
+// need not be repeated on each answer. The region must be defined in
+// Regions or referenced by an answer; in the latter case it is added to
+// Regions.
+func (r *Record) SetRegionMeta(regionName string, meta data.Meta) error {
+	if _, ok := r.Regions[regionName]; !ok {
+		referenced := false
+		for _, a := range r.Answers {
+			if a != nil && a.RegionName == regionName {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			return fmt.Errorf("%s: region %q is not defined or used by any answer", r, regionName)
+		}
+		if r.Regions == nil {
+			r.Regions = data.Regions{}
+		}
+	}
+
+	r.Regions[regionName] = data.Region{Meta: meta}
+	return nil
+}
+
+// RegionsInUse returns the sorted names of the record's regions that at
+// least one answer belongs to.
+func (r *Record) RegionsInUse() []string {
+	used := map[string]bool{}
+	for _, a := range r.Answers {
+		if a == nil {
+			continue
+		}
+		if _, ok := r.Regions[a.RegionName]; ok {
+			used[a.RegionName] = true
+		}
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveUpState computes the effective up/down state of each answer, given
+// the current values of the data feeds its 'up' metadata may point at, keyed
+// by feed id. The precedence rules are:
+//
+//  1. Levels are considered from most to least specific: the answer's meta,
+//     then the meta of the answer's region, then the record's meta.
+//  2. At each level, a static bool 'up' decides the state.
+//  3. A feed-bound 'up' decides the state if feedValues has a value for the
+//     feed; otherwise the level is skipped, as the feed has not reported.
+//  4. If no level decides, the answer is up.
+//
+// So a static answer-level 'up' overrides a feed bound at the region or
+// record level, and a feed that has reported overrides less specific static
+// values.
+func (r *Record) ResolveUpState(feedValues map[string]bool) map[*Answer]bool {
+	states := make(map[*Answer]bool, len(r.Answers))
+	for _, a := range r.Answers {
+		if a == nil {
+			continue
+		}
+
+		var metas []*data.Meta
+		if a.Meta != nil {
+			metas = append(metas, a.Meta)
+		}
+		if region, ok := r.Regions[a.RegionName]; ok && a.RegionName != "" {
+			metas = append(metas, &region.Meta)
+		}
+		if r.Meta != nil {
+			metas = append(metas, r.Meta)
+		}
+
+		up := true
+		for _, m := range metas {
+			if v, ok := resolveUp(m.Up, feedValues); ok {
+				up = v
+				break
+			}
+		}
+		states[a] = up
+	}
+	return states
+}
+
+// resolveUp returns the state an 'up' metadata value decides, if any.
+func resolveUp(v interface{}, feedValues map[string]bool) (bool, bool) {
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	if id, ok := data.FeedIDOf(v); ok {
+		up, ok := feedValues[id]
+		return up, ok
+	}
+	return false, false
+}
+
 // AddFilter adds a filter to the records' filter chain(ordering of filters matters).
 func (r *Record) AddFilter(fil *filter.Filter) {
 	if r.Filters == nil {
@@ -86,6 +198,212 @@ func (r *Record) AddFilter(fil *filter.Filter) {
 	r.Filters = append(r.Filters, fil)
 }
 
+// ValidationWarning is returned by Record.Validate for configurations that
+// are accepted by the API but are likely mistakes. It is the warning of all
+// the models, so IsWarning also recognizes those of monitor.Job.Validate.
+type ValidationWarning = model.ValidationWarning
+
+// IsWarning reports whether err is a *ValidationWarning.
+func IsWarning(err error) bool {
+	return model.IsWarning(err)
+}
+
+// ValidateOption enables optional checks in Record.Validate.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	answerLimits map[string]int
+}
+
+// WithAnswerLimits caps the number of answers per record type, e.g. to the
+// limits of the account's plan, so that Record.Validate reports records the
+// API would reject. Types are case insensitive, and the "*" key applies to
+// types without their own limit. There is no limit by default.
+func WithAnswerLimits(limits map[string]int) ValidateOption {
+	upper := make(map[string]int, len(limits))
+	for t, limit := range limits {
+		upper[strings.ToUpper(t)] = limit
+	}
+	return func(vo *validateOptions) {
+		vo.answerLimits = upper
+	}
+}
+
+// answerLimit returns the configured answer limit for type t, or 0.
+func (vo *validateOptions) answerLimit(t string) int {
+	if limit, ok := vo.answerLimits[strings.ToUpper(t)]; ok {
+		return limit
+	}
+	return vo.answerLimits["*"]
+}
+
+// weightingFilters are the filters that make use of the answer weight
+// metadata.
+var weightingFilters = map[string]bool{
+	"weighted_shuffle": true,
+	"weighted_sticky":  true,
+}
+
+// Validate checks the record's domain and type, the format of its answers for
+// the common record types, that answer regions are defined in Regions, the
+// record and answer metadata and the filter configs, and cross-checks the
+// filter chain against the answers. Mismatches between answer weights and
+// the weighting filters are reported as *ValidationWarning, since the
+// record still works but the weights are silently ignored.
+//
+// Options enable further, account-specific checks, see WithAnswerLimits.
+func (r *Record) Validate(opts ...ValidateOption) (errs []error) {
+	var vo validateOptions
+	for _, opt := range opts {
+		opt(&vo)
+	}
+
+	if r.Domain == "" {
+		errs = append(errs, fmt.Errorf("%s: domain is required", r))
+	} else if r.Zone != "" && !InZone(r.Domain, r.Zone) {
+		errs = append(errs, fmt.Errorf("%s: domain is not in zone %s", r, r.Zone))
+	}
+	if r.Type == "" {
+		errs = append(errs, fmt.Errorf("%s: type is required", r))
+	}
+	if limit := vo.answerLimit(r.Type); limit > 0 && len(r.Answers) > limit {
+		errs = append(errs, fmt.Errorf("%s: has %d answers, more than the limit of %d", r, len(r.Answers), limit))
+	}
+
+	if r.Meta != nil {
+		errs = append(errs, r.Meta.Validate()...)
+	}
+
+	for i, a := range r.Answers {
+		if a == nil {
+			continue
+		}
+		if err := validateRdata(r.Type, a.Rdata); err != nil {
+			errs = append(errs, fmt.Errorf("%s: answer %d: %s", r, i, err))
+		}
+		if a.RegionName != "" {
+			if _, ok := r.Regions[a.RegionName]; !ok {
+				errs = append(errs, fmt.Errorf("%s: answer %d: region %q is not defined in the record's regions", r, i, a.RegionName))
+			}
+		}
+	}
+
+	weighted := 0
+	for _, a := range r.Answers {
+		if a == nil || a.Meta == nil {
+			continue
+		}
+		errs = append(errs, a.Meta.Validate()...)
+		if a.Meta.Weight != nil {
+			weighted++
+		}
+	}
+
+	for i, f := range r.Filters {
+		if f == nil {
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: filter %d: %s", r, i, err))
+		}
+	}
+
+	var weightFilter string
+	for _, f := range r.Filters {
+		if f != nil && !f.Disabled && weightingFilters[f.Type] {
+			weightFilter = f.Type
+			break
+		}
+	}
+
+	switch {
+	case weighted > 0 && weightFilter == "":
+		errs = append(errs, &ValidationWarning{Message: fmt.Sprintf(
+			"%s: answers have weight metadata but no weighted_shuffle or weighted_sticky filter is enabled", r,
+		)})
+	case weightFilter != "" && weighted < len(r.Answers):
+		errs = append(errs, &ValidationWarning{Message: fmt.Sprintf(
+			"%s: %s filter is enabled but %d of %d answers have no weight metadata",
+			r, weightFilter, len(r.Answers)-weighted, len(r.Answers),
+		)})
+	}
+
+	return errs
+}
+
+// InZone reports whether domain is zone itself or a name below it.
+func InZone(domain, zone string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return domain == zone || strings.HasSuffix(domain, "."+zone)
+}
+
+// validateRdata checks the number and format of an answer's fields for the
+// common record types. Answers given as a single space separated string
+// (e.g. "10 mail.example.com") are accepted as well. Other types are not
+// checked.
+func validateRdata(t string, rdata []string) error {
+	fields := rdata
+	if len(fields) == 1 && t != "TXT" && t != "SPF" {
+		fields = strings.Fields(fields[0])
+	}
+
+	want := 0
+	switch t {
+	case "A", "AAAA", "CNAME", "ALIAS", "PTR", "NS", "DNAME":
+		want = 1
+	case "MX":
+		want = 2
+	case "CAA":
+		want = 3
+	case "SRV":
+		want = 4
+	case "TXT", "SPF":
+		if len(fields) == 0 {
+			return fmt.Errorf("%s answer requires at least one field", t)
+		}
+		return nil
+	default:
+		return nil
+	}
+	if len(fields) != want {
+		return fmt.Errorf("%s answer requires %d field(s), got %d", t, want, len(fields))
+	}
+
+	switch t {
+	case "A":
+		if ip := net.ParseIP(fields[0]); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is not a valid IPv4 address", fields[0])
+		}
+	case "AAAA":
+		if ip := net.ParseIP(fields[0]); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is not a valid IPv6 address", fields[0])
+		}
+	case "MX":
+		return validateUint(fields[0], "priority", 16)
+	case "SRV":
+		for i, name := range []string{"priority", "weight", "port"} {
+			if err := validateUint(fields[i], name, 16); err != nil {
+				return err
+			}
+		}
+	case "CAA":
+		return validateUint(fields[0], "flag", 8)
+	}
+
+	if fields[len(fields)-1] == "" {
+		return fmt.Errorf("%s answer has an empty value", t)
+	}
+	return nil
+}
+
+func validateUint(s, name string, bits int) error {
+	if _, err := strconv.ParseUint(s, 10, bits); err != nil {
+		return fmt.Errorf("%s %q must be an unsigned %d bit integer", name, s, bits)
+	}
+	return nil
+}
+
 // MarshalJSON attempts to convert any Rdata elements that cannot be passed as
 // strings to the API to their correct type.
 func (r *Record) MarshalJSON() ([]byte, error) {
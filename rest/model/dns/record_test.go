@@ -3,7 +3,12 @@ package dns
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
 )
 
 var marshalRecordCases = []struct {
@@ -45,3 +50,291 @@ func TestMarshalRecords(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRecordWeights(t *testing.T) {
+	weighted := func(w float64) *Answer {
+		a := NewAv4Answer("1.2.3.4")
+		a.Meta.Weight = w
+		return a
+	}
+
+	cases := []struct {
+		name     string
+		answers  []*Answer
+		filters  []*filter.Filter
+		warnings int
+	}{
+		{"no weights no filter", []*Answer{NewAv4Answer("1.2.3.4")}, nil, 0},
+		{"weights and filter", []*Answer{weighted(1), weighted(2)}, []*filter.Filter{filter.NewWeightedShuffle()}, 0},
+		{"weights without filter", []*Answer{weighted(1), weighted(2)}, []*filter.Filter{filter.NewShuffle()}, 1},
+		{"filter without weights", []*Answer{weighted(1), NewAv4Answer("5.6.7.8")}, []*filter.Filter{filter.NewWeightedSticky(false)}, 1},
+		{"disabled filter", []*Answer{weighted(1)}, []*filter.Filter{{Type: "weighted_shuffle", Disabled: true}}, 1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecord("example.com", "www", "A")
+			for _, a := range tt.answers {
+				r.AddAnswer(a)
+			}
+			for _, f := range tt.filters {
+				r.AddFilter(f)
+			}
+
+			errs := r.Validate()
+			if len(errs) != tt.warnings {
+				t.Fatalf("expected %d warnings, got %v", tt.warnings, errs)
+			}
+			for _, err := range errs {
+				if !IsWarning(err) {
+					t.Errorf("expected a warning, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRecordMeta(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	a := NewAv4Answer("1.2.3.4")
+	a.Meta.Weight = -1.0
+	r.AddAnswer(a)
+	r.AddFilter(filter.NewWeightedShuffle())
+
+	errs := r.Validate()
+	if len(errs) != 1 || IsWarning(errs[0]) {
+		t.Fatalf("expected a single hard error, got %v", errs)
+	}
+}
+
+func TestValidateRecordAnswers(t *testing.T) {
+	cases := []struct {
+		t     string
+		rdata []string
+		ok    bool
+	}{
+		{"A", []string{"1.2.3.4"}, true},
+		{"A", []string{"2001:db8::1"}, false},
+		{"A", []string{"1.2.3.4", "5.6.7.8"}, false},
+		{"AAAA", []string{"2001:db8::1"}, true},
+		{"AAAA", []string{"1.2.3.4"}, false},
+		{"CNAME", []string{"target.example.com"}, true},
+		{"MX", []string{"10", "mail.example.com"}, true},
+		{"MX", []string{"10 mail.example.com"}, true},
+		{"MX", []string{"high", "mail.example.com"}, false},
+		{"SRV", []string{"10", "5", "443", "svc.example.com"}, true},
+		{"SRV", []string{"10", "5", "99999", "svc.example.com"}, false},
+		{"CAA", []string{"0", "issue", "letsencrypt.org"}, true},
+		{"CAA", []string{"256", "issue", "letsencrypt.org"}, false},
+		{"TXT", []string{"v=spf1 -all"}, true},
+		{"TXT", []string{}, false},
+		{"URLFWD", []string{"anything"}, true},
+	}
+
+	for _, tt := range cases {
+		r := NewRecord("example.com", "www", tt.t)
+		r.AddAnswer(NewAnswer(tt.rdata))
+		errs := r.Validate()
+		if tt.ok && len(errs) != 0 {
+			t.Errorf("%s %v: unexpected errors %v", tt.t, tt.rdata, errs)
+		}
+		if !tt.ok && len(errs) != 1 {
+			t.Errorf("%s %v: expected one error, got %v", tt.t, tt.rdata, errs)
+		}
+	}
+}
+
+func TestValidateRecordDomain(t *testing.T) {
+	r := &Record{Zone: "example.com", Domain: "www.example.net", Type: "A"}
+	if errs := r.Validate(); len(errs) != 1 {
+		t.Errorf("expected a single zone membership error, got %v", errs)
+	}
+
+	r = &Record{Zone: "example.com"}
+	if errs := r.Validate(); len(errs) != 2 {
+		t.Errorf("expected missing domain and type errors, got %v", errs)
+	}
+
+	if !InZone("WWW.Example.com.", "example.com") || !InZone("example.com", "example.com") {
+		t.Error("expected names to be in zone")
+	}
+	if InZone("badexample.com", "example.com") {
+		t.Error("expected badexample.com not to be in example.com")
+	}
+}
+
+func TestRecordFilterChainRoundTrip(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	r.AddAnswer(NewAv4Answer("1.2.3.4"))
+	r.AddFilter(filter.NewUp())
+	r.AddFilter(filter.StickyFilter(filter.StickyByIP))
+	r.AddFilter(filter.NewSelFirstN(1))
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Record
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	types := []string{}
+	for _, f := range decoded.Filters {
+		types = append(types, f.Type)
+	}
+	if want := []string{"up", "sticky", "select_first_n"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("filter order: got %v, want %v", types, want)
+	}
+	if sc, err := decoded.Filters[1].StickyConfig(); err != nil || sc.By != filter.StickyByIP {
+		t.Errorf("sticky config: got %+v, %v", sc, err)
+	}
+
+	r.Filters[1] = filter.StickyFilter("cookie")
+	if errs := r.Validate(); len(errs) != 1 || IsWarning(errs[0]) {
+		t.Errorf("expected a sticky_by error, got %v", errs)
+	}
+}
+
+func TestRecordRegions(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	r.Regions["us-east"] = data.Region{}
+	r.Regions["eu-west"] = data.Region{}
+	r.Regions["unused"] = data.Region{}
+
+	for _, region := range []string{"us-east", "eu-west", "us-east"} {
+		a := NewAv4Answer("1.2.3.4")
+		a.SetRegion(region)
+		r.AddAnswer(a)
+	}
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if got := r.RegionsInUse(); !reflect.DeepEqual(got, []string{"eu-west", "us-east"}) {
+		t.Errorf("RegionsInUse: got %v", got)
+	}
+
+	orphan := NewAv4Answer("5.6.7.8")
+	orphan.SetRegion("ap-south")
+	r.AddAnswer(orphan)
+	if errs := r.Validate(); len(errs) != 1 {
+		t.Errorf("expected an orphaned region error, got %v", errs)
+	}
+}
+
+func TestRecordSetRegionMeta(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	a := NewAv4Answer("1.2.3.4")
+	a.SetRegion("us-east")
+	r.AddAnswer(a)
+
+	if err := r.SetRegionMeta("us-east", data.Meta{Georegion: []string{"US-EAST"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetRegionMeta("eu-west", data.Meta{}); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+
+	out, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Record
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out, []byte(`"regions":{"us-east":{"meta":{"georegion":["US-EAST"]}}}`)) {
+		t.Errorf("unexpected regions in %s", out)
+	}
+	if got := back.Regions["us-east"].Meta.Georegion; !reflect.DeepEqual(got, []interface{}{"US-EAST"}) {
+		t.Errorf("region meta not round-tripped: %v", got)
+	}
+}
+
+func TestResolveUpState(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	r.Meta.Up = data.FeedPtr{FeedID: "record-feed"}
+	r.Regions["east"] = data.Region{Meta: data.Meta{Up: map[string]interface{}{"feed": "east-feed"}}}
+
+	static := NewAv4Answer("1.1.1.1")
+	static.Meta.Up = false
+	static.SetRegion("east")
+
+	fed := NewAv4Answer("2.2.2.2")
+	fed.Meta.Up = data.FeedPtr{FeedID: "answer-feed"}
+
+	regional := NewAv4Answer("3.3.3.3")
+	regional.SetRegion("east")
+
+	plain := NewAv4Answer("4.4.4.4")
+
+	for _, a := range []*Answer{static, fed, regional, plain} {
+		r.AddAnswer(a)
+	}
+
+	states := r.ResolveUpState(map[string]bool{"answer-feed": true, "east-feed": false, "record-feed": true})
+	// Static answer meta beats the region feed; feeds decide otherwise.
+	assert.Equal(t, map[*Answer]bool{static: false, fed: true, regional: false, plain: true}, states)
+
+	// Feeds that have not reported fall through to less specific levels.
+	states = r.ResolveUpState(map[string]bool{"record-feed": false})
+	assert.Equal(t, map[*Answer]bool{static: false, fed: false, regional: false, plain: false}, states)
+
+	// Nothing set anywhere means up.
+	states = r.ResolveUpState(nil)
+	assert.Equal(t, map[*Answer]bool{static: false, fed: true, regional: true, plain: true}, states)
+}
+
+func TestMarshalRecordStable(t *testing.T) {
+	newRecord := func() *Record {
+		r := NewRecord("example.com", "www.example.com", "A")
+		r.Meta.Subdivisions = map[string]interface{}{"US": []string{"NY", "CA"}, "CA": []string{"ON"}, "BR": []string{"SP"}}
+		r.Meta.Up = map[string]interface{}{"feed": "f1"}
+		r.Regions = data.Regions{
+			"us-west": {Meta: data.Meta{Priority: 1}},
+			"us-east": {Meta: data.Meta{Priority: 2}},
+			"eu":      {Meta: data.Meta{Priority: 3}},
+		}
+		for _, ip := range []string{"3.3.3.3", "1.1.1.1", "2.2.2.2"} {
+			r.AddAnswer(NewAv4Answer(ip))
+		}
+		return r
+	}
+
+	first, err := json.Marshal(newRecord())
+	assert.Nil(t, err)
+	for i := 0; i < 20; i++ {
+		out, err := json.Marshal(newRecord())
+		assert.Nil(t, err)
+		assert.Equal(t, string(first), string(out))
+	}
+	assert.True(t, bytes.Index(first, []byte(`"eu"`)) < bytes.Index(first, []byte(`"us-east"`)))
+	assert.True(t, bytes.Index(first, []byte("3.3.3.3")) < bytes.Index(first, []byte("1.1.1.1")))
+}
+
+func TestRecordValidateAnswerLimits(t *testing.T) {
+	r := NewRecord("example.com", "www", "A")
+	for _, ip := range []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"} {
+		r.AddAnswer(NewAv4Answer(ip))
+	}
+
+	if errs := r.Validate(); len(errs) != 0 {
+		t.Fatalf("expected no limit by default, got %v", errs)
+	}
+	if errs := r.Validate(WithAnswerLimits(map[string]int{"A": 3})); len(errs) != 0 {
+		t.Errorf("expected 3 answers to be within the limit, got %v", errs)
+	}
+	errs := r.Validate(WithAnswerLimits(map[string]int{"a": 2}))
+	if len(errs) != 1 || errs[0].Error() != "www.example.com A: has 3 answers, more than the limit of 2" {
+		t.Errorf("expected a limit error, got %v", errs)
+	}
+	if errs := r.Validate(WithAnswerLimits(map[string]int{"*": 1, "A": 5})); len(errs) != 0 {
+		t.Errorf("expected the per-type limit to win, got %v", errs)
+	}
+	if errs := r.Validate(WithAnswerLimits(map[string]int{"*": 1})); len(errs) != 1 {
+		t.Errorf("expected the default limit to apply, got %v", errs)
+	}
+}
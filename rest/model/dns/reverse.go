@@ -0,0 +1,78 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	reverseV4Suffix = "in-addr.arpa"
+	reverseV6Suffix = "ip6.arpa"
+)
+
+// ReverseName returns the fully qualified reverse DNS name (without the
+// trailing dot) for an IPv4 or IPv6 address, e.g.
+//
+//	192.0.2.1   -> 1.2.0.192.in-addr.arpa
+//	2001:db8::1 -> 1.0.0.0.[...].8.b.d.0.1.0.0.2.ip6.arpa
+func ReverseName(ip string) (string, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", fmt.Errorf("invalid IP address: %q", ip)
+	}
+	if v4 := addr.To4(); v4 != nil {
+		return reverseLabels(v4, 32), nil
+	}
+	return reverseLabels(addr.To16(), 128), nil
+}
+
+// ReverseZone returns the reverse zone name that holds the PTR records for
+// the given CIDR block, e.g. 192.0.2.0/24 -> 2.0.192.in-addr.arpa. IPv4
+// prefixes must fall on an octet boundary and IPv6 prefixes on a nibble
+// boundary; classless (RFC 2317) delegations are not calculated.
+func ReverseZone(cidr string) (string, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := ipnet.Mask.Size()
+	if bits == 32 {
+		if ones%8 != 0 {
+			return "", fmt.Errorf("IPv4 prefix length must be a multiple of 8, got /%d", ones)
+		}
+		return reverseLabels(ipnet.IP.To4(), ones), nil
+	}
+	if ones%4 != 0 {
+		return "", fmt.Errorf("IPv6 prefix length must be a multiple of 4, got /%d", ones)
+	}
+	return reverseLabels(ipnet.IP.To16(), ones), nil
+}
+
+// reverseLabels builds the reversed name for the first prefixLen bits of ip,
+// using octets for 4 byte addresses and nibbles for 16 byte addresses.
+func reverseLabels(ip net.IP, prefixLen int) string {
+	var labels []string
+	if len(ip) == net.IPv4len {
+		for i := prefixLen/8 - 1; i >= 0; i-- {
+			labels = append(labels, fmt.Sprintf("%d", ip[i]))
+		}
+		return strings.Join(append(labels, reverseV4Suffix), ".")
+	}
+
+	for i := prefixLen/4 - 1; i >= 0; i-- {
+		b := ip[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		labels = append(labels, fmt.Sprintf("%x", b&0xf))
+	}
+	return strings.Join(append(labels, reverseV6Suffix), ".")
+}
+
+// IsReverseZone reports whether zone is an in-addr.arpa or ip6.arpa zone.
+func IsReverseZone(zone string) bool {
+	zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+	return zone == reverseV4Suffix || strings.HasSuffix(zone, "."+reverseV4Suffix) ||
+		zone == reverseV6Suffix || strings.HasSuffix(zone, "."+reverseV6Suffix)
+}
@@ -0,0 +1,64 @@
+package dns
+
+import "testing"
+
+func TestReverseName(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"10.0.0.255", "255.0.0.10.in-addr.arpa"},
+		{"::ffff:192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"2001:db8::567:89ab", "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+	for _, c := range cases {
+		got, err := ReverseName(c.in)
+		if err != nil {
+			t.Errorf("ReverseName(%q) returned error: %v", c.in, err)
+			continue
+		}
+		if got != c.out {
+			t.Errorf("ReverseName(%q): got %q, want %q", c.in, got, c.out)
+		}
+	}
+
+	if _, err := ReverseName("not-an-ip"); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestReverseZone(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{"192.0.2.0/24", "2.0.192.in-addr.arpa"},
+		{"10.0.0.0/8", "10.in-addr.arpa"},
+		{"2001:db8::/32", "8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8:abcd::/36", "a.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+	for _, c := range cases {
+		got, err := ReverseZone(c.in)
+		if err != nil {
+			t.Errorf("ReverseZone(%q) returned error: %v", c.in, err)
+			continue
+		}
+		if got != c.out {
+			t.Errorf("ReverseZone(%q): got %q, want %q", c.in, got, c.out)
+		}
+	}
+
+	for _, bad := range []string{"192.0.2.0/26", "2001:db8::/33", "192.0.2.1"} {
+		if _, err := ReverseZone(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestIsReverseZone(t *testing.T) {
+	if !IsReverseZone("2.0.192.in-addr.arpa") || !IsReverseZone("8.b.d.0.1.0.0.2.ip6.arpa.") {
+		t.Error("expected reverse zones to be detected")
+	}
+	if IsReverseZone("example.com") || IsReverseZone("notin-addr.arpa") {
+		t.Error("expected forward zones not to be detected")
+	}
+}
@@ -0,0 +1,14 @@
+package dns
+
+// TSIGKey wraps an NS1 /tsig resource, a named TSIG key that secondary zones
+// reference (see Zone.SetTSIG) to authenticate their transfers.
+type TSIGKey struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	// Secret is the base64-encoded key shared with the primary.
+	Secret string `json:"secret"`
+}
+
+func (k TSIGKey) String() string {
+	return k.Name
+}
@@ -0,0 +1,13 @@
+package dns
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// ZoneVersion wraps an NS1 /zones/{zone}/versions resource: a snapshot of a
+// zone's records, of which the active one is served.
+type ZoneVersion struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	Active      bool       `json:"active"`
+	CreatedAt   model.Time `json:"created_at"`
+	ActivatedAt model.Time `json:"activated_at"`
+}
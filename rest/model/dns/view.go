@@ -0,0 +1,31 @@
+package dns
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// View wraps an NS1 /views/{viewname} resource (DDI only). A view serves its
+// zones to the clients matched by its read ACLs, the view with the highest
+// preference winning when several match.
+type View struct {
+	Name string `json:"name,omitempty"`
+
+	// Read-only fields, not sent by ViewsService.Create and Update.
+	Created *model.Time `json:"created_at,omitempty"`
+	Updated *model.Time `json:"updated_at,omitempty"`
+
+	ReadACLs   []string `json:"read_acls"`
+	UpdateACLs []string `json:"update_acls"`
+	Zones      []string `json:"zones"`
+	Networks   []int    `json:"networks"`
+	Preference int      `json:"preference,omitempty"`
+}
+
+// NewView returns an empty view with the given name.
+func NewView(name string) *View {
+	return &View{
+		Name:       name,
+		ReadACLs:   []string{},
+		UpdateACLs: []string{},
+		Zones:      []string{},
+		Networks:   []int{},
+	}
+}
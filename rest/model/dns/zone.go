@@ -2,7 +2,9 @@ package dns
 
 import (
 	"encoding/json"
+	"time"
 
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 )
 
@@ -20,6 +22,11 @@ type Zone struct {
 	ID   string `json:"id,omitempty"`
 	Zone string `json:"zone,omitempty"`
 
+	// SOA fields. Serial is managed by NS1, which increments it on every
+	// change to the zone or its records (NOTIFYing the secondaries in
+	// Primary), and is ignored on update. Compare it with the serial served
+	// by external secondaries to check propagation; the API has no call to
+	// bump it or send a NOTIFY without a change.
 	TTL        int    `json:"ttl,omitempty"`
 	NxTTL      int    `json:"nx_ttl,omitempty"`
 	Retry      int    `json:"retry,omitempty"`
@@ -34,8 +41,11 @@ type Zone struct {
 
 	// Networks contains the network ids the zone is available. Most zones
 	// will be in the NSONE Global Network(which is id 0).
-	NetworkIDs []int         `json:"networks,omitempty"`
-	Records    []*ZoneRecord `json:"records,omitempty"`
+	NetworkIDs []int `json:"networks,omitempty"`
+
+	// Records is the short-form summary of the zone's records, as returned
+	// by a zone GET. It is read-only and ignored on zone create/update.
+	Records []*ZoneRecord `json:"records,omitempty"`
 
 	// Primary contains info to enable slaving of the zone by third party dns servers.
 	Primary *ZonePrimary `json:"primary,omitempty"`
@@ -54,9 +64,12 @@ func (z Zone) String() string {
 	return z.Zone
 }
 
-// ZoneRecord wraps Zone's "records" attribute
+// ZoneRecord wraps Zone's "records" attribute, a short-form summary of a
+// record. ShortAns is a flattened view of the record's answers, one string
+// per answer (e.g. "10 mail.example.com" for MX); use RecordsService.Get for
+// the full answers, metadata and filters.
 type ZoneRecord struct {
-	Domain   string      `json:"Domain,omitempty"`
+	Domain   string      `json:"domain,omitempty"`
 	ID       string      `json:"id,omitempty"`
 	Link     string      `json:"link,omitempty"`
 	ShortAns []string    `json:"short_answers,omitempty"`
@@ -71,6 +84,9 @@ type ZoneRecord struct {
 	LocalTags []string `json:"local_tags,omitempty"` // Only relevant for DDI
 }
 
+// ZoneRecordSummary is an alias of ZoneRecord, naming what it represents.
+type ZoneRecordSummary = ZoneRecord
+
 // ZonePrimary wraps a Zone's "primary" attribute
 type ZonePrimary struct {
 	// Enabled determines whether AXFR queries (and optionally NOTIFY messages)
@@ -92,10 +108,10 @@ type ZoneSecondaryServer struct {
 // ZoneSecondary wraps a Zone's "secondary" attribute
 type ZoneSecondary struct {
 	// Read-Only fields
-	Expired bool    `json:"expired,omitempty"`
-	LastXfr int     `json:"last_xfr,omitempty"`
-	Status  string  `json:"status,omitempty"`
-	Error   *string `json:"error"`
+	Expired bool        `json:"expired,omitempty"`
+	LastXfr *model.Time `json:"last_xfr,omitempty"`
+	Status  string      `json:"status,omitempty"`
+	Error   *string     `json:"error"`
 
 	PrimaryIP   string `json:"primary_ip,omitempty"`
 	PrimaryPort int    `json:"primary_port,omitempty"`
@@ -107,6 +123,15 @@ type ZoneSecondary struct {
 	TSIG *TSIG `json:"tsig,omitempty"`
 }
 
+// LastTransfer returns the time of the last zone transfer from the primary,
+// or the zero time if there has been none.
+func (s *ZoneSecondary) LastTransfer() time.Time {
+	if s.LastXfr == nil {
+		return time.Time{}
+	}
+	return s.LastXfr.Time
+}
+
 // TSIG is a zones transaction signature.
 type TSIG struct {
 	// Key is the encrypted TSIG key(read-only)
@@ -155,6 +180,16 @@ func (z *Zone) MakeSecondary(ip string) {
 	}
 }
 
+// SetTSIG makes a secondary zone sign its transfers from the primary with
+// the named TSIG key (see TSIGKey), using the given hash algorithm (e.g.
+// "hmac-sha256"). MakeSecondary must be called first.
+func (z *Zone) SetTSIG(name, hash string) {
+	if z.Secondary == nil {
+		return
+	}
+	z.Secondary.TSIG = &TSIG{Enabled: true, Name: name, Hash: hash}
+}
+
 // LinkTo sets Link to a target zone domain name and unsets all other configuration properties.
 // No other zone configuration properties (such as refresh, retry, etc) may be specified,
 // since they are all pulled from the target zone. Linked zones, once created, cannot be
@@ -47,6 +47,36 @@ func TestUnmarshalZoneRecords(t *testing.T) {
 
 }
 
+func TestUnmarshalZoneRecordSummary(t *testing.T) {
+	d := []byte(`{
+   "zone":"test.zone",
+   "records":[
+      {
+         "domain":"mail.test.zone",
+         "short_answers":["10 mx1.test.zone", "20 mx2.test.zone"],
+         "link":null,
+         "ttl":3600,
+         "tier":1,
+         "type":"MX",
+         "id":"5e3d91f01c56932156905bf7"
+      }
+   ]
+}`)
+	z := Zone{}
+	assert.Nil(t, json.Unmarshal(d, &z))
+	assert.Len(t, z.Records, 1)
+
+	var r *ZoneRecordSummary = z.Records[0]
+	assert.Equal(t, "mail.test.zone", r.Domain)
+	assert.Equal(t, "MX", r.Type)
+	assert.Equal(t, 3600, r.TTL)
+	assert.Equal(t, []string{"10 mx1.test.zone", "20 mx2.test.zone"}, r.ShortAns)
+
+	out, err := json.Marshal(r)
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), `"domain":"mail.test.zone"`)
+}
+
 func TestUnmarshalZones(t *testing.T) {
 	d := []byte(`[
    {
@@ -258,7 +288,7 @@ func TestUnmarshalZones(t *testing.T) {
 	secondary := secZ.Secondary
 	assert.Nil(t, secondary.Error)
 	assert.Equal(t, secondary.Status, "pending", "Wrong zone secondary status")
-	assert.Equal(t, secondary.LastXfr, 0, "Wrong zone secondary last xfr")
+	assert.True(t, secondary.LastXfr.IsZero(), "Wrong zone secondary last xfr")
 	assert.Equal(t, secondary.PrimaryIP, "1.1.1.1", "Wrong zone secondary primary ip")
 	assert.Equal(t, secondary.PrimaryPort, 53, "Wrong zone secondary primary port")
 	assert.Equal(t, secondary.Enabled, true, "Wrong zone secondary enabled")
@@ -342,4 +372,19 @@ func TestMakeSecondary(t *testing.T) {
 	assert.Equal(t, z.Primary, primary, "Zone primary should be disabled")
 	assert.Equal(t, z.Secondary.PrimaryIP, "1.1.1.1", "Wrong zone secondary primary IP")
 	assert.Equal(t, z.Secondary.PrimaryPort, 53, "Wrong zone secondary primary port")
+
+	z.SetTSIG("xfr-key", "hmac-sha256")
+	assert.Equal(t, &TSIG{Enabled: true, Name: "xfr-key", Hash: "hmac-sha256"}, z.Secondary.TSIG)
+}
+
+func TestMarshalZoneSecondaryReadOnly(t *testing.T) {
+	z := NewZone("example.com")
+	z.MakeSecondary("192.0.2.1")
+	b, err := json.Marshal(z)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), "last_xfr")
+
+	var read Zone
+	assert.Nil(t, json.Unmarshal([]byte(`{"zone": "example.com", "secondary": {"last_xfr": 1600000000}}`), &read))
+	assert.EqualValues(t, 1600000000, read.Secondary.LastTransfer().Unix())
 }
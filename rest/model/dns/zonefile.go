@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteZoneFile writes the zone in BIND zone file format: the SOA record
+// built from the zone's SOA fields, followed by its records as summarized in
+// Records. Answers are written as NS1 reports them in their short form, with
+// TXT and SPF answers quoted and the targets of CNAME, MX, NS, SRV, PTR and
+// ALIAS answers fully qualified. Records without short answers, such as
+// linked records, are written as comments.
+func (z *Zone) WriteZoneFile(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	origin := fqdn(z.Zone)
+
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	if z.TTL > 0 {
+		fmt.Fprintf(bw, "$TTL %d\n", z.TTL)
+	}
+
+	mname := "ns1." + origin
+	if len(z.DNSServers) > 0 {
+		mname = fqdn(z.DNSServers[0])
+	}
+	rname := "hostmaster." + origin
+	if z.Hostmaster != "" {
+		rname = fqdn(strings.Replace(z.Hostmaster, "@", ".", 1))
+	}
+	fmt.Fprintf(bw, "%s\t%d\tIN\tSOA\t%s %s %d %d %d %d %d\n",
+		origin, z.TTL, mname, rname, z.Serial, z.Refresh, z.Retry, z.Expiry, z.NxTTL)
+
+	for _, r := range z.Records {
+		if len(r.ShortAns) == 0 {
+			if r.Link != "" {
+				fmt.Fprintf(bw, "; %s %s is linked to %s\n", fqdn(r.Domain), r.Type, r.Link)
+			} else {
+				fmt.Fprintf(bw, "; %s %s has no answers\n", fqdn(r.Domain), r.Type)
+			}
+			continue
+		}
+		for _, ans := range r.ShortAns {
+			if r.Type == "TXT" || r.Type == "SPF" {
+				ans = quoteTXT(ans)
+			} else {
+				ans = absoluteTargets(r.Type, ans)
+			}
+			fmt.Fprintf(bw, "%s\t%d\tIN\t%s\t%s\n", fqdn(r.Domain), r.TTL, r.Type, ans)
+		}
+	}
+
+	return bw.Flush()
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// targetField is the index of the domain name in the short answer of the
+// record types that have one.
+var targetField = map[string]int{
+	"CNAME": 0, "DNAME": 0, "NS": 0, "PTR": 0, "ALIAS": 0,
+	"MX": 1, "SRV": 3,
+}
+
+// absoluteTargets makes the domain name in a short answer fully qualified,
+// so that it is not read as relative to the $ORIGIN.
+func absoluteTargets(t, ans string) string {
+	i, ok := targetField[t]
+	if !ok {
+		return ans
+	}
+	fields := strings.Fields(ans)
+	if i >= len(fields) {
+		return ans
+	}
+	fields[i] = fqdn(fields[i])
+	return strings.Join(fields, " ")
+}
+
+// quoteTXT quotes a TXT answer, unless it already is.
+func quoteTXT(s string) string {
+	if strings.HasPrefix(s, `"`) {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
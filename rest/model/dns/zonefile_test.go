@@ -0,0 +1,74 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZone_WriteZoneFile(t *testing.T) {
+	z := &Zone{
+		Zone:       "example.com",
+		DNSServers: []string{"dns1.p01.nsone.net"},
+		Hostmaster: "hostmaster@nsone.net",
+		TTL:        3600, Serial: 1500000000, Refresh: 43200, Retry: 7200, Expiry: 1209600, NxTTL: 3600,
+		Records: []*ZoneRecord{
+			{Domain: "www.example.com", Type: "A", TTL: 300, ShortAns: []string{"1.2.3.4", "5.6.7.8"}},
+			{Domain: "example.com", Type: "TXT", TTL: 300, ShortAns: []string{`v=spf1 "all"`}},
+			{Domain: "alias.example.com", Type: "A", TTL: 300, Link: "www.example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, z.WriteZoneFile(&buf))
+	assert.Equal(t, `$ORIGIN example.com.
+$TTL 3600
+example.com.	3600	IN	SOA	dns1.p01.nsone.net. hostmaster.nsone.net. 1500000000 43200 7200 1209600 3600
+www.example.com.	300	IN	A	1.2.3.4
+www.example.com.	300	IN	A	5.6.7.8
+example.com.	300	IN	TXT	"v=spf1 \"all\""
+; alias.example.com. A is linked to www.example.com
+`, buf.String())
+}
+
+func TestZone_WriteZoneFileTargets(t *testing.T) {
+	z := &Zone{
+		Zone: "example.com",
+		Records: []*ZoneRecord{
+			{Domain: "www.example.com", Type: "CNAME", ShortAns: []string{"web.example.net"}},
+			{Domain: "example.com", Type: "MX", ShortAns: []string{"10 mx.example.net"}},
+			{Domain: "example.com", Type: "NS", ShortAns: []string{"dns1.p01.nsone.net."}},
+			{Domain: "_sip._tcp.example.com", Type: "SRV", ShortAns: []string{"10 5 5060 sip.example.net"}},
+			{Domain: "4.3.2.1.in-addr.arpa", Type: "PTR", ShortAns: []string{"host.example.net"}},
+			{Domain: "example.com", Type: "ALIAS", ShortAns: []string{"lb.example.net"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, z.WriteZoneFile(&buf))
+
+	// Reading the targets back under the $ORIGIN gives the original names:
+	// only relative names get the origin appended.
+	var got []string
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		fields := strings.Split(sc.Text(), "\t")
+		if len(fields) < 5 || fields[3] == "SOA" {
+			continue
+		}
+		rdata := strings.Fields(fields[4])
+		target := rdata[len(rdata)-1]
+		if !strings.HasSuffix(target, ".") {
+			target += ".example.com."
+		}
+		got = append(got, strings.TrimSuffix(target, "."))
+	}
+	assert.Equal(t, []string{
+		"web.example.net", "mx.example.net", "dns1.p01.nsone.net",
+		"sip.example.net", "host.example.net", "lb.example.net",
+	}, got)
+}
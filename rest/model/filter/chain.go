@@ -0,0 +1,94 @@
+package filter
+
+// Chain is a record's filter chain, built in the order NS1 applies it:
+//
+//	r.Filters = filter.NewFilterChain().
+//		GeotargetCountry().
+//		Up().
+//		SelectFirstN(1).
+//		Filters()
+//
+// Every method appends one filter, built by the matching New constructor,
+// and returns the chain.
+type Chain struct {
+	filters []*Filter
+}
+
+// NewFilterChain returns an empty filter chain.
+func NewFilterChain() *Chain {
+	return &Chain{filters: []*Filter{}}
+}
+
+// Filters returns the filters of the chain, for a Record's Filters.
+func (c *Chain) Filters() []*Filter {
+	return c.filters
+}
+
+// Add appends the given filters to the chain.
+func (c *Chain) Add(fs ...*Filter) *Chain {
+	c.filters = append(c.filters, fs...)
+	return c
+}
+
+// SelectFirstN appends a select_first_n filter, see NewSelFirstN.
+func (c *Chain) SelectFirstN(n int) *Chain { return c.Add(NewSelFirstN(n)) }
+
+// Shuffle appends a shuffle filter, see NewShuffle.
+func (c *Chain) Shuffle() *Chain { return c.Add(NewShuffle()) }
+
+// SelectFirstRegion appends a select_first_region filter, see
+// NewSelFirstRegion.
+func (c *Chain) SelectFirstRegion() *Chain { return c.Add(NewSelFirstRegion()) }
+
+// StickyRegion appends a sticky_region filter, see NewStickyRegion.
+func (c *Chain) StickyRegion(byNetwork bool) *Chain { return c.Add(NewStickyRegion(byNetwork)) }
+
+// GeofenceCountry appends a geofence_country filter, see NewGeofenceCountry.
+func (c *Chain) GeofenceCountry(rmNoLoc bool) *Chain { return c.Add(NewGeofenceCountry(rmNoLoc)) }
+
+// GeofenceRegional appends a geofence_regional filter, see
+// NewGeofenceRegional.
+func (c *Chain) GeofenceRegional(rmNoGeo bool) *Chain { return c.Add(NewGeofenceRegional(rmNoGeo)) }
+
+// GeotargetCountry appends a geotarget_country filter, see
+// NewGeotargetCountry.
+func (c *Chain) GeotargetCountry() *Chain { return c.Add(NewGeotargetCountry()) }
+
+// GeotargetLatLong appends a geotarget_latlong filter, see
+// NewGeotargetLatLong.
+func (c *Chain) GeotargetLatLong() *Chain { return c.Add(NewGeotargetLatLong()) }
+
+// GeotargetRegional appends a geotarget_regional filter, see
+// NewGeotargetRegional.
+func (c *Chain) GeotargetRegional() *Chain { return c.Add(NewGeotargetRegional()) }
+
+// Sticky appends a sticky filter pinning requesters by StickyByIP or
+// StickyBySubnet, see StickyFilter.
+func (c *Chain) Sticky(by string) *Chain { return c.Add(StickyFilter(by)) }
+
+// WeightedSticky appends a weighted_sticky filter, see NewWeightedSticky.
+func (c *Chain) WeightedSticky(byNetwork bool) *Chain { return c.Add(NewWeightedSticky(byNetwork)) }
+
+// IPv4PrefixShuffle appends an ipv4_prefix_shuffle filter, see
+// NewIPv4PrefixShuffle.
+func (c *Chain) IPv4PrefixShuffle(n int) *Chain { return c.Add(NewIPv4PrefixShuffle(n)) }
+
+// NetfenceASN appends a netfence_asn filter, see NewNetfenceASN.
+func (c *Chain) NetfenceASN(rmNoASN bool) *Chain { return c.Add(NewNetfenceASN(rmNoASN)) }
+
+// NetfencePrefix appends a netfence_prefix filter, see NewNetfencePrefix.
+func (c *Chain) NetfencePrefix(rmNoIPPrefix bool) *Chain {
+	return c.Add(NewNetfencePrefix(rmNoIPPrefix))
+}
+
+// Up appends an up filter, see NewUp.
+func (c *Chain) Up() *Chain { return c.Add(NewUp()) }
+
+// Priority appends a priority filter, see NewPriority.
+func (c *Chain) Priority() *Chain { return c.Add(NewPriority()) }
+
+// ShedLoad appends a shed_load filter on the given metric, see NewShedLoad.
+func (c *Chain) ShedLoad(metric string) *Chain { return c.Add(NewShedLoad(metric)) }
+
+// WeightedShuffle appends a weighted_shuffle filter, see NewWeightedShuffle.
+func (c *Chain) WeightedShuffle() *Chain { return c.Add(NewWeightedShuffle()) }
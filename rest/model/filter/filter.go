@@ -1,5 +1,7 @@
 package filter
 
+import "fmt"
+
 // Filter wraps the values of a Record's "filters" attribute
 type Filter struct {
 	Type     string `json:"filter"`
@@ -39,7 +41,7 @@ func NewShuffle() *Filter {
 // NewSelFirstRegion returns a filter that keeps only the answers
 // that are in the same region as the first answer.
 func NewSelFirstRegion() *Filter {
-	return &Filter{Type: "select_first_n", Config: Config{}}
+	return &Filter{Type: "select_first_region", Config: Config{}}
 }
 
 // NewStickyRegion first sorts regions uniquely depending on the IP
@@ -180,3 +182,83 @@ func NewShedLoad(metric string) *Filter {
 func NewWeightedShuffle() *Filter {
 	return &Filter{Type: "weighted_shuffle", Config: Config{}}
 }
+
+// STICKY FILTER CONFIG
+
+// Accepted values of the sticky filter's sticky_by config.
+const (
+	StickyByIP     = "ip"
+	StickyBySubnet = "subnet"
+)
+
+// StickyConfig is the typed config of a "sticky" filter. By is one of
+// StickyByIP or StickyBySubnet, and Timeout (seconds, optional) is how long
+// a requester stays pinned to its answer.
+type StickyConfig struct {
+	By      string
+	Timeout int
+}
+
+// StickyFilter returns a sticky filter that pins requesters to an answer by
+// their IP or subnet, see StickyByIP and StickyBySubnet.
+func StickyFilter(by string) *Filter {
+	return NewStickyWithConfig(StickyConfig{By: by})
+}
+
+// NewStickyWithConfig returns a sticky filter with the given config.
+func NewStickyWithConfig(sc StickyConfig) *Filter {
+	c := Config{"sticky_by": sc.By}
+	if sc.Timeout > 0 {
+		c["timeout"] = sc.Timeout
+	}
+	return &Filter{Type: "sticky", Config: c}
+}
+
+// StickyConfig returns the typed config of a sticky filter, whether built
+// with StickyFilter or decoded from the API.
+func (f *Filter) StickyConfig() (StickyConfig, error) {
+	var sc StickyConfig
+	if f.Type != "sticky" {
+		return sc, fmt.Errorf("%s filter is not a sticky filter", f.Type)
+	}
+
+	if by, ok := f.Config["sticky_by"]; ok {
+		s, ok := by.(string)
+		if !ok {
+			return sc, fmt.Errorf("sticky_by must be a string, got %T", by)
+		}
+		sc.By = s
+	}
+
+	switch t := f.Config["timeout"].(type) {
+	case nil:
+	case int:
+		sc.Timeout = t
+	case float64:
+		sc.Timeout = int(t)
+	default:
+		return sc, fmt.Errorf("timeout must be a number, got %T", t)
+	}
+
+	return sc, nil
+}
+
+// Validate checks the config of filters with a known config shape. Currently
+// only the sticky filter's sticky_by and timeout are checked.
+func (f *Filter) Validate() error {
+	if f.Type != "sticky" {
+		return nil
+	}
+
+	sc, err := f.StickyConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := f.Config["sticky_by"]; ok && sc.By != StickyByIP && sc.By != StickyBySubnet {
+		return fmt.Errorf("sticky_by must be %q or %q, got %q", StickyByIP, StickyBySubnet, sc.By)
+	}
+	if sc.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative, got %d", sc.Timeout)
+	}
+	return nil
+}
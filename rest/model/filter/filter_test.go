@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStickyFilter(t *testing.T) {
+	f := StickyFilter(StickyBySubnet)
+	assert.Nil(t, f.Validate())
+
+	sc, err := f.StickyConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, StickyConfig{By: StickyBySubnet}, sc)
+
+	f = NewStickyWithConfig(StickyConfig{By: StickyByIP, Timeout: 300})
+	b, err := json.Marshal(f)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"filter":"sticky","config":{"sticky_by":"ip","timeout":300}}`, string(b))
+
+	var decoded Filter
+	assert.Nil(t, json.Unmarshal(b, &decoded))
+	sc, err = decoded.StickyConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, StickyConfig{By: StickyByIP, Timeout: 300}, sc)
+
+	assert.NotNil(t, StickyFilter("network").Validate())
+	assert.NotNil(t, (&Filter{Type: "sticky", Config: Config{"sticky_by": 1}}).Validate())
+
+	// Filters with the older sticky_by_network config are still valid.
+	assert.Nil(t, NewSticky(true).Validate())
+
+	_, err = NewShuffle().StickyConfig()
+	assert.NotNil(t, err)
+}
+
+func TestChain(t *testing.T) {
+	fs := NewFilterChain().GeotargetCountry().Up().SelectFirstN(1).Filters()
+	b, err := json.Marshal(fs)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"filter": "geotarget_country", "config": {}},
+		{"filter": "up", "config": {}},
+		{"filter": "select_first_n", "config": {"N": 1}}
+	]`, string(b))
+
+	assert.Equal(t, "select_first_region", NewFilterChain().SelectFirstRegion().Filters()[0].Type)
+	assert.Empty(t, NewFilterChain().Filters())
+}
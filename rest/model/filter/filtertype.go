@@ -0,0 +1,15 @@
+package filter
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model/data"
+
+// FilterType wraps an element of the NS1 /filtertypes resource, which
+// describes a kind of filter and the config it takes.
+type FilterType struct {
+	Type     string `json:"filter"`
+	Name     string `json:"name,omitempty"`
+	Desc     string `json:"desc,omitempty"`
+	Category string `json:"category,omitempty"`
+
+	// Config keys accepted by filters of this type.
+	Config []*data.ConfigField `json:"config,omitempty"`
+}
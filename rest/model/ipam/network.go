@@ -28,3 +28,13 @@ type Address struct {
 	DHCPScoped    bool                   `json:"dhcp_scoped"`
 	Parent        int                    `json:"parent_id"`
 }
+
+// Network wraps an NS1 /ipam/network resource, a separate address space
+// holding root addresses, such as a VRF or a site.
+type Network struct {
+	ID   int                    `json:"id,omitempty"`
+	Name string                 `json:"name"`
+	Desc string                 `json:"desc,omitempty"`
+	KVPS map[string]interface{} `json:"kvps,omitempty"`
+	Tags map[string]interface{} `json:"tags,omitempty"`
+}
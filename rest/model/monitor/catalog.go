@@ -0,0 +1,32 @@
+package monitor
+
+// JobType wraps an element of the NS1 /monitoring/jobtypes resource, which
+// describes a type of monitoring job and the config it accepts.
+type JobType struct {
+	ShortDesc string `json:"shortdesc"`
+	Desc      string `json:"desc"`
+
+	// JSON schema of the job's config.
+	Config map[string]interface{} `json:"config,omitempty"`
+
+	// Results the job produces, usable in rules, keyed by name.
+	Results map[string]*JobTypeResult `json:"results,omitempty"`
+}
+
+// JobTypeResult describes a result produced by a job type.
+type JobTypeResult struct {
+	ShortDesc   string   `json:"shortdesc"`
+	Desc        string   `json:"desc"`
+	Type        string   `json:"type"`
+	Metric      bool     `json:"metric,omitempty"`
+	Validator   string   `json:"validator,omitempty"`
+	Comparators []string `json:"comparators,omitempty"`
+}
+
+// Region wraps an element of the NS1 /monitoring/regions resource.
+type Region struct {
+	// Region code used in Job.Regions, eg "lga".
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Subnets []string `json:"subnets,omitempty"`
+}
@@ -1,4 +1,5 @@
 package monitor
 
-// Config is a flat mapping where values are simple (no slices/maps).
+// Config is a flat mapping where values are simple (no slices/maps), but
+// for the headers of a webhook notification.
 type Config map[string]interface{}
@@ -1,6 +1,11 @@
 package monitor
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+)
 
 // Job wraps an NS1 /monitoring/jobs resource
 type Job struct {
@@ -72,6 +77,9 @@ type Job struct {
 
 	// If true, a notification is sent when a job returns to an "up" state.
 	NotifyFailback bool `json:"notify_failback"`
+
+	// The DDI networks the job is scoped to. Empty means all networks.
+	NetworkIDs []int `json:"networks,omitempty"` // Only relevant for DDI
 }
 
 // Activate a monitoring job.
@@ -85,6 +93,82 @@ func (j *Job) Deactivate() {
 	j.Active = false
 }
 
+// ValidationWarning is returned by Job.Validate for configurations that are
+// accepted by the API but likely don't behave as intended. It is the warning
+// of all the models, so IsWarning also recognizes those of
+// dns.Record.Validate.
+type ValidationWarning = model.ValidationWarning
+
+// IsWarning reports whether err is a *ValidationWarning.
+func IsWarning(err error) bool {
+	return model.IsWarning(err)
+}
+
+// policies are the accepted values of Job.Policy.
+var policies = map[string]bool{"quorum": true, "all": true, "one": true}
+
+// rapidRecheckDelay is the time, in seconds, after which a job with
+// RapidRecheck is re-run to confirm a state change.
+const rapidRecheckDelay = 1
+
+// Validate checks the job for invalid or ineffective combinations of its
+// type, regions, frequency, rapid_recheck and policy. Hard errors are returned as plain
+// errors; combinations that work but are likely mistakes, such as a quorum
+// policy over a single region, are returned as *ValidationWarning.
+func (j *Job) Validate() (errs []error) {
+	name := j.Name
+	if name == "" {
+		name = j.ID
+	}
+	errorf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("job %s: "+format, append([]interface{}{name}, args...)...))
+	}
+	warnf := func(format string, args ...interface{}) {
+		errs = append(errs, &ValidationWarning{Message: fmt.Sprintf("job %s: "+format, append([]interface{}{name}, args...)...)})
+	}
+
+	if j.Type == "" {
+		errorf("job_type is required")
+	}
+	if j.Frequency <= 0 {
+		errorf("frequency must be a positive number of seconds")
+	}
+	if j.Policy != "" && !policies[j.Policy] {
+		errorf("policy %q must be one of quorum, all or one", j.Policy)
+	}
+	if j.RegionScope != "" && j.RegionScope != "fixed" {
+		errorf("region_scope %q must be fixed", j.RegionScope)
+	}
+
+	seen := map[string]bool{}
+	for _, r := range j.Regions {
+		if seen[r] {
+			errorf("region %q is listed more than once", r)
+		}
+		seen[r] = true
+	}
+	switch n := len(seen); {
+	case n == 0:
+		errorf("at least one region is required")
+	case n == 1 && policies[j.Policy] && j.Policy != "one":
+		warnf("%s policy with a single region behaves like the one policy", j.Policy)
+	case n == 2 && j.Policy == "quorum":
+		warnf("quorum policy with two regions behaves like the all policy")
+	}
+
+	if j.RapidRecheck && j.Frequency > 0 && j.Frequency <= 2*rapidRecheckDelay {
+		warnf("rapid_recheck has no effect with a frequency of %ds, the next run already rechecks", j.Frequency)
+	}
+
+	if j.NotifyListID == "" && (j.NotifyRepeat > 0 || j.NotifyFailback || j.NotifyRegional) {
+		warnf("notification settings have no effect without a notify_list")
+	}
+	if j.NotifyRepeat > 0 && j.NotifyRepeat < j.Frequency {
+		warnf("notify_repeat (%ds) is shorter than the frequency (%ds)", j.NotifyRepeat, j.Frequency)
+	}
+	return errs
+}
+
 // Result wraps an element of a JobType's "results" attribute
 type Result struct {
 	Comparators []string `json:"comparators"`
@@ -97,17 +181,17 @@ type Result struct {
 
 // Status wraps an value of a Job's "status" attribute
 type Status struct {
-	Since  int    `json:"since"`
-	Status string `json:"status"`
+	Since  model.Time `json:"since"`
+	Status string     `json:"status"`
 }
 
 // StatusLog wraps an NS1 /monitoring/history resource
 type StatusLog struct {
-	Job    string `json:"job"`
-	Region string `json:"region"`
-	Status string `json:"status"`
-	Since  int    `json:"since"`
-	Until  int    `json:"until"`
+	Job    string     `json:"job"`
+	Region string     `json:"region"`
+	Status string     `json:"status"`
+	Since  model.Time `json:"since"`
+	Until  model.Time `json:"until"`
 }
 
 // Rule wraps an element of a Job's "rules" attribute
@@ -64,14 +64,14 @@ func TestUnmarshalJobs(t *testing.T) {
 		t.Error("Wrong host")
 	}
 
-	if j.Status["global"].Since != 1389407609 {
+	if j.Status["global"].Since.Unix() != 1389407609 {
 		t.Error("since has unexpected value")
 	}
 	if j.Status["global"].Status != "up" {
 		t.Error("Status is not up")
 	}
 
-	if j.Status["sjc"].Since != 1389404014 {
+	if j.Status["sjc"].Since.Unix() != 1389404014 {
 		t.Error("sjc since has unexpected value")
 	}
 	if j.Status["sjc"].Status != "up" {
@@ -125,10 +125,10 @@ func TestUnmarshalStatusLog(t *testing.T) {
 	if log.Region != "lga" {
 		t.Error("Wrong region")
 	}
-	if log.Since != 1488297041 {
+	if log.Since.Unix() != 1488297041 {
 		t.Error("Wrong since")
 	}
-	if log.Until != 1488297042 {
+	if log.Until.Unix() != 1488297042 {
 		t.Error("Wrong until")
 	}
 }
@@ -154,10 +154,10 @@ func TestUnmarshalStatusLogMostRecent(t *testing.T) {
 	if log.Region != "lga" {
 		t.Error("Wrong region")
 	}
-	if log.Since != 1488297041 {
+	if log.Since.Unix() != 1488297041 {
 		t.Error("Wrong since")
 	}
-	if log.Until != 0 {
+	if !log.Until.IsZero() {
 		t.Error("Wrong until")
 	}
 }
@@ -237,3 +237,39 @@ func TestUnmarshalStatusLogs(t *testing.T) {
 		t.Errorf("Do not have correct number of status logs in job history. Expected: %d, Actual: %d", 9, len(logs))
 	}
 }
+
+func TestJobValidate(t *testing.T) {
+	valid := &Job{Name: "web", Type: "tcp", Frequency: 60, Policy: "quorum", RegionScope: "fixed",
+		Regions: []string{"lga", "sjc", "ams"}, NotifyListID: "n1", NotifyRepeat: 300}
+	assert.Empty(t, valid.Validate())
+
+	invalid := &Job{Name: "web", Policy: "most", RegionScope: "auto", Regions: []string{"lga", "lga"}}
+	errs := invalid.Validate()
+	assert.Len(t, errs, 5)
+	for _, err := range errs {
+		assert.False(t, IsWarning(err), err.Error())
+	}
+	assert.Contains(t, errs[4].Error(), `job web: region "lga" is listed more than once`)
+
+	single := &Job{Name: "web", Type: "ping", Frequency: 60, Policy: "all", Regions: []string{"lga"}}
+	errs = single.Validate()
+	assert.Len(t, errs, 1)
+	assert.True(t, IsWarning(errs[0]))
+	assert.Equal(t, "warning: job web: all policy with a single region behaves like the one policy", errs[0].Error())
+
+	fast := &Job{Name: "web", Type: "ping", Frequency: 2, RapidRecheck: true, Regions: []string{"lga"}}
+	errs = fast.Validate()
+	assert.Len(t, errs, 1)
+	assert.True(t, IsWarning(errs[0]))
+	assert.Equal(t, "warning: job web: rapid_recheck has no effect with a frequency of 2s, the next run already rechecks", errs[0].Error())
+	fast.Frequency = 30
+	assert.Empty(t, fast.Validate())
+
+	pair := &Job{Name: "web", Type: "ping", Frequency: 60, Policy: "quorum", Regions: []string{"lga", "sjc"},
+		NotifyFailback: true, NotifyRepeat: 30}
+	errs = pair.Validate()
+	assert.Len(t, errs, 3)
+	for _, err := range errs {
+		assert.True(t, IsWarning(err), err.Error())
+	}
+}
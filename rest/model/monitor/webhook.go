@@ -0,0 +1,93 @@
+package monitor
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+)
+
+// WebhookSecretHeader is the header of the shared secret a webhook
+// notification made with NewSecretWebNotification sends.
+const WebhookSecretHeader = "X-NS1-Webhook-Secret"
+
+// maxWebhookBody bounds the size of a webhook request body read by
+// ParseNotification; notifications are a few KiB.
+const maxWebhookBody = 1 << 20
+
+// ErrWebhookSecret is returned by ParseNotification for a request without
+// the expected shared secret.
+var ErrWebhookSecret = errors.New("webhook request has no valid secret")
+
+// WebhookNotification is the body of the request a webhook notification
+// sends when a monitoring job changes state.
+type WebhookNotification struct {
+	// The job whose state changed, as configured when notified.
+	Job *Job `json:"job"`
+
+	// The region whose state changed, "global" for the job's overall state.
+	Region string `json:"region"`
+
+	// The new state, "up" or "down".
+	State string `json:"state"`
+
+	// Time of the state change.
+	Since model.Time `json:"since"`
+}
+
+// Up reports whether the job came up.
+func (n *WebhookNotification) Up() bool {
+	return n.State == "up"
+}
+
+// Global reports whether the notification is about the job's overall state
+// rather than a single region's.
+func (n *WebhookNotification) Global() bool {
+	return n.Region == "" || n.Region == "global"
+}
+
+// NewSecretWebNotification returns a notification that alerts via webhook,
+// sending secret in the WebhookSecretHeader header of each request, for the
+// receiver to check with ParseNotification.
+func NewSecretWebNotification(url, secret string) *Notification {
+	n := NewWebNotification(url)
+	n.Config["headers"] = map[string]string{WebhookSecretHeader: secret}
+	return n
+}
+
+// ParseNotification reads and decodes the request of a webhook
+// notification, e.g. in the http.Handler receiving it. If secret is set,
+// the request must carry it in the WebhookSecretHeader header, or
+// ErrWebhookSecret is returned without reading the body; compare it with
+// the secret given to NewSecretWebNotification. Bodies over 1 MiB are
+// rejected.
+func ParseNotification(r *http.Request, secret string) (*WebhookNotification, error) {
+	if secret != "" {
+		got := r.Header.Get(WebhookSecretHeader)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+			return nil, ErrWebhookSecret
+		}
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxWebhookBody {
+		return nil, fmt.Errorf("webhook request body exceeds %d bytes", maxWebhookBody)
+	}
+
+	var n WebhookNotification
+	if err := json.Unmarshal(b, &n); err != nil {
+		return nil, fmt.Errorf("invalid webhook notification: %w", err)
+	}
+	if n.Job == nil || n.State == "" {
+		return nil, errors.New("invalid webhook notification: no job or state")
+	}
+	return &n, nil
+}
@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotification(t *testing.T) {
+	body := `{"job": {"id": "j1", "job_type": "tcp", "name": "web"}, "region": "lga", "state": "down", "since": 1600000000}`
+
+	req := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	req.Header.Set(WebhookSecretHeader, "s3cret")
+	n, err := ParseNotification(req, "s3cret")
+	require.Nil(t, err)
+	assert.Equal(t, "j1", n.Job.ID)
+	assert.Equal(t, "web", n.Job.Name)
+	assert.Equal(t, "lga", n.Region)
+	assert.EqualValues(t, 1600000000, n.Since.Unix())
+	assert.False(t, n.Up())
+	assert.False(t, n.Global())
+
+	// Without a secret, any request is accepted.
+	n, err = ParseNotification(httptest.NewRequest("POST", "/hook", strings.NewReader(body)), "")
+	require.Nil(t, err)
+	assert.Equal(t, "down", n.State)
+
+	req = httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+	req.Header.Set(WebhookSecretHeader, "guess")
+	_, err = ParseNotification(req, "s3cret")
+	assert.Equal(t, ErrWebhookSecret, err)
+	_, err = ParseNotification(httptest.NewRequest("POST", "/hook", strings.NewReader(body)), "s3cret")
+	assert.Equal(t, ErrWebhookSecret, err)
+
+	_, err = ParseNotification(httptest.NewRequest("POST", "/hook", strings.NewReader(`{"region": "lga"}`)), "")
+	assert.NotNil(t, err)
+	_, err = ParseNotification(httptest.NewRequest("POST", "/hook", strings.NewReader(`not json`)), "")
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr), err)
+	_, err = ParseNotification(httptest.NewRequest("POST", "/hook", strings.NewReader(strings.Repeat(" ", maxWebhookBody+1))), "")
+	assert.NotNil(t, err)
+}
+
+func TestNewSecretWebNotification(t *testing.T) {
+	n := NewSecretWebNotification("https://hooks.example.com/ns1", "s3cret")
+	assert.Equal(t, "webhook", n.Type)
+	assert.Equal(t, "https://hooks.example.com/ns1", n.Config["url"])
+	assert.Equal(t, map[string]string{WebhookSecretHeader: "s3cret"}, n.Config["headers"])
+}
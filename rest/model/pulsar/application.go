@@ -0,0 +1,25 @@
+package pulsar
+
+// Application wraps an NS1 /pulsar/apps resource, a Pulsar RUM application
+// whose jobs are measured by the end users' browsers.
+type Application struct {
+	ID       string `json:"appid,omitempty"`
+	Customer int    `json:"customer,omitempty"`
+
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+
+	// BrowserWaitMillis is how long the browser waits before running the
+	// jobs, and JobsPerTransaction how many jobs it runs per page view.
+	BrowserWaitMillis  int `json:"browser_wait_millis,omitempty"`
+	JobsPerTransaction int `json:"jobs_per_transaction,omitempty"`
+}
+
+// NewApplication returns an active application with the given name.
+func NewApplication(name string) *Application {
+	return &Application{Name: name, Active: true}
+}
+
+func (a Application) String() string {
+	return a.Name
+}
@@ -0,0 +1,2 @@
+// Package pulsar contains definitions for NS1 Pulsar applications and jobs.
+package pulsar
@@ -0,0 +1,55 @@
+package pulsar
+
+// Pulsar job types.
+const (
+	JobTypeLatency = "latency"
+	JobTypeCustom  = "custom"
+)
+
+// Job wraps an NS1 /pulsar/apps/{appid}/jobs resource, a measurement of one
+// endpoint (such as a CDN) by an Application.
+type Job struct {
+	ID       string `json:"jobid,omitempty"`
+	AppID    string `json:"appid,omitempty"`
+	Customer int    `json:"customer,omitempty"`
+
+	Name   string `json:"name"`
+	TypeID string `json:"typeid"`
+	Active bool   `json:"active"`
+
+	Shared    bool `json:"shared"`
+	Community bool `json:"community"`
+
+	Config *JobConfig `json:"config,omitempty"`
+}
+
+// JobConfig wraps a Job's "config" attribute, saying what is measured and
+// how. Unset options take the API's defaults.
+type JobConfig struct {
+	Host    *string `json:"host,omitempty"`
+	URLPath *string `json:"url_path,omitempty"`
+	HTTP    *bool   `json:"http,omitempty"`
+	HTTPS   *bool   `json:"https,omitempty"`
+
+	RequestTimeoutMillis *int  `json:"request_timeout_millis,omitempty"`
+	JobTimeoutMillis     *int  `json:"job_timeout_millis,omitempty"`
+	UseXHR               *bool `json:"use_xhr,omitempty"`
+	StaticValues         *bool `json:"static_values,omitempty"`
+}
+
+// NewLatencyJob returns an active latency job of the given application,
+// measuring https://host/urlPath.
+func NewLatencyJob(appID, name, host, urlPath string) *Job {
+	https := true
+	return &Job{
+		AppID:  appID,
+		Name:   name,
+		TypeID: JobTypeLatency,
+		Active: true,
+		Config: &JobConfig{Host: &host, URLPath: &urlPath, HTTPS: &https},
+	}
+}
+
+func (j Job) String() string {
+	return j.Name
+}
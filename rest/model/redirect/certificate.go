@@ -0,0 +1,29 @@
+package redirect
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// Certificate wraps an NS1 /redirect/certificates resource, a certificate NS1
+// obtains and renews for serving redirects of Domain over HTTPS.
+type Certificate struct {
+	ID     string `json:"id,omitempty"`
+	Domain string `json:"domain"`
+
+	// Read-only fields
+	Certificate string      `json:"certificate,omitempty"`
+	Processing  bool        `json:"processing,omitempty"`
+	Errors      string      `json:"errors,omitempty"`
+	ValidFrom   *model.Time `json:"validFrom,omitempty"`
+	ValidUntil  *model.Time `json:"validUntil,omitempty"`
+	LastUpdated *model.Time `json:"lastUpdated,omitempty"`
+}
+
+func (c Certificate) String() string {
+	return c.Domain
+}
+
+// CertificateList is a page of redirect certificates.
+type CertificateList struct {
+	Count   int            `json:"count"`
+	Total   int            `json:"total"`
+	Results []*Certificate `json:"results"`
+}
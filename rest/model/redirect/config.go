@@ -0,0 +1,78 @@
+package redirect
+
+import "gopkg.in/ns1/ns1-go.v2/rest/model"
+
+// ForwardingMode says which part of the request path is forwarded to the
+// target.
+type ForwardingMode string
+
+// Forwarding modes.
+const (
+	// All appends the whole request path to the target.
+	All ForwardingMode = "all"
+	// Capture appends the part of the request path after the config's Path.
+	Capture ForwardingMode = "capture"
+	// None redirects to the target as is.
+	None ForwardingMode = "none"
+)
+
+// ForwardingType is the kind of redirect served.
+type ForwardingType string
+
+// Forwarding types.
+const (
+	// Permanent redirects with a 301.
+	Permanent ForwardingType = "permanent"
+	// Temporary redirects with a 302.
+	Temporary ForwardingType = "temporary"
+	// Masking serves the target in a frame, keeping the source URL.
+	Masking ForwardingType = "masking"
+)
+
+// Configuration wraps an NS1 /redirect resource, redirecting requests for
+// Domain and Path to Target. Domain must have a record pointing at the NS1
+// redirect service for requests to reach it.
+type Configuration struct {
+	// Read-only fields
+	ID          string      `json:"id,omitempty"`
+	LastUpdated *model.Time `json:"lastUpdated,omitempty"`
+
+	Domain string   `json:"domain"`
+	Path   string   `json:"path"`
+	Target string   `json:"target"`
+	Tags   []string `json:"tags"`
+
+	ForwardingMode  ForwardingMode `json:"forwardingMode,omitempty"`
+	ForwardingType  ForwardingType `json:"forwardingType,omitempty"`
+	QueryForwarding *bool          `json:"queryForwarding,omitempty"`
+
+	// HTTPSEnabled serves the redirect over HTTPS too, with the certificate
+	// CertificateID, and HTTPSForced redirects HTTP requests to HTTPS.
+	CertificateID *string `json:"certificateId,omitempty"`
+	HTTPSEnabled  *bool   `json:"httpsEnabled,omitempty"`
+	HTTPSForced   *bool   `json:"httpsForced,omitempty"`
+}
+
+// NewConfiguration returns a permanent redirect of every path of domain to
+// target.
+func NewConfiguration(domain, path, target string) *Configuration {
+	return &Configuration{
+		Domain:         domain,
+		Path:           path,
+		Target:         target,
+		Tags:           []string{},
+		ForwardingMode: All,
+		ForwardingType: Permanent,
+	}
+}
+
+func (c Configuration) String() string {
+	return c.Domain + c.Path + " -> " + c.Target
+}
+
+// ConfigurationList is a page of redirect configurations.
+type ConfigurationList struct {
+	Count   int              `json:"count"`
+	Total   int              `json:"total"`
+	Results []*Configuration `json:"results"`
+}
@@ -0,0 +1,3 @@
+// Package redirect contains definitions for NS1 HTTP redirects and their
+// certificates.
+package redirect
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Time is a timestamp as returned by the NS1 API: Unix epoch seconds,
+// possibly fractional, given either as a JSON number or as a string. A zero,
+// null, empty or absent timestamp decodes to the zero time, and the zero time
+// encodes as 0, so values round-trip. Sub-second precision is kept down to
+// the nanosecond.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns t as a Time.
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+// Unix returns the Time of the given epoch seconds, or the zero time for 0.
+func Unix(sec int64) Time {
+	if sec == 0 {
+		return Time{}
+	}
+	return Time{Time: time.Unix(sec, 0)}
+}
+
+// MarshalJSON encodes the time as epoch seconds.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	s := strconv.FormatInt(t.Unix(), 10)
+	if ns := t.Nanosecond(); ns != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", ns), "0")
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSON decodes epoch seconds given as a number or a string.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	var s string
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(b)
+	}
+
+	parsed, err := parseEpoch(strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s: %v", b, err)
+	}
+	*t = parsed
+	return nil
+}
+
+// parseEpoch parses decimal epoch seconds exactly, and other number forms
+// (e.g. exponents) as floats.
+func parseEpoch(s string) (Time, error) {
+	if s == "" {
+		return Time{}, nil
+	}
+
+	secPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		secPart, fracPart = s[:i], s[i+1:]
+	}
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err == nil && len(fracPart) <= 9 && strings.Trim(fracPart, "0123456789") == "" {
+		ns := int64(0)
+		if fracPart != "" {
+			ns, _ = strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+		}
+		if sec < 0 || strings.HasPrefix(secPart, "-") {
+			ns = -ns
+		}
+		if sec == 0 && ns == 0 {
+			return Time{}, nil
+		}
+		return Time{Time: time.Unix(sec, ns)}, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Time{}, err
+	}
+	if f == 0 {
+		return Time{}, nil
+	}
+	whole, frac := math.Modf(f)
+	return Time{Time: time.Unix(int64(whole), int64(frac*1e9))}, nil
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`1600000000`, time.Unix(1600000000, 0)},
+		{`"1600000000"`, time.Unix(1600000000, 0)},
+		{`1376325771.25`, time.Unix(1376325771, 250000000)},
+		{`" 1376325771.5 "`, time.Unix(1376325771, 500000000)},
+		{`1.6e9`, time.Unix(1600000000, 0)},
+		{`0`, time.Time{}},
+		{`"0"`, time.Time{}},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var got Time
+		require.Nil(t, json.Unmarshal([]byte(tt.in), &got), tt.in)
+		assert.True(t, tt.want.Equal(got.Time), "%s: got %v", tt.in, got)
+	}
+
+	var bad Time
+	assert.NotNil(t, json.Unmarshal([]byte(`"yesterday"`), &bad))
+
+	// Absent fields are zero, and values round-trip.
+	var v struct {
+		At    Time `json:"at"`
+		Other Time `json:"other"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(`{"at": 1376325771.123456789}`), &v))
+	assert.True(t, v.Other.IsZero())
+	b, err := json.Marshal(v)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"at": 1376325771.123456789, "other": 0}`, string(b))
+
+	assert.Equal(t, Time{}, Unix(0))
+	assert.Equal(t, int64(1600000000), Unix(1600000000).Unix())
+}
@@ -0,0 +1,19 @@
+package model
+
+// ValidationWarning is returned by the Validate methods of the models, such
+// as dns.Record and monitor.Job, for configurations that are accepted by the
+// API but are likely mistakes. Callers that only care about hard errors can
+// skip these with IsWarning.
+type ValidationWarning struct {
+	Message string
+}
+
+func (w *ValidationWarning) Error() string {
+	return "warning: " + w.Message
+}
+
+// IsWarning reports whether err is a *ValidationWarning.
+func IsWarning(err error) bool {
+	_, ok := err.(*ValidationWarning)
+	return ok
+}
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+const defaultCatalogTTL = time.Hour
+
+// MonitoringCatalogs holds the monitoring job types and regions, which
+// rarely change and are needed by client-side job validation.
+type MonitoringCatalogs struct {
+	JobTypes  map[string]*monitor.JobType
+	Regions   []*monitor.Region
+	FetchedAt time.Time
+}
+
+// HasJobType reports whether name is a known job type.
+func (mc *MonitoringCatalogs) HasJobType(name string) bool {
+	_, ok := mc.JobTypes[name]
+	return ok
+}
+
+// HasRegion reports whether code is a known monitoring region.
+func (mc *MonitoringCatalogs) HasRegion(code string) bool {
+	for _, r := range mc.Regions {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogCache is shared by pointer between copies of a Client, and is safe
+// for concurrent use.
+type catalogCache struct {
+	mu  sync.Mutex
+	ttl time.Duration
+	cat *MonitoringCatalogs
+
+	// Data source types keyed by type, see DataSourcesService.ValidateConfig.
+	sourceTypes   map[string]*data.SourceType
+	sourceTypesAt time.Time
+
+	// Filter types keyed by type, see RecordsService.ValidateFilters.
+	filterTypes   map[string]*filter.FilterType
+	filterTypesAt time.Time
+}
+
+// SetCatalogTTL sets how long the monitoring catalogs, data source types and
+// filter types are cached before they are fetched again. A ttl of 0 or less
+// caches them until RefreshCatalogs is called.
+func SetCatalogTTL(ttl time.Duration) func(*Client) {
+	return func(c *Client) {
+		c.catalogs.mu.Lock()
+		c.catalogs.ttl = ttl
+		c.catalogs.mu.Unlock()
+	}
+}
+
+// fresh reports whether an entry fetched at the given time is still within
+// the TTL. cc.mu must be held.
+func (cc *catalogCache) fresh(at time.Time) bool {
+	return cc.ttl <= 0 || time.Since(at) < cc.ttl
+}
+
+// Catalogs returns the monitoring job types and regions, fetching them on
+// first use and again once the cached copy is older than the catalog TTL
+// (an hour by default, see SetCatalogTTL). The returned value must not be
+// modified.
+func (s *JobsService) Catalogs() (*MonitoringCatalogs, error) {
+	cc := s.client.catalogs
+	cc.mu.Lock()
+	cat := cc.cat
+	fresh := cat != nil && cc.fresh(cat.FetchedAt)
+	cc.mu.Unlock()
+
+	if fresh {
+		return cat, nil
+	}
+	return s.RefreshCatalogs(context.Background())
+}
+
+// RefreshCatalogs fetches the monitoring job types and regions and replaces
+// the cached copy used by Catalogs. The cached data source types and filter
+// types are dropped, to be fetched again on next use.
+func (s *JobsService) RefreshCatalogs(ctx context.Context) (*MonitoringCatalogs, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/jobtypes", nil)
+	if err != nil {
+		return nil, err
+	}
+	jts := map[string]*monitor.JobType{}
+	if _, err := s.client.Do(req.WithContext(ctx), &jts); err != nil {
+		return nil, err
+	}
+
+	req, err = s.client.NewRequest("GET", "monitoring/regions", nil)
+	if err != nil {
+		return nil, err
+	}
+	regions := []*monitor.Region{}
+	if _, err := s.client.Do(req.WithContext(ctx), &regions); err != nil {
+		return nil, err
+	}
+
+	cat := &MonitoringCatalogs{JobTypes: jts, Regions: regions, FetchedAt: time.Now()}
+
+	cc := s.client.catalogs
+	cc.mu.Lock()
+	cc.cat = cat
+	cc.sourceTypes = nil
+	cc.filterTypes = nil
+	cc.mu.Unlock()
+
+	return cat, nil
+}
+
+// Validate checks mj with monitor.Job.Validate, and its job type and regions
+// against the monitoring catalogs (see Catalogs). All problems found are
+// returned as a MultiError, including *monitor.ValidationWarning values.
+func (s *JobsService) Validate(mj *monitor.Job) error {
+	cat, err := s.Catalogs()
+	if err != nil {
+		return err
+	}
+
+	errs := MultiError(mj.Validate())
+	if mj.Type != "" && !cat.HasJobType(mj.Type) {
+		errs = append(errs, fmt.Errorf("job %s: unknown job_type %q", mj.Name, mj.Type))
+	}
+	for _, r := range mj.Regions {
+		if !cat.HasRegion(r) {
+			errs = append(errs, fmt.Errorf("job %s: unknown region %q", mj.Name, r))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
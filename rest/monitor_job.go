@@ -14,8 +14,8 @@ type JobsService service
 // List returns all monitoring jobs for the account.
 //
 // NS1 API docs: https://ns1.com/api/#jobs-get
-func (s *JobsService) List() ([]*monitor.Job, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "monitoring/jobs", nil)
+func (s *JobsService) List(opts ...RequestOption) ([]*monitor.Job, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/jobs", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -29,13 +29,14 @@ func (s *JobsService) List() ([]*monitor.Job, *http.Response, error) {
 	return mjl, resp, nil
 }
 
-// Get takes an ID and returns details for a specific monitoring job.
+// Get takes an ID and returns details for a specific monitoring job, or
+// ErrJobMissing if there is none.
 //
 // NS1 API docs: https://ns1.com/api/#jobs-jobid-get
-func (s *JobsService) Get(id string) (*monitor.Job, *http.Response, error) {
+func (s *JobsService) Get(id string, opts ...RequestOption) (*monitor.Job, *http.Response, error) {
 	path := fmt.Sprintf("%s/%s", "monitoring/jobs", id)
 
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -43,19 +44,21 @@ func (s *JobsService) Get(id string) (*monitor.Job, *http.Response, error) {
 	var mj monitor.Job
 	resp, err := s.client.Do(req, &mj)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, jobError(err)
 	}
 
 	return &mj, resp, nil
 }
 
-// Create takes a *MonitoringJob and creates a new monitoring job.
+// Create takes a *MonitoringJob and creates a new monitoring job. Use
+// ForNetwork to create it in a specific DDI network; the job's NetworkIDs
+// are read back from the API.
 //
 // NS1 API docs: https://ns1.com/api/#jobs-put
-func (s *JobsService) Create(mj *monitor.Job) (*http.Response, error) {
+func (s *JobsService) Create(mj *monitor.Job, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("%s/%s", "monitoring/jobs", mj.ID)
 
-	req, err := s.client.NewRequest("PUT", path, &mj)
+	req, err := s.client.NewRequest("PUT", path, &mj, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,10 +75,10 @@ func (s *JobsService) Create(mj *monitor.Job) (*http.Response, error) {
 // Update takes a *MonitoringJob and change the configuration details of an existing monitoring job.
 //
 // NS1 API docs: https://ns1.com/api/#jobs-jobid-post
-func (s *JobsService) Update(mj *monitor.Job) (*http.Response, error) {
+func (s *JobsService) Update(mj *monitor.Job, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("%s/%s", "monitoring/jobs", mj.ID)
 
-	req, err := s.client.NewRequest("POST", path, &mj)
+	req, err := s.client.NewRequest("POST", path, &mj, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +86,7 @@ func (s *JobsService) Update(mj *monitor.Job) (*http.Response, error) {
 	// Update mon jobs' fields with data from api(ensure consistent)
 	resp, err := s.client.Do(req, &mj)
 	if err != nil {
-		return resp, err
+		return resp, jobError(err)
 	}
 
 	return resp, nil
@@ -92,17 +95,17 @@ func (s *JobsService) Update(mj *monitor.Job) (*http.Response, error) {
 // Delete takes an ID and immediately terminates and deletes and existing monitoring job.
 //
 // NS1 API docs: https://ns1.com/api/#jobs-jobid-delete
-func (s *JobsService) Delete(id string) (*http.Response, error) {
+func (s *JobsService) Delete(id string, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("%s/%s", "monitoring/jobs", id)
 
-	req, err := s.client.NewRequest("DELETE", path, nil)
+	req, err := s.client.NewRequest("DELETE", path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := s.client.Do(req, nil)
 	if err != nil {
-		return resp, err
+		return resp, jobError(err)
 	}
 
 	return resp, nil
@@ -127,8 +130,170 @@ func (s *JobsService) History(id string, opts ...func(*url.Values)) ([]*monitor.
 	var slgs []*monitor.StatusLog
 	resp, err := s.client.Do(req, &slgs)
 	if err != nil {
-		return nil, resp, err
+		return nil, resp, jobError(err)
 	}
 
 	return slgs, resp, nil
 }
+
+// JobTypes returns the available monitoring job types, keyed by name.
+//
+// NS1 API docs: https://ns1.com/api/#jobtypes-get
+func (s *JobsService) JobTypes() (map[string]*monitor.JobType, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/jobtypes", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jts := map[string]*monitor.JobType{}
+	resp, err := s.client.Do(req, &jts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return jts, resp, nil
+}
+
+// Regions returns the available monitoring regions.
+//
+// NS1 API docs: https://ns1.com/api/#regions-get
+func (s *JobsService) Regions() ([]*monitor.Region, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/regions", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	regions := []*monitor.Region{}
+	resp, err := s.client.Do(req, &regions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return regions, resp, nil
+}
+
+// NotifyListJobs is a notify list with the monitoring jobs alerting to it,
+// see JobsService.ListByNotifyList.
+type NotifyListJobs struct {
+	// List is nil for jobs without a notify list (keyed by ""), and for
+	// jobs referencing a list that no longer exists.
+	List *monitor.NotifyList
+	Jobs []*monitor.Job
+}
+
+// ListByNotifyList returns all monitoring jobs grouped by the id of the
+// notify list they alert to, with the list details, e.g. to audit which
+// monitors alert a given channel. Notify lists without jobs are included
+// with no jobs. Jobs and lists are fetched concurrently, both with opts.
+func (s *JobsService) ListByNotifyList(opts ...RequestOption) (map[string]*NotifyListJobs, *http.Response, error) {
+	var (
+		jobs     []*monitor.Job
+		lists    []*monitor.NotifyList
+		jobsResp *http.Response
+		listResp *http.Response
+		jobsErr  error
+		listErr  error
+	)
+	parallel(2, 2, func(i int) {
+		if i == 0 {
+			jobs, jobsResp, jobsErr = s.List(opts...)
+		} else {
+			lists, listResp, listErr = s.client.Notifications.List(opts...)
+		}
+	})
+	if jobsErr != nil {
+		return nil, jobsResp, jobsErr
+	}
+	if listErr != nil {
+		return nil, listResp, listErr
+	}
+
+	grouped := make(map[string]*NotifyListJobs, len(lists))
+	for _, l := range lists {
+		grouped[l.ID] = &NotifyListJobs{List: l, Jobs: []*monitor.Job{}}
+	}
+	for _, j := range jobs {
+		g, ok := grouped[j.NotifyListID]
+		if !ok {
+			g = &NotifyListJobs{Jobs: []*monitor.Job{}}
+			grouped[j.NotifyListID] = g
+		}
+		g.Jobs = append(g.Jobs, j)
+	}
+	return grouped, listResp, nil
+}
+
+// CreateWithNotifyLists creates monitoring jobs along with the notify lists
+// they alert to, for promoting monitoring config between accounts where ids
+// differ. The jobs reference their lists by name in NotifyListID; each list
+// is created (or updated, if the account has a list of the same name), and
+// once all lists exist the references are rewritten to the lists' ids and
+// the jobs are created.
+//
+// It returns the ids of the lists by name. Jobs referencing a list that is
+// neither given nor in the account are reported before anything is written.
+func (s *JobsService) CreateWithNotifyLists(lists []*monitor.NotifyList, jobs []*monitor.Job) (map[string]string, *http.Response, error) {
+	existing, resp, err := s.client.Notifications.List()
+	if err != nil {
+		return nil, resp, err
+	}
+	ids := make(map[string]string, len(existing)+len(lists))
+	for _, l := range existing {
+		ids[l.Name] = l.ID
+	}
+
+	var errs MultiError
+	known := make(map[string]bool, len(lists))
+	for _, l := range lists {
+		known[l.Name] = true
+	}
+	for _, j := range jobs {
+		if _, ok := ids[j.NotifyListID]; j.NotifyListID != "" && !ok && !known[j.NotifyListID] {
+			errs = append(errs, fmt.Errorf("job %s: unknown notify list %q", j.Name, j.NotifyListID))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, resp, errs
+	}
+
+	mapping := make(map[string]string, len(lists))
+	for _, l := range lists {
+		if id, ok := ids[l.Name]; ok {
+			l.ID = id
+			resp, err = s.client.Notifications.Update(l)
+		} else {
+			resp, err = s.client.Notifications.Create(l)
+		}
+		if err != nil {
+			return mapping, resp, err
+		}
+		ids[l.Name] = l.ID
+		mapping[l.Name] = l.ID
+	}
+
+	for _, j := range jobs {
+		if j.NotifyListID != "" {
+			if _, ok := mapping[j.NotifyListID]; !ok {
+				mapping[j.NotifyListID] = ids[j.NotifyListID]
+			}
+			j.NotifyListID = ids[j.NotifyListID]
+		}
+		if resp, err = s.Create(j); err != nil {
+			return mapping, resp, err
+		}
+	}
+	return mapping, resp, nil
+}
+
+// jobError maps a 404 for a monitoring job to ErrJobMissing.
+func jobError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrJobMissing
+	}
+	return err
+}
+
+var (
+	// ErrJobMissing bundles GET/POST/DELETE error.
+	ErrJobMissing = missingError("monitoring job does not exist")
+)
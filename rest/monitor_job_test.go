@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestJobsCatalogs(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		switch r.URL.Path {
+		case "/monitoring/jobtypes":
+			w.Write([]byte(`{"http":{"shortdesc":"HTTP/HTTPS","config":{},"results":{"status_code":{"type":"number","comparators":["==","!="]}}},"ping":{"shortdesc":"Ping"}}`)) // nolint: errcheck
+		case "/monitoring/regions":
+			w.Write([]byte(`[{"code":"lga","name":"New York","subnets":["1.2.3.0/24"]},{"code":"sin","name":"Singapore"}]`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	jts, _, err := c.Jobs.JobTypes()
+	require.Nil(t, err)
+	assert.Equal(t, []string{"==", "!="}, jts["http"].Results["status_code"].Comparators)
+	regions, _, err := c.Jobs.Regions()
+	require.Nil(t, err)
+	assert.Equal(t, "lga", regions[0].Code)
+	atomic.StoreInt32(&hits, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cat, err := c.Jobs.Catalogs()
+			assert.Nil(t, err)
+			assert.True(t, cat.HasJobType("ping"))
+			assert.True(t, cat.HasRegion("sin"))
+			assert.False(t, cat.HasRegion("xyz"))
+		}()
+	}
+	wg.Wait()
+	// Concurrent first calls may each fetch, later ones use the cache.
+	fetched := atomic.LoadInt32(&hits)
+	_, err = c.Jobs.Catalogs()
+	require.Nil(t, err)
+	assert.Equal(t, fetched, atomic.LoadInt32(&hits))
+
+	_, err = c.Jobs.RefreshCatalogs(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, fetched+2, atomic.LoadInt32(&hits))
+
+	// An expired cache is fetched again.
+	c = NewClient(nil, SetEndpoint(ts.URL+"/"), SetCatalogTTL(time.Nanosecond))
+	atomic.StoreInt32(&hits, 0)
+	_, err = c.Jobs.Catalogs()
+	require.Nil(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = c.Jobs.Catalogs()
+	require.Nil(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+
+	mj := &monitor.Job{Name: "web", Type: "tcp", Frequency: 60, Policy: "quorum", Regions: []string{"lga", "xyz"}}
+	err = c.Jobs.Validate(mj)
+	require.NotNil(t, err)
+	errs, ok := err.(MultiError)
+	require.True(t, ok)
+	require.Len(t, errs, 3)
+	assert.True(t, monitor.IsWarning(errs[0]))
+	// The models share their warning type.
+	assert.True(t, dns.IsWarning(errs[0]))
+	assert.Equal(t, `job web: unknown job_type "tcp"`, errs[1].Error())
+	assert.Equal(t, `job web: unknown region "xyz"`, errs[2].Error())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.Jobs.RefreshCatalogs(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestJobsForNetwork(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/monitoring/jobs/", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("networks"))
+		w.Write([]byte(`{"id": "j1", "job_type": "ping", "networks": [2]}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	mj := &monitor.Job{Type: "ping", NetworkIDs: []int{2}}
+	_, err := c.Jobs.Create(mj, ForNetwork(2))
+	require.Nil(t, err)
+	assert.Equal(t, "j1", mj.ID)
+	assert.Equal(t, []int{2}, mj.NetworkIDs)
+}
+
+func TestJobsMissing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "unknown job"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	_, _, err := c.Jobs.Get("gone")
+	assert.Equal(t, ErrJobMissing, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = c.Jobs.Update(&monitor.Job{ID: "gone"})
+	assert.Equal(t, ErrJobMissing, err)
+	_, err = c.Jobs.Delete("gone")
+	assert.Equal(t, ErrJobMissing, err)
+	_, _, err = c.Jobs.History("gone")
+	assert.Equal(t, ErrJobMissing, err)
+}
+
+func TestJobsListByNotifyList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/monitoring/jobs":
+			w.Write([]byte(`[{"id":"j1","notify_list":"l1"},{"id":"j2","notify_list":"l1"},{"id":"j3","notify_list":"gone"},{"id":"j4","notify_list":""}]`)) // nolint: errcheck
+		case "/lists":
+			w.Write([]byte(`[{"id":"l1","name":"ops"},{"id":"l2","name":"unused"}]`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	grouped, _, err := c.Jobs.ListByNotifyList()
+	require.Nil(t, err)
+	require.Len(t, grouped, 4)
+	assert.Equal(t, "ops", grouped["l1"].List.Name)
+	assert.Len(t, grouped["l1"].Jobs, 2)
+	assert.Empty(t, grouped["l2"].Jobs)
+	assert.Nil(t, grouped["gone"].List)
+	assert.Equal(t, "j3", grouped["gone"].Jobs[0].ID)
+	assert.Equal(t, "j4", grouped[""].Jobs[0].ID)
+}
+
+func TestJobsCreateWithNotifyLists(t *testing.T) {
+	var created []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/lists":
+			w.Write([]byte(`[{"id":"l-ops","name":"ops"},{"id":"l-legacy","name":"legacy"}]`)) // nolint: errcheck
+		case r.Method == "PUT" && r.URL.Path == "/lists":
+			w.Write([]byte(`{"id":"l-oncall","name":"oncall"}`)) // nolint: errcheck
+		case r.Method == "POST" && r.URL.Path == "/lists/l-ops":
+			w.Write([]byte(`{"id":"l-ops","name":"ops"}`)) // nolint: errcheck
+		case r.Method == "PUT" && r.URL.Path == "/monitoring/jobs/":
+			var mj monitor.Job
+			if err := json.NewDecoder(r.Body).Decode(&mj); err != nil {
+				t.Errorf("decode job: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			created = append(created, mj.Name+"="+mj.NotifyListID)
+			json.NewEncoder(w).Encode(mj) // nolint: errcheck
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	lists := []*monitor.NotifyList{
+		monitor.NewNotifyList("oncall", monitor.NewEmailNotification("oncall@example.com")),
+		monitor.NewNotifyList("ops", monitor.NewEmailNotification("ops@example.com")),
+	}
+	jobs := []*monitor.Job{
+		{Name: "web", NotifyListID: "oncall"},
+		{Name: "api", NotifyListID: "ops"},
+		{Name: "old", NotifyListID: "legacy"},
+		{Name: "quiet"},
+	}
+	mapping, _, err := c.Jobs.CreateWithNotifyLists(lists, jobs)
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{"oncall": "l-oncall", "ops": "l-ops", "legacy": "l-legacy"}, mapping)
+	assert.Equal(t, []string{"web=l-oncall", "api=l-ops", "old=l-legacy", "quiet="}, created)
+
+	// Unknown lists are reported before anything is created.
+	created = nil
+	_, _, err = c.Jobs.CreateWithNotifyLists(nil, []*monitor.Job{{Name: "web", NotifyListID: "nope"}})
+	require.NotNil(t, err)
+	assert.Equal(t, `1 error(s): job web: unknown notify list "nope"`, err.Error())
+	assert.Empty(t, created)
+}
@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,8 +13,8 @@ type NotificationsService service
 // List returns all configured notification lists.
 //
 // NS1 API docs: https://ns1.com/api/#lists-get
-func (s *NotificationsService) List() ([]*monitor.NotifyList, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "lists", nil)
+func (s *NotificationsService) List(opts ...RequestOption) ([]*monitor.NotifyList, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "lists", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -43,13 +42,7 @@ func (s *NotificationsService) Get(listID string) (*monitor.NotifyList, *http.Re
 	var nl monitor.NotifyList
 	resp, err := s.client.Do(req, &nl)
 	if err != nil {
-		switch err.(type) {
-		case *Error:
-			if err.(*Error).Message == "unknown notification list" {
-				return nil, resp, ErrListMissing
-			}
-		}
-		return nil, resp, err
+		return nil, resp, listError(err)
 	}
 
 	return &nl, resp, nil
@@ -93,7 +86,7 @@ func (s *NotificationsService) Update(nl *monitor.NotifyList) (*http.Response, e
 	// Update mon lists' fields with data from api(ensure consistent)
 	resp, err := s.client.Do(req, &nl)
 	if err != nil {
-		return resp, err
+		return resp, listError(err)
 	}
 
 	return resp, nil
@@ -112,15 +105,27 @@ func (s *NotificationsService) Delete(listID string) (*http.Response, error) {
 
 	resp, err := s.client.Do(req, nil)
 	if err != nil {
-		return resp, err
+		return resp, listError(err)
 	}
 
 	return resp, nil
 }
 
+// listError maps the API's error for an unknown notify list to
+// ErrListMissing.
+func listError(err error) error {
+	switch err.(type) {
+	case *Error:
+		if err.(*Error).Message == "unknown notification list" {
+			return ErrListMissing
+		}
+	}
+	return err
+}
+
 var (
 	// ErrListExists bundles PUT create error.
-	ErrListExists = errors.New("notify List already exists")
+	ErrListExists = existsError("notify List already exists")
 	// ErrListMissing bundles GET/POST/DELETE error.
-	ErrListMissing = errors.New("notify List does not exist")
+	ErrListMissing = missingError("notify List does not exist")
 )
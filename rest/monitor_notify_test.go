@@ -0,0 +1,28 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestNotificationsMissing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "unknown notification list"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	_, _, err := c.Notifications.Get("gone")
+	assert.Equal(t, ErrListMissing, err)
+	_, err = c.Notifications.Update(&monitor.NotifyList{ID: "gone"})
+	assert.Equal(t, ErrListMissing, err)
+	_, err = c.Notifications.Delete("gone")
+	assert.Equal(t, ErrListMissing, err)
+}
@@ -13,8 +13,8 @@ type OptionDefService service
 // List returns a list of all option definitions.
 //
 // NS1 API docs: https://ns1.com/api#getlist-dhcp-option-definitions
-func (s *OptionDefService) List() ([]dhcp.OptionDef, *http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "dhcp/optiondef", nil)
+func (s *OptionDefService) List(opts ...RequestOption) ([]dhcp.OptionDef, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "dhcp/optiondef", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
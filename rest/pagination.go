@@ -0,0 +1,301 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// Links returns the parsed Link header of the response. Like
+// DoWithPagination, HTTP links are rewritten to HTTPS if the request was
+// sent over HTTPS.
+func (r Response) Links() Links {
+	forceHTTPS := r.Request != nil && r.Request.URL.Scheme == "https"
+	return ParseLink(r.Header.Get("Link"), forceHTTPS)
+}
+
+// NextURI returns the URI of the next page, or "" on the last page.
+func (r Response) NextURI() string {
+	return r.Links().Next()
+}
+
+// Cursor returns the cursor of the next page, i.e. the "after" query
+// parameter of its URI, or "" on the last page.
+func (r Response) Cursor() string {
+	u, err := url.Parse(r.NextURI())
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("after")
+}
+
+// DoAll is Do for list endpoints: v must point to a slice, and the elements
+// of every page are appended to it, following the "next" Link header of each
+// response until the last page. Each page is requested with the headers and
+// context of req and goes through Do, so rate limiting, retries and error
+// handling apply as for a single page. On error the pages read so far are
+// kept in v, and the failing response is returned; otherwise the response of
+// the last page is.
+func (c *Client) DoAll(req *http.Request, v interface{}) (*http.Response, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("DoAll: expected a pointer to a slice, got %T", v)
+	}
+	slice := rv.Elem()
+
+	for {
+		page := reflect.New(slice.Type())
+		resp, err := c.Do(req, page.Interface())
+		if err != nil {
+			return resp, err
+		}
+		slice.Set(reflect.AppendSlice(slice, page.Elem()))
+
+		next := Response{Response: resp}.NextURI()
+		if next == "" {
+			return resp, nil
+		}
+		u, err := req.URL.Parse(next)
+		if err != nil {
+			return resp, err
+		}
+		req = nextPageRequest(req, u)
+	}
+}
+
+// nextPageRequest returns a GET request of u with the headers and context of
+// req.
+func nextPageRequest(req *http.Request, u *url.URL) *http.Request {
+	next := req.Clone(req.Context())
+	next.Method = "GET"
+	next.URL = u
+	next.Host = ""
+	next.Body = http.NoBody
+	next.GetBody = nil
+	next.ContentLength = 0
+	return next
+}
+
+// ListOptions selects the first page of a paginated listing, see
+// ZonesService.ListPages. Zero fields are left to the API's defaults. The
+// List methods take them as a RequestOption, see Option.
+type ListOptions struct {
+	// Limit is the number of items per page.
+	Limit int
+	// Offset is the number of items to skip, for the endpoints paging by
+	// offset rather than cursor.
+	Offset int
+	// After is the cursor of the page to start from, as returned by
+	// Response.Cursor or ZoneList.Cursor, e.g. to resume an earlier listing.
+	After string
+}
+
+// Option returns a RequestOption setting the query parameters of o, for the
+// List methods, e.g.
+//
+//	zones, _, err := client.Zones.List((&rest.ListOptions{Limit: 100}).Option())
+//
+// Server-side filters are passed the same way with WithParam or WithParams.
+// With FollowPagination, the following pages are requested as given by the
+// Link header of each response.
+func (o *ListOptions) Option() RequestOption {
+	return WithParams(o.params())
+}
+
+// WithParam sets the query parameter key of a request to value, e.g. a
+// server-side filter of a list endpoint.
+func WithParam(key, value string) RequestOption {
+	return WithParams(url.Values{key: {value}})
+}
+
+// WithValues sets the query parameters of a request with the given
+// functions, e.g. SetTimeParam("start", t), as taken by the methods with
+// query options of their own.
+func WithValues(opts ...func(*url.Values)) RequestOption {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(&v)
+	}
+	return WithParams(v)
+}
+
+// params returns the query parameters of the options.
+func (o *ListOptions) params() url.Values {
+	params := url.Values{}
+	if o == nil {
+		return params
+	}
+	if o.Limit > 0 {
+		params.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		params.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.After != "" {
+		params.Set("after", o.After)
+	}
+	return params
+}
+
+// pager requests the pages of a listing one at a time, following the "next"
+// Link header of each response.
+type pager struct {
+	client *Client
+	next   string // URI of the next page, "" after the last one
+	resp   *http.Response
+	err    error
+}
+
+// fetch decodes the next page into v, returning false after the last page
+// or on error.
+func (p *pager) fetch(v interface{}) bool {
+	if p.err != nil || p.next == "" {
+		return false
+	}
+	p.resp, p.err = p.client.getURI(v, p.next)
+	if p.err != nil {
+		return false
+	}
+	p.next = Response{Response: p.resp}.NextURI()
+	return true
+}
+
+// cursor returns the cursor of the page after the current one.
+func (p *pager) cursor() string {
+	if p.resp == nil {
+		return ""
+	}
+	return Response{Response: p.resp}.Cursor()
+}
+
+// ZoneList iterates over the pages of the account's zones, requesting each
+// page as Next is called, regardless of the client's FollowPagination:
+//
+//	zl := client.Zones.ListPages(&rest.ListOptions{Limit: 500})
+//	for zl.Next() {
+//		for _, z := range zl.Zones() {
+//			...
+//		}
+//	}
+//	if err := zl.Err(); err != nil {
+//		...
+//	}
+type ZoneList struct {
+	pager
+	zones []*dns.Zone
+}
+
+// ListPages returns a ZoneList starting at the page selected by opts, which
+// may be nil for the first page with the API's default size.
+//
+// NS1 API docs: https://ns1.com/api/#zones-get
+func (s *ZonesService) ListPages(opts *ListOptions) *ZoneList {
+	uri := "zones"
+	if params := opts.params(); len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+	return &ZoneList{pager: pager{client: s.client, next: uri}}
+}
+
+// Next requests the next page, returning false after the last page or on
+// error, see Err.
+func (l *ZoneList) Next() bool {
+	l.zones = nil
+	zones := []*dns.Zone{}
+	if !l.fetch(&zones) {
+		return false
+	}
+	l.zones = zones
+	return true
+}
+
+// Zones returns the zones of the current page.
+func (l *ZoneList) Zones() []*dns.Zone {
+	return l.zones
+}
+
+// Cursor returns the cursor of the page after the current one, or "" on the
+// last page; pass it as ListOptions.After to resume from there.
+func (l *ZoneList) Cursor() string {
+	return l.cursor()
+}
+
+// Response returns the response of the current page, or of the failed
+// request after an error.
+func (l *ZoneList) Response() *http.Response {
+	return l.resp
+}
+
+// Err returns the error that ended the iteration, if any.
+func (l *ZoneList) Err() error {
+	return l.err
+}
+
+// ZoneRecordList iterates over the pages of a zone's records, see ZoneList.
+// Each page is the zone with a page of its records.
+type ZoneRecordList struct {
+	pager
+	zone *dns.Zone
+}
+
+// RecordPages returns a ZoneRecordList of zone's records starting at the
+// page selected by opts, which may be nil. A missing zone ends the
+// iteration with ErrZoneMissing.
+//
+// NS1 API docs: https://ns1.com/api/#zones-zone-get
+func (s *ZonesService) RecordPages(zone string, opts *ListOptions) *ZoneRecordList {
+	uri := fmt.Sprintf("zones/%s", zone)
+	if params := opts.params(); len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+	return &ZoneRecordList{pager: pager{client: s.client, next: uri}}
+}
+
+// Next requests the next page, returning false after the last page or on
+// error, see Err.
+func (l *ZoneRecordList) Next() bool {
+	l.zone = nil
+	var z dns.Zone
+	if !l.fetch(&z) {
+		if e, ok := l.err.(*Error); ok && e.Message == "zone not found" {
+			l.err = ErrZoneMissing
+		}
+		return false
+	}
+	l.zone = &z
+	return true
+}
+
+// Zone returns the zone of the current page.
+func (l *ZoneRecordList) Zone() *dns.Zone {
+	return l.zone
+}
+
+// Records returns the records of the current page.
+func (l *ZoneRecordList) Records() []*dns.ZoneRecord {
+	if l.zone == nil {
+		return nil
+	}
+	return l.zone.Records
+}
+
+// Cursor returns the cursor of the page after the current one, or "" on the
+// last page.
+func (l *ZoneRecordList) Cursor() string {
+	return l.cursor()
+}
+
+// Response returns the response of the current page, or of the failed
+// request after an error.
+func (l *ZoneRecordList) Response() *http.Response {
+	return l.resp
+}
+
+// Err returns the error that ended the iteration, if any.
+func (l *ZoneRecordList) Err() error {
+	return l.err
+}
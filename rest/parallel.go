@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Task is a unit of work of Client.Go, e.g. one or a few SDK calls made
+// with the given client.
+type Task func(c *Client) error
+
+// TaskError is the failure of a task of Client.Go, with the index of the
+// task in the call.
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the task.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// Go runs tasks on at most concurrency goroutines (1 if it is not
+// positive), e.g. for a bulk migration, and returns once all are done.
+//
+// Each task is given a client scoped to ctx, as by WithContext, which paces
+// its requests with RateLimitStrategyConcurrent(concurrency) in place of
+// the client's strategy, so that the workers share the rate limit budget
+// rather than each sleeping as if it were alone; the SharedLimiter, retries
+// and other settings are the client's. Tasks are independent: a failure
+// doesn't stop the others, but once ctx is done the remaining ones fail with
+// its error without being run.
+//
+// The error is a MultiError of a *TaskError for each failed task, in order,
+// or nil if all succeeded.
+func (c *Client) Go(ctx context.Context, concurrency int, tasks ...Task) error {
+	scoped := c.WithContext(ctx)
+	scoped.RateLimitStrategyConcurrent(concurrency)
+
+	errs := make([]error, len(tasks))
+	parallel(len(tasks), concurrency, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		errs[i] = tasks[i](scoped)
+	})
+
+	var failed MultiError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &TaskError{Index: i, Err: err})
+		}
+	}
+	if len(failed) > 0 {
+		return failed
+	}
+	return nil
+}
+
+// parallel calls fn for each index in [0, n) using at most workers
+// goroutines, and returns once all calls are done. Requests made by fn still
+// go through the client's SharedLimiter and RateLimitFunc, so workers should
+// match the parallelism given to RateLimitStrategyConcurrent.
+func parallel(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+}
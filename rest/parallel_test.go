@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Go(t *testing.T) {
+	var inFlight, maxInFlight int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/zones/missing.com" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`))
+			return
+		}
+		w.Write([]byte(`{"zone": "example.com"}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	var tasks []Task
+	for i := 0; i < 8; i++ {
+		zone := "example.com"
+		if i == 5 {
+			zone = "missing.com"
+		}
+		tasks = append(tasks, func(c *Client) error {
+			_, _, err := c.Zones.Get(zone)
+			return err
+		})
+	}
+
+	err := client.Go(context.Background(), 3, tasks...)
+	require.IsType(t, MultiError{}, err)
+	require.Len(t, err.(MultiError), 1)
+	var te *TaskError
+	require.True(t, errors.As(err.(MultiError)[0], &te))
+	assert.Equal(t, 5, te.Index)
+	assert.Equal(t, ErrZoneMissing, te.Err)
+	assert.True(t, maxInFlight <= 3)
+	assert.EqualValues(t, 8, client.RequestStats().Attempts)
+
+	// The client's own strategy is left alone.
+	assert.Nil(t, client.RateLimitContextFunc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.Go(ctx, 2, tasks[:2]...)
+	require.IsType(t, MultiError{}, err)
+	assert.Len(t, err.(MultiError), 2)
+	assert.True(t, errors.Is(err.(MultiError)[1], context.Canceled))
+
+	assert.Nil(t, client.Go(context.Background(), 2))
+}
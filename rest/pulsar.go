@@ -0,0 +1,233 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+// ApplicationsService handles 'pulsar/apps' endpoint.
+type ApplicationsService service
+
+// List returns all Pulsar applications of the account.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-get
+func (s *ApplicationsService) List(opts ...RequestOption) ([]*pulsar.Application, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "pulsar/apps", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	al := []*pulsar.Application{}
+	resp, err := s.client.Do(req, &al)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return al, resp, nil
+}
+
+// Get takes an application id and returns the application.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-get
+func (s *ApplicationsService) Get(id string) (*pulsar.Application, *http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s", id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var a pulsar.Application
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return nil, resp, appError(err)
+	}
+
+	return &a, resp, nil
+}
+
+// Create takes an *Application and creates a new Pulsar application. Its ID
+// is set from the response.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-put
+func (s *ApplicationsService) Create(a *pulsar.Application) (*http.Response, error) {
+	req, err := s.client.NewRequest("PUT", "pulsar/apps", &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update application fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Update takes an *Application and modifies the application.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-post
+func (s *ApplicationsService) Update(a *pulsar.Application) (*http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s", a.ID)
+
+	req, err := s.client.NewRequest("POST", path, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update application fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &a)
+	if err != nil {
+		return resp, appError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes an application id and deletes the application and its jobs.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-delete
+func (s *ApplicationsService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s", id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, appError(err)
+	}
+
+	return resp, nil
+}
+
+// PulsarJobsService handles 'pulsar/apps/APPID/jobs' endpoint.
+type PulsarJobsService service
+
+// List takes an application id and returns all jobs of the application.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-jobs-get
+func (s *PulsarJobsService) List(appID string, opts ...RequestOption) ([]*pulsar.Job, *http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s/jobs", appID)
+
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jl := []*pulsar.Job{}
+	resp, err := s.client.Do(req, &jl)
+	if err != nil {
+		return nil, resp, appError(err)
+	}
+
+	return jl, resp, nil
+}
+
+// Get takes an application id and a job id and returns the job.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-jobs-jobid-get
+func (s *PulsarJobsService) Get(appID, jobID string) (*pulsar.Job, *http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s/jobs/%s", appID, jobID)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var j pulsar.Job
+	resp, err := s.client.Do(req, &j)
+	if err != nil {
+		return nil, resp, pulsarJobError(err)
+	}
+
+	return &j, resp, nil
+}
+
+// Create takes a *Job and creates a new job for its application. The job's
+// ID is set from the response.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-jobs-put
+func (s *PulsarJobsService) Create(j *pulsar.Job) (*http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s/jobs", j.AppID)
+
+	req, err := s.client.NewRequest("PUT", path, &j)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update job fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &j)
+	if err != nil {
+		return resp, appError(err)
+	}
+
+	return resp, nil
+}
+
+// Update takes a *Job and modifies the job.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-jobs-jobid-post
+func (s *PulsarJobsService) Update(j *pulsar.Job) (*http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s/jobs/%s", j.AppID, j.ID)
+
+	req, err := s.client.NewRequest("POST", path, &j)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update job fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &j)
+	if err != nil {
+		return resp, pulsarJobError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes an application id and a job id and deletes the job.
+//
+// NS1 API docs: https://ns1.com/api/#pulsar-apps-appid-jobs-jobid-delete
+func (s *PulsarJobsService) Delete(appID, jobID string) (*http.Response, error) {
+	path := fmt.Sprintf("pulsar/apps/%s/jobs/%s", appID, jobID)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, pulsarJobError(err)
+	}
+
+	return resp, nil
+}
+
+// appError maps a 404 for a Pulsar application to ErrAppMissing.
+func appError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrAppMissing
+	}
+	return err
+}
+
+// pulsarJobError maps a 404 for a Pulsar job to ErrPulsarJobMissing.
+func pulsarJobError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrPulsarJobMissing
+	}
+	return err
+}
+
+var (
+	// ErrAppMissing bundles GET/POST/DELETE error.
+	ErrAppMissing = missingError("pulsar application does not exist")
+	// ErrPulsarJobMissing bundles GET/POST/DELETE error.
+	ErrPulsarJobMissing = missingError("pulsar job does not exist")
+)
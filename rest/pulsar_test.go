@@ -0,0 +1,67 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/pulsar"
+)
+
+func TestPulsar(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("Applications", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		app := pulsar.NewApplication("web")
+		created := &pulsar.Application{ID: "app-1", Name: "web", Active: true, BrowserWaitMillis: 5000}
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/pulsar/apps", http.StatusOK, nil, nil, app, created))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/pulsar/apps", http.StatusOK, nil, nil, "",
+			[]*pulsar.Application{created}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/pulsar/apps/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "application not found"}`))
+
+		_, err := client.Applications.Create(app)
+		require.Nil(t, err)
+		require.Equal(t, "app-1", app.ID)
+
+		al, _, err := client.Applications.List()
+		require.Nil(t, err)
+		require.Equal(t, []*pulsar.Application{created}, al)
+
+		_, _, err = client.Applications.Get("gone")
+		require.Equal(t, api.ErrAppMissing, err)
+	})
+
+	t.Run("Jobs", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		job := pulsar.NewLatencyJob("app-1", "cdn-a", "cdn-a.example.com", "/pulsar.gif")
+		created := *job
+		created.ID = "job-1"
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/pulsar/apps/app-1/jobs", http.StatusOK, nil, nil, job, &created))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/pulsar/apps/app-1/jobs", http.StatusOK, nil, nil, "",
+			[]*pulsar.Job{&created}))
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "/pulsar/apps/app-1/jobs/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "job not found"}`))
+
+		_, err := client.PulsarJobs.Create(job)
+		require.Nil(t, err)
+		require.Equal(t, "job-1", job.ID)
+
+		jl, _, err := client.PulsarJobs.List("app-1")
+		require.Nil(t, err)
+		require.Len(t, jl, 1)
+		require.Equal(t, "cdn-a.example.com", *jl[0].Config.Host)
+
+		_, err = client.PulsarJobs.Delete("app-1", "gone")
+		require.Equal(t, api.ErrPulsarJobMissing, err)
+	})
+}
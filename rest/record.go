@@ -1,10 +1,14 @@
 package rest
 
 import (
-	"errors"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
 
@@ -12,12 +16,13 @@ import (
 type RecordsService service
 
 // Get takes a zone, domain and record type t and returns full configuration for a DNS record.
+// Pass ForNetwork to read the variant of the record for a single DDI network.
 //
 // NS1 API docs: https://ns1.com/api/#record-get
-func (s *RecordsService) Get(zone, domain, t string) (*dns.Record, *http.Response, error) {
+func (s *RecordsService) Get(zone, domain, t string, opts ...RequestOption) (*dns.Record, *http.Response, error) {
 	path := fmt.Sprintf("zones/%s/%s/%s", zone, domain, t)
 
-	req, err := s.client.NewRequest("GET", path, nil)
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -39,12 +44,14 @@ func (s *RecordsService) Get(zone, domain, t string) (*dns.Record, *http.Respons
 
 // Create takes a *Record and creates a new DNS record in the specified zone, for the specified domain, of the given record type.
 //
-// The given record must have at least one answer.
+// The given record must have at least one answer. Pass ForNetwork to create
+// the variant of the record for a single DDI network, and WithReadYourWrites
+// to return only once the record can be read back.
 // NS1 API docs: https://ns1.com/api/#record-put
-func (s *RecordsService) Create(r *dns.Record) (*http.Response, error) {
+func (s *RecordsService) Create(r *dns.Record, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("zones/%s/%s/%s", r.Zone, r.Domain, r.Type)
 
-	req, err := s.client.NewRequest("PUT", path, &r)
+	req, err := s.client.NewRequest("PUT", path, &r, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,17 +71,22 @@ func (s *RecordsService) Create(r *dns.Record) (*http.Response, error) {
 		return resp, err
 	}
 
+	if awaitResp, err := s.awaitRecord(req, r, opts); err != nil {
+		return awaitResp, err
+	}
+
 	return resp, nil
 }
 
 // Update takes a *Record and modifies configuration details for an existing DNS record.
 //
-// Only the fields to be updated are required in the given record.
+// Only the fields to be updated are required in the given record. Pass
+// WithReadYourWrites to return only once the update can be read back.
 // NS1 API docs: https://ns1.com/api/#record-post
-func (s *RecordsService) Update(r *dns.Record) (*http.Response, error) {
+func (s *RecordsService) Update(r *dns.Record, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("zones/%s/%s/%s", r.Zone, r.Domain, r.Type)
 
-	req, err := s.client.NewRequest("POST", path, &r)
+	req, err := s.client.NewRequest("POST", path, &r, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -96,16 +108,67 @@ func (s *RecordsService) Update(r *dns.Record) (*http.Response, error) {
 		return resp, err
 	}
 
+	if awaitResp, err := s.awaitRecord(req, r, opts); err != nil {
+		return awaitResp, err
+	}
+
 	return resp, nil
 }
 
+// ReplaceAnswers replaces the answers of an existing record, leaving its
+// other fields (TTL, filters, metadata, ...) untouched, and returns the
+// updated record.
+//
+// NS1 has no answers-only endpoint, but record updates only change the
+// fields sent, so this is a single record update sending just the answers:
+// there is no read-modify-write round trip to race with other changes.
+// NS1 API docs: https://ns1.com/api/#record-post
+func (s *RecordsService) ReplaceAnswers(zone, domain, t string, answers []*dns.Answer, opts ...RequestOption) (*dns.Record, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s/%s/%s", zone, domain, t)
+
+	if answers == nil {
+		answers = []*dns.Answer{}
+	}
+	// Marshal through a record for any type specific answer encoding.
+	b, err := json.Marshal(&dns.Record{Zone: zone, Domain: domain, Type: t, Answers: answers})
+	if err != nil {
+		return nil, nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("POST", path, map[string]json.RawMessage{"answers": fields["answers"]}, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var r dns.Record
+	resp, err := s.client.Do(req, &r)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			switch err.(*Error).Message {
+			case "zone not found":
+				return nil, resp, ErrZoneMissing
+			case "record not found":
+				return nil, resp, ErrRecordMissing
+			}
+		}
+		return nil, resp, err
+	}
+
+	return &r, resp, nil
+}
+
 // Delete takes a zone, domain and record type t and removes an existing record and all associated answers and configuration details.
 //
 // NS1 API docs: https://ns1.com/api/#record-delete
-func (s *RecordsService) Delete(zone string, domain string, t string) (*http.Response, error) {
+func (s *RecordsService) Delete(zone string, domain string, t string, opts ...RequestOption) (*http.Response, error) {
 	path := fmt.Sprintf("zones/%s/%s/%s", zone, domain, t)
 
-	req, err := s.client.NewRequest("DELETE", path, nil)
+	req, err := s.client.NewRequest("DELETE", path, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,9 +187,289 @@ func (s *RecordsService) Delete(zone string, domain string, t string) (*http.Res
 	return resp, nil
 }
 
+// GetByNetwork takes a zone, domain, record type t and DDI network ids, and
+// returns the variant of the record for each network, keyed by network id.
+// Networks without a variant of the record are left out of the map.
+func (s *RecordsService) GetByNetwork(zone, domain, t string, networkIDs ...int) (map[int]*dns.Record, *http.Response, error) {
+	records := make(map[int]*dns.Record, len(networkIDs))
+
+	var resp *http.Response
+	for _, id := range networkIDs {
+		r, rsp, err := s.Get(zone, domain, t, ForNetwork(id))
+		resp = rsp
+		if err == ErrRecordMissing {
+			continue
+		}
+		if err != nil {
+			return nil, resp, err
+		}
+		records[id] = r
+	}
+
+	return records, resp, nil
+}
+
+// CreatePTR takes an IPv4 or IPv6 address and a hostname and creates the PTR
+// record for the address in the most specific matching reverse zone
+// (in-addr.arpa or ip6.arpa) of the account.
+//
+// ErrZoneMissing is returned if no reverse zone covers the address.
+func (s *RecordsService) CreatePTR(ip, hostname string) (*dns.Record, *http.Response, error) {
+	name, err := dns.ReverseName(ip)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zones, resp, err := s.client.Zones.List()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	zone := ""
+	for _, z := range zones {
+		if !dns.IsReverseZone(z.Zone) {
+			continue
+		}
+		if (name == z.Zone || strings.HasSuffix(name, "."+z.Zone)) && len(z.Zone) > len(zone) {
+			zone = z.Zone
+		}
+	}
+	if zone == "" {
+		return nil, resp, ErrZoneMissing
+	}
+
+	r := dns.NewRecord(zone, name, "PTR")
+	r.AddAnswer(dns.NewPTRAnswer(hostname))
+
+	resp, err = s.Create(r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// ValidateAll validates a set of records destined for zone without making
+// any API calls, and returns a MultiError of every problem found, or nil.
+// On top of Record.Validate (domain, type, answer format, metadata and
+// filter/weight consistency), it checks that each record belongs to zone and
+// that no domain/type pair appears twice. Weight consistency problems are
+// *dns.ValidationWarning entries; use dns.IsWarning to skip them. opts are
+// passed on to Record.Validate.
+func (s *RecordsService) ValidateAll(zone string, records []*dns.Record, opts ...dns.ValidateOption) error {
+	var errs MultiError
+	seen := map[string]bool{}
+	for i, r := range records {
+		if r == nil {
+			errs = append(errs, fmt.Errorf("record %d is nil", i))
+			continue
+		}
+		if r.Zone != "" && !strings.EqualFold(strings.TrimSuffix(r.Zone, "."), strings.TrimSuffix(zone, ".")) {
+			errs = append(errs, fmt.Errorf("%s: record zone %s does not match %s", r, r.Zone, zone))
+		} else if r.Zone == "" && r.Domain != "" && !dns.InZone(r.Domain, zone) {
+			// Record.Validate checks the domain against r.Zone when it is set.
+			errs = append(errs, fmt.Errorf("%s: domain is not in zone %s", r, zone))
+		}
+
+		key := strings.ToLower(r.Domain) + " " + strings.ToUpper(r.Type)
+		if seen[key] {
+			errs = append(errs, fmt.Errorf("%s: duplicate record", r))
+		}
+		seen[key] = true
+
+		errs = append(errs, r.Validate(opts...)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ttlUnit is the duration of one TTL second, shortened in tests.
+var ttlUnit = time.Second
+
+// LowerTTLAndWait sets the TTL of a record to newTTL and, if that lowers it,
+// waits for the old TTL to elapse so that resolvers no longer cache answers
+// with the old TTL and later changes propagate within newTTL. The record is
+// read and updated with ctx, and the wait ends early with ctx.Err() if ctx is
+// done. The old and new TTLs are returned.
+func (s *RecordsService) LowerTTLAndWait(ctx context.Context, zone, domain, t string, newTTL int) (int, int, *http.Response, error) {
+	r, resp, err := s.Get(zone, domain, t, withContext(ctx))
+	if err != nil {
+		return 0, 0, resp, err
+	}
+
+	oldTTL := r.TTL
+	if oldTTL == newTTL {
+		return oldTTL, newTTL, resp, nil
+	}
+
+	r.TTL = newTTL
+	if resp, err = s.Update(r, withContext(ctx)); err != nil {
+		return oldTTL, 0, resp, err
+	}
+	if newTTL > oldTTL {
+		return oldTTL, newTTL, resp, nil
+	}
+
+	timer := time.NewTimer(time.Duration(oldTTL) * ttlUnit)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return oldTTL, newTTL, resp, nil
+	case <-ctx.Done():
+		return oldTTL, newTTL, resp, ctx.Err()
+	}
+}
+
+// AnswerHealthMechanism reports how SetAnswerHealth changed an answer's state.
+type AnswerHealthMechanism string
+
+const (
+	// AnswerHealthStatic means the answer's static 'up' metadata was updated.
+	AnswerHealthStatic AnswerHealthMechanism = "static"
+	// AnswerHealthFeed means the answer's 'up' metadata is bound to a data
+	// feed, and the new state was published to that feed.
+	AnswerHealthFeed AnswerHealthMechanism = "feed"
+)
+
+// SetAnswerHealth marks the answer of the given record whose rdata (answer
+// fields joined by single spaces, e.g. "1.2.3.4" or "10 mail.example.com")
+// matches as up or down.
+//
+// If the answer's 'up' metadata points at a data feed, the state is published
+// to the feed's data source; otherwise the record is updated with the new
+// static value. The mechanism used is returned. ErrAnswerMissing is returned
+// if no answer matches rdata.
+func (s *RecordsService) SetAnswerHealth(zone, domain, t, rdata string, up bool) (AnswerHealthMechanism, *http.Response, error) {
+	r, resp, err := s.Get(zone, domain, t)
+	if err != nil {
+		return "", resp, err
+	}
+
+	ans := findAnswer(r, rdata)
+	if ans == nil {
+		return "", resp, ErrAnswerMissing
+	}
+
+	if ans.Meta != nil {
+		if feedID, ok := data.FeedIDOf(ans.Meta.Up); ok {
+			resp, err = s.publishFeedUp(feedID, up)
+			return AnswerHealthFeed, resp, err
+		}
+	}
+
+	if ans.Meta == nil {
+		ans.Meta = &data.Meta{}
+	}
+	ans.Meta.Up = up
+
+	resp, err = s.Update(r)
+	return AnswerHealthStatic, resp, err
+}
+
+// BindAnswerToFeed makes the 'up' status of the answer of the given record
+// whose rdata matches (see SetAnswerHealth) driven by a data feed: it
+// creates a feed named feedName in the data source sourceID (reusing an
+// existing feed of that name), and points the answer's 'up' metadata at it.
+// NS1 then lists the answer in the feed's destinations.
+//
+// The feed's label is set to feedName, so publishing {feedName: {"up": ...}}
+// to the data source updates the answer. The feed is returned as read back
+// after binding. ErrAnswerMissing is returned if no answer matches rdata.
+func (s *RecordsService) BindAnswerToFeed(zone, domain, t, rdata, sourceID, feedName string) (*data.Feed, *http.Response, error) {
+	return s.BindAnswerMetaToFeed(zone, domain, t, rdata, "up", sourceID, feedName)
+}
+
+// BindAnswerMetaToFeed is BindAnswerToFeed for any metadata field of the
+// answer, given by its JSON name, e.g. "ip_prefixes" for steering on a
+// changing set of networks (see DataSourcesService.PublishIPPrefixes).
+func (s *RecordsService) BindAnswerMetaToFeed(zone, domain, t, rdata, field, sourceID, feedName string) (*data.Feed, *http.Response, error) {
+	// Check the field name before making any change.
+	if err := (&data.Meta{}).SetFeed(field, ""); err != nil {
+		return nil, nil, err
+	}
+
+	r, resp, err := s.Get(zone, domain, t)
+	if err != nil {
+		return nil, resp, err
+	}
+	ans := findAnswer(r, rdata)
+	if ans == nil {
+		return nil, resp, ErrAnswerMissing
+	}
+
+	feeds, resp, err := s.client.DataFeeds.List(sourceID)
+	if err != nil {
+		return nil, resp, err
+	}
+	var feed *data.Feed
+	for _, f := range feeds {
+		if f.Name == feedName {
+			feed = f
+			break
+		}
+	}
+	if feed == nil {
+		feed = data.NewFeed(feedName, data.Config{"label": feedName})
+		if resp, err = s.client.DataFeeds.Create(sourceID, feed); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if ans.Meta == nil {
+		ans.Meta = &data.Meta{}
+	}
+	ans.Meta.SetFeed(field, feed.ID) // nolint: errcheck
+	if resp, err = s.Update(r); err != nil {
+		return nil, resp, err
+	}
+
+	return s.client.DataFeeds.Get(sourceID, feed.ID)
+}
+
+// findAnswer returns the answer of r whose fields, joined by single spaces,
+// equal rdata, or nil.
+func findAnswer(r *dns.Record, rdata string) *dns.Answer {
+	for _, a := range r.Answers {
+		if strings.Join(a.Rdata, " ") == rdata {
+			return a
+		}
+	}
+	return nil
+}
+
+// publishFeedUp looks up the data source and label of the feed with the given
+// id and publishes an 'up' value to it.
+func (s *RecordsService) publishFeedUp(feedID string, up bool) (*http.Response, error) {
+	sources, resp, err := s.client.DataSources.List()
+	if err != nil {
+		return resp, err
+	}
+
+	for _, src := range sources {
+		for _, f := range src.Feeds {
+			if f.ID != feedID {
+				continue
+			}
+			label, _ := f.Config["label"].(string)
+			if label == "" {
+				return resp, fmt.Errorf("feed %s of data source %s has no label to publish to", feedID, src.ID)
+			}
+			payload := map[string]interface{}{label: map[string]interface{}{"up": up}}
+			return s.client.DataSources.Publish(src.ID, payload)
+		}
+	}
+
+	return resp, fmt.Errorf("data feed %s not found", feedID)
+}
+
 var (
 	// ErrRecordExists bundles PUT create error.
-	ErrRecordExists = errors.New("record already exists")
+	ErrRecordExists = existsError("record already exists")
 	// ErrRecordMissing bundles GET/POST/DELETE error.
-	ErrRecordMissing = errors.New("record does not exist")
+	ErrRecordMissing = missingError("record does not exist")
+	// ErrAnswerMissing is returned when no answer of a record matches.
+	ErrAnswerMissing = missingError("answer does not exist")
 )
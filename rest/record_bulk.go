@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// defaultBulkParallelism is the number of concurrent requests of Bulk when
+// none is given.
+const defaultBulkParallelism = 4
+
+// RecordOp is the kind of change of a RecordChange.
+type RecordOp string
+
+// The RecordOps.
+const (
+	RecordCreate RecordOp = "create"
+	RecordUpdate RecordOp = "update"
+	RecordDelete RecordOp = "delete"
+)
+
+// RecordChange is one change of a Bulk call. A RecordDelete only uses the
+// Zone, Domain and Type of the Record.
+type RecordChange struct {
+	Op     RecordOp
+	Record *dns.Record
+}
+
+// String returns the op and the record, e.g. "create www.example.com A".
+func (c RecordChange) String() string {
+	if c.Record == nil {
+		return string(c.Op)
+	}
+	return fmt.Sprintf("%s %s %s", c.Op, c.Record.Domain, c.Record.Type)
+}
+
+// Bulk applies many record changes, e.g. to import a large zone, with at
+// most parallelism requests at a time (4 if parallelism is not positive).
+// The API has no bulk record endpoint, so each change is its own request;
+// they go through the client's rate limiting and retries, so use the same
+// parallelism with RateLimitStrategyConcurrent.
+//
+// The returned slice holds the error of each change, in order, nil for
+// those that were applied; the error is a MultiError of the failed ones, or
+// nil if all were applied. Created and updated records are set from the
+// responses as with Create and Update. Changes are independent: a failure
+// doesn't stop the others, but once ctx is done the remaining ones fail with
+// its error without being sent.
+func (s *RecordsService) Bulk(ctx context.Context, changes []RecordChange, parallelism int) ([]error, error) {
+	if parallelism <= 0 {
+		parallelism = defaultBulkParallelism
+	}
+
+	errs := make([]error, len(changes))
+	parallel(len(changes), parallelism, func(i int) {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			return
+		}
+		errs[i] = s.apply(ctx, changes[i])
+	})
+
+	var failed MultiError
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", changes[i], err))
+		}
+	}
+	if len(failed) > 0 {
+		return errs, failed
+	}
+	return errs, nil
+}
+
+// apply makes the request of a RecordChange.
+func (s *RecordsService) apply(ctx context.Context, c RecordChange) error {
+	if c.Record == nil {
+		return fmt.Errorf("no record to %s", c.Op)
+	}
+
+	var err error
+	switch c.Op {
+	case RecordCreate:
+		_, err = s.Create(c.Record, withContext(ctx))
+	case RecordUpdate:
+		_, err = s.Update(c.Record, withContext(ctx))
+	case RecordDelete:
+		_, err = s.Delete(c.Record.Zone, c.Record.Domain, c.Record.Type, withContext(ctx))
+	default:
+		err = fmt.Errorf("unknown record op %q", c.Op)
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestRecordsBulk(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen = map[string]int{}
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == "/zones/example.com/dup.example.com/A" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "record already exists"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "r1"}`))
+	}))
+	defer ts.Close()
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	changes := []RecordChange{
+		{Op: RecordCreate, Record: dns.NewRecord("example.com", "a.example.com", "A")},
+		{Op: RecordCreate, Record: dns.NewRecord("example.com", "dup.example.com", "A")},
+		{Op: RecordUpdate, Record: dns.NewRecord("example.com", "b.example.com", "A")},
+		{Op: RecordDelete, Record: dns.NewRecord("example.com", "c.example.com", "A")},
+		{Op: "rename", Record: dns.NewRecord("example.com", "d.example.com", "A")},
+	}
+	errs, err := client.Records.Bulk(context.Background(), changes, 2)
+	require.IsType(t, MultiError{}, err)
+	assert.Len(t, err.(MultiError), 2)
+	require.Len(t, errs, len(changes))
+	assert.Nil(t, errs[0])
+	assert.Equal(t, ErrRecordExists, errs[1])
+	assert.Contains(t, err.Error(), "create dup.example.com A: record already exists")
+	assert.Nil(t, errs[2])
+	assert.Nil(t, errs[3])
+	assert.NotNil(t, errs[4])
+	assert.Equal(t, "r1", changes[0].Record.ID)
+
+	assert.Equal(t, map[string]int{
+		"PUT /zones/example.com/a.example.com/A":    1,
+		"PUT /zones/example.com/dup.example.com/A":  1,
+		"POST /zones/example.com/b.example.com/A":   1,
+		"DELETE /zones/example.com/c.example.com/A": 1,
+	}, seen)
+
+	// Nothing is sent once the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs, err = client.Records.Bulk(ctx, changes[:1], 0)
+	require.NotNil(t, err)
+	assert.Equal(t, context.Canceled, errs[0])
+	assert.Equal(t, 1, seen["PUT /zones/example.com/a.example.com/A"])
+}
@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+const monitoringSourceType = "nsone_monitoring"
+
+// Failover is the set of resources making up a primary/backup failover pair,
+// as set up by RecordsService.SetupFailover.
+type Failover struct {
+	Record  *dns.Record
+	Monitor *monitor.Job
+	Source  *data.Source
+	Feed    *data.Feed
+}
+
+// SetupFailover configures the record zone/domain/t to fail over from the
+// primary answer to the backup one: a monitoring job, built from job, checks
+// the primary, and a feed of the account's NS1 monitoring data source
+// (created if there is none) publishes the job's status to the primary
+// answer's 'up' metadata. The up and select_first_n (N=1) filters then serve
+// the primary while it is up, and the backup otherwise.
+//
+// Answers are given as rdata fields joined by single spaces. job is a
+// template for the monitor (type, config, regions, ...); if it has no Name,
+// "failover <domain> <primary>" is used.
+//
+// SetupFailover is idempotent: the job is matched by name, the feed by job
+// id and the record by zone/domain/t, and existing ones are updated rather
+// than duplicated. The record's answers and filters are replaced; its other
+// fields are kept.
+func (s *RecordsService) SetupFailover(zone, domain, t, primary, backup string, job *monitor.Job) (*Failover, *http.Response, error) {
+	if job == nil {
+		return nil, nil, errors.New("a monitoring job is required")
+	}
+	if job.Name == "" {
+		job.Name = fmt.Sprintf("failover %s %s", domain, primary)
+	}
+
+	resp, err := s.ensureJob(job)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	src, resp, err := s.ensureMonitoringSource()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	feed, resp, err := s.ensureJobFeed(src.ID, job)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	r, resp, err := s.Get(zone, domain, t)
+	exists := err == nil
+	if err == ErrRecordMissing {
+		r = dns.NewRecord(zone, domain, t)
+	} else if err != nil {
+		return nil, resp, err
+	}
+
+	primaryAnswer := dns.NewAnswer(strings.Fields(primary))
+	primaryAnswer.Meta.Up = data.FeedPtr{FeedID: feed.ID}
+	r.Answers = []*dns.Answer{primaryAnswer, dns.NewAnswer(strings.Fields(backup))}
+	r.Filters = []*filter.Filter{filter.NewUp(), filter.NewSelFirstN(1)}
+
+	if exists {
+		resp, err = s.Update(r)
+	} else {
+		resp, err = s.Create(r)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &Failover{Record: r, Monitor: job, Source: src, Feed: feed}, resp, nil
+}
+
+// ensureJob updates the job of the same name as job, or creates it.
+func (s *RecordsService) ensureJob(job *monitor.Job) (*http.Response, error) {
+	jobs, resp, err := s.client.Jobs.List()
+	if err != nil {
+		return resp, err
+	}
+	for _, j := range jobs {
+		if j.Name == job.Name {
+			job.ID = j.ID
+			return s.client.Jobs.Update(job)
+		}
+	}
+	return s.client.Jobs.Create(job)
+}
+
+// ensureMonitoringSource returns the account's NS1 monitoring data source,
+// creating it if needed.
+func (s *RecordsService) ensureMonitoringSource() (*data.Source, *http.Response, error) {
+	sources, resp, err := s.client.DataSources.List()
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, src := range sources {
+		if src.Type == monitoringSourceType {
+			return src, resp, nil
+		}
+	}
+
+	src := data.NewSource("NS1 Monitoring", monitoringSourceType)
+	resp, err = s.client.DataSources.Create(src)
+	if err != nil {
+		return nil, resp, err
+	}
+	return src, resp, nil
+}
+
+// ensureJobFeed returns the feed of the monitoring source for the job,
+// creating it if needed.
+func (s *RecordsService) ensureJobFeed(sourceID string, job *monitor.Job) (*data.Feed, *http.Response, error) {
+	feeds, resp, err := s.client.DataFeeds.List(sourceID)
+	if err != nil {
+		return nil, resp, err
+	}
+	for _, f := range feeds {
+		if id, _ := f.Config["jobid"].(string); id == job.ID {
+			return f, resp, nil
+		}
+	}
+
+	feed := data.NewFeed(job.Name, data.Config{"jobid": job.ID})
+	resp, err = s.client.DataFeeds.Create(sourceID, feed)
+	if err != nil {
+		return nil, resp, err
+	}
+	return feed, resp, nil
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+// maxLinkDepth bounds the chain of linked records EffectiveFilters follows.
+const maxLinkDepth = 8
+
+// EffectiveFilters returns the filter chain NS1 evaluates when answering
+// queries for the record, in order.
+//
+// NS1 applies no implicit zone or account default filters: a record without
+// a filter chain serves all of its answers. The effective chain is therefore
+// the record's explicit chain, without the disabled filters. For a linked
+// record it is the chain of the record linked to, which is read as well.
+func (s *RecordsService) EffectiveFilters(zone, domain, t string) ([]*filter.Filter, *http.Response, error) {
+	r, resp, err := s.Get(zone, domain, t)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for depth := 0; r.Link != ""; depth++ {
+		if depth == maxLinkDepth {
+			return nil, resp, fmt.Errorf("record %s %s: more than %d linked records", domain, t, maxLinkDepth)
+		}
+		if r, resp, err = s.getLinked(r.Link, t); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	chain := []*filter.Filter{}
+	for _, f := range r.Filters {
+		if !f.Disabled {
+			chain = append(chain, f)
+		}
+	}
+	return chain, resp, nil
+}
+
+// getLinked reads the record of the given type at domain, a link target,
+// trying the zones it may be in from the most specific.
+func (s *RecordsService) getLinked(domain, t string) (*dns.Record, *http.Response, error) {
+	domain = strings.TrimSuffix(domain, ".")
+	labels := strings.Split(domain, ".")
+	var (
+		resp *http.Response
+		err  error
+	)
+	for i := 0; i < len(labels)-1; i++ {
+		zone := strings.Join(labels[i:], ".")
+		var r *dns.Record
+		r, resp, err = s.Get(zone, domain, t)
+		if err == nil || err == ErrRecordMissing || !errors.Is(err, ErrNotFound) {
+			return r, resp, err
+		}
+	}
+	return nil, resp, ErrRecordMissing
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+// FilterTypes returns the available filter types and their config schemas.
+//
+// NS1 API docs: https://ns1.com/api/#filtertypes-get
+func (s *RecordsService) FilterTypes() ([]*filter.FilterType, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "filtertypes", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fts := []*filter.FilterType{}
+	resp, err := s.client.Do(req, &fts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fts, resp, nil
+}
+
+// cachedFilterTypes returns the filter types keyed by type, cached with the
+// same TTL as the monitoring catalogs (see SetCatalogTTL).
+func (s *RecordsService) cachedFilterTypes() (map[string]*filter.FilterType, error) {
+	cc := s.client.catalogs
+	cc.mu.Lock()
+	fts := cc.filterTypes
+	fresh := fts != nil && cc.fresh(cc.filterTypesAt)
+	cc.mu.Unlock()
+
+	if fresh {
+		return fts, nil
+	}
+
+	list, _, err := s.FilterTypes()
+	if err != nil {
+		return nil, err
+	}
+	fts = make(map[string]*filter.FilterType, len(list))
+	for _, ft := range list {
+		fts[ft.Type] = ft
+	}
+
+	cc.mu.Lock()
+	cc.filterTypes, cc.filterTypesAt = fts, time.Now()
+	cc.mu.Unlock()
+
+	return fts, nil
+}
+
+// ValidateFilters checks a filter chain client-side: each filter must be of
+// a known filter type, pass Filter.Validate, and have a config matching the
+// schema of its type, as ValidateConfig does for data sources. Filter types
+// are fetched on first use and cached. A MultiError listing every problem is
+// returned, or nil.
+func (s *RecordsService) ValidateFilters(chain []*filter.Filter) error {
+	fts, err := s.cachedFilterTypes()
+	if err != nil {
+		return err
+	}
+
+	var errs MultiError
+	for i, f := range chain {
+		ft, ok := fts[f.Type]
+		if !ok {
+			errs = append(errs, fmt.Errorf("filter %d: unknown filter type %q", i, f.Type))
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("filter %d (%s): %v", i, f.Type, err))
+		}
+		if err := validateConfigFields(ft.Config, f.Config); err != nil {
+			for _, e := range err.(MultiError) {
+				errs = append(errs, fmt.Errorf("filter %d (%s): %v", i, f.Type, e))
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+func TestRecordsService_ValidateFilters(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/monitoring/jobtypes":
+			w.Write([]byte(`{}`)) // nolint: errcheck
+			return
+		case "/monitoring/regions":
+			w.Write([]byte(`[]`)) // nolint: errcheck
+			return
+		}
+		hits++
+		w.Write([]byte(`[
+			{"filter": "geotarget_country", "name": "Geotarget Country", "config": []},
+			{"filter": "up", "name": "Up", "config": []},
+			{"filter": "select_first_n", "name": "Select First N", "config": [
+				{"name": "N", "type": "number", "required": true}
+			]}
+		]`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	chain := filter.NewFilterChain().GeotargetCountry().Up().SelectFirstN(1).Filters()
+	assert.Nil(t, c.Records.ValidateFilters(chain))
+
+	chain = append(chain, filter.NewShuffle(), &filter.Filter{Type: "select_first_n", Config: filter.Config{"n": 1}})
+	err := c.Records.ValidateFilters(chain)
+	require.NotNil(t, err)
+	errs, ok := err.(MultiError)
+	require.True(t, ok)
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), `filter 3: unknown filter type "shuffle"`)
+	assert.Contains(t, errs[1].Error(), `filter 4 (select_first_n): config key "N" is required`)
+	assert.Contains(t, errs[2].Error(), `config key "n" is not accepted`)
+
+	assert.Equal(t, 1, hits)
+
+	// Refreshing the catalogs drops the cached filter types.
+	_, err = c.Jobs.RefreshCatalogs(context.Background())
+	require.Nil(t, err)
+	assert.Nil(t, c.Records.ValidateFilters(chain[:1]))
+	assert.Equal(t, 2, hits)
+
+	// As does their TTL running out.
+	c = NewClient(nil, SetEndpoint(ts.URL+"/"), SetCatalogTTL(time.Nanosecond))
+	hits = 0
+	assert.Nil(t, c.Records.ValidateFilters(chain[:1]))
+	assert.Nil(t, c.Records.ValidateFilters(chain[:1]))
+	assert.Equal(t, 2, hits)
+}
@@ -0,0 +1,68 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// SwapAnswers exchanges the answers, filters, regions and metadata of the
+// records domainA and domainB of type t in zone, e.g. for a blue/green
+// cutover between a record and its staging counterpart. The records are
+// returned as written.
+//
+// The two writes are not atomic: domainA is updated first, and if updating
+// domainB then fails domainA is restored to its previous configuration on a
+// best-effort basis. The returned error reports a failed rollback as well.
+func (s *RecordsService) SwapAnswers(zone, domainA, domainB, t string) (*dns.Record, *dns.Record, *http.Response, error) {
+	a, resp, err := s.Get(zone, domainA, t)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	b, resp, err := s.Get(zone, domainB, t)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	// Keep a deep copy of a to roll back to, since the updates decode the
+	// API response into the (swapped) slices and maps.
+	origA, err := copyRecord(a)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+
+	a.Answers, b.Answers = b.Answers, a.Answers
+	a.Filters, b.Filters = b.Filters, a.Filters
+	a.Regions, b.Regions = b.Regions, a.Regions
+	a.Meta, b.Meta = b.Meta, a.Meta
+
+	if resp, err = s.Update(a); err != nil {
+		return nil, nil, resp, err
+	}
+	if resp, err = s.Update(b); err != nil {
+		if _, rbErr := s.Update(origA); rbErr != nil {
+			return nil, nil, resp, fmt.Errorf(
+				"updating %s failed: %v; restoring %s also failed, it now has the answers of %s: %v",
+				domainB, err, domainA, domainB, rbErr,
+			)
+		}
+		return nil, nil, resp, err
+	}
+
+	return a, b, resp, nil
+}
+
+// copyRecord returns a deep copy of r.
+func copyRecord(r *dns.Record) (*dns.Record, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	var c dns.Record
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+)
+
+const defaultTemplateParallelism = 4
+
+// TemplateMerge selects how RecordsService.ApplyTemplate merges a
+// RecordTemplate into records.
+type TemplateMerge int
+
+const (
+	// TemplateFillEmpty only sets what a record leaves unset: the TTL if it
+	// is 0 (the zone default), the filter chain if it is empty, and tags and
+	// metadata fields the record does not have.
+	TemplateFillEmpty TemplateMerge = iota
+	// TemplateOverride sets the template's TTL and filter chain, and its tags
+	// and metadata fields, replacing the record's values. Tags and metadata
+	// fields not in the template are kept.
+	TemplateOverride
+)
+
+// RecordTemplate is a set of record defaults, e.g. an organization's standard
+// TTL, tags and filter chain, see RecordsService.ApplyTemplate. Zero fields
+// (TTL 0, nil Tags, Filters and Meta) are not part of the template.
+type RecordTemplate struct {
+	TTL     int
+	Tags    map[string]string
+	Filters []*filter.Filter
+	Meta    *data.Meta
+
+	Merge TemplateMerge
+}
+
+// TemplateChange is a record changed by RecordsService.ApplyTemplate, with
+// the changed fields as reported by dns.DiffRecords.
+type TemplateChange struct {
+	Domain string
+	Type   string
+	Fields []dns.FieldDiff
+}
+
+// withContext binds a request to ctx.
+func withContext(ctx context.Context) RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(ctx)
+	}
+}
+
+// ApplyTemplate merges tmpl into every record of zone for which match returns
+// true (all records if match is nil), by reading each record, merging as
+// selected by tmpl.Merge, and updating the records that changed. Fields the
+// template does not set are left intact. Linked records are skipped, as they
+// have no configuration of their own.
+//
+// The changed records are returned in zone order. ctx applies to all
+// requests; on error, the changes made so far are returned with it.
+func (s *RecordsService) ApplyTemplate(ctx context.Context, zone string, match func(*dns.Record) bool, tmpl RecordTemplate) ([]TemplateChange, *http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	z, resp, err := s.client.WithContext(ctx).Zones.Get(zone)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	records := make([]*dns.Record, len(z.Records))
+	errs := make([]error, len(z.Records))
+	resps := make([]*http.Response, len(z.Records))
+	parallel(len(z.Records), defaultTemplateParallelism, func(i int) {
+		zr := z.Records[i]
+		if zr.Link != "" {
+			return
+		}
+		records[i], resps[i], errs[i] = s.Get(zone, zr.Domain, zr.Type, withContext(ctx))
+	})
+
+	var changes []TemplateChange
+	for i, r := range records {
+		if errs[i] != nil {
+			return changes, resps[i], errs[i]
+		}
+		if r == nil || r.Link != "" || (match != nil && !match(r)) {
+			continue
+		}
+
+		before, err := copyRecord(r)
+		if err != nil {
+			return changes, resp, err
+		}
+		if err := tmpl.apply(r); err != nil {
+			return changes, resp, err
+		}
+		fields := dns.DiffRecords(before, r)
+		if len(fields) == 0 {
+			continue
+		}
+		if resp, err = s.Update(r, withContext(ctx)); err != nil {
+			return changes, resp, err
+		}
+		changes = append(changes, TemplateChange{Domain: r.Domain, Type: r.Type, Fields: fields})
+	}
+	return changes, resp, nil
+}
+
+// apply merges the template into r.
+func (tmpl *RecordTemplate) apply(r *dns.Record) error {
+	override := tmpl.Merge == TemplateOverride
+
+	if tmpl.TTL != 0 && (override || r.TTL == 0) {
+		r.TTL = tmpl.TTL
+	}
+	if tmpl.Filters != nil && (override || len(r.Filters) == 0) {
+		r.Filters = make([]*filter.Filter, len(tmpl.Filters))
+		for i, f := range tmpl.Filters {
+			copied := *f
+			r.Filters[i] = &copied
+		}
+	}
+	for k, v := range tmpl.Tags {
+		if r.Tags == nil {
+			r.Tags = map[string]string{}
+		}
+		if _, ok := r.Tags[k]; override || !ok {
+			r.Tags[k] = v
+		}
+	}
+	if tmpl.Meta != nil {
+		meta, err := mergeMeta(r.Meta, tmpl.Meta, override)
+		if err != nil {
+			return err
+		}
+		r.Meta = meta
+	}
+	return nil
+}
+
+// mergeMeta returns meta with the fields set in tmpl merged in, replacing
+// fields set in both if override is true.
+func mergeMeta(meta, tmpl *data.Meta, override bool) (*data.Meta, error) {
+	fields := map[string]json.RawMessage{}
+	if meta != nil {
+		b, err := json.Marshal(meta)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var tmplFields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &tmplFields); err != nil {
+		return nil, err
+	}
+	for k, v := range tmplFields {
+		if _, ok := fields[k]; override || !ok {
+			fields[k] = v
+		}
+	}
+
+	if b, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	var merged data.Meta
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
@@ -0,0 +1,482 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/filter"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestRecord(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("CreatePTR", func(t *testing.T) {
+		zones := []*dns.Zone{
+			{Zone: "example.com"},
+			{Zone: "192.in-addr.arpa"},
+			{Zone: "2.0.192.in-addr.arpa"},
+		}
+
+		t.Run("Success", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			expected := dns.NewRecord("2.0.192.in-addr.arpa", "1.2.0.192.in-addr.arpa", "PTR")
+			expected.AddAnswer(dns.NewPTRAnswer("host.example.com"))
+
+			require.Nil(t, mock.AddZoneListTestCase(nil, nil, zones))
+			require.Nil(t, mock.AddTestCase(
+				http.MethodPut, "/zones/2.0.192.in-addr.arpa/1.2.0.192.in-addr.arpa/PTR",
+				http.StatusOK, nil, nil, expected, expected,
+			))
+
+			r, _, err := client.Records.CreatePTR("192.0.2.1", "host.example.com")
+			require.Nil(t, err)
+			require.Equal(t, "2.0.192.in-addr.arpa", r.Zone)
+			require.Equal(t, "1.2.0.192.in-addr.arpa", r.Domain)
+			require.Equal(t, []string{"host.example.com"}, r.Answers[0].Rdata)
+		})
+
+		t.Run("No Reverse Zone", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			require.Nil(t, mock.AddZoneListTestCase(nil, nil, zones))
+
+			_, _, err := client.Records.CreatePTR("2001:db8::1", "host.example.com")
+			require.Equal(t, api.ErrZoneMissing, err)
+		})
+
+		t.Run("Invalid IP", func(t *testing.T) {
+			_, _, err := client.Records.CreatePTR("nope", "host.example.com")
+			require.NotNil(t, err)
+		})
+	})
+
+	t.Run("SetAnswerHealth", func(t *testing.T) {
+		uri := "/zones/example.com/www.example.com/A"
+
+		t.Run("Static", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			current := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+				"answers":[{"answer":["1.2.3.4"],"meta":{"up":true}},{"answer":["5.6.7.8"]}],"filters":[]}`)
+			updated := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+				"answers":[{"answer":["1.2.3.4"],"meta":{"up":true}},{"answer":["5.6.7.8"],"meta":{"up":false}}],"filters":[]}`)
+
+			require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", current))
+			require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusOK, nil, nil, updated, updated))
+
+			m, _, err := client.Records.SetAnswerHealth("example.com", "www.example.com", "A", "5.6.7.8", false)
+			require.Nil(t, err)
+			require.Equal(t, api.AnswerHealthStatic, m)
+		})
+
+		t.Run("Feed", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			current := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+				"answers":[{"answer":["1.2.3.4"],"meta":{"up":{"feed":"f1"}}}],"filters":[]}`)
+			sources := json.RawMessage(`[{"id":"s1","name":"api","sourcetype":"nsone_v1",
+				"feeds":[{"id":"f1","name":"www","config":{"label":"www-1"}}]}]`)
+
+			require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", current))
+			require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/sources", http.StatusOK, nil, nil, "", sources))
+			require.Nil(t, mock.AddTestCase(http.MethodPost, "/feed/s1", http.StatusOK, nil, nil,
+				json.RawMessage(`{"www-1":{"up":false}}`), "{}"))
+
+			m, _, err := client.Records.SetAnswerHealth("example.com", "www.example.com", "A", "1.2.3.4", false)
+			require.Nil(t, err)
+			require.Equal(t, api.AnswerHealthFeed, m)
+		})
+
+		t.Run("Missing Answer", func(t *testing.T) {
+			defer mock.ClearTestCases()
+
+			current := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+				"answers":[{"answer":["1.2.3.4"]}],"filters":[]}`)
+			require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", current))
+
+			_, _, err := client.Records.SetAnswerHealth("example.com", "www.example.com", "A", "9.9.9.9", false)
+			require.Equal(t, api.ErrAnswerMissing, err)
+		})
+	})
+
+	t.Run("ValidateAll", func(t *testing.T) {
+		good := dns.NewRecord("example.com", "www", "A")
+		good.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+
+		require.Nil(t, client.Records.ValidateAll("example.com", []*dns.Record{good}))
+
+		badAnswer := dns.NewRecord("example.com", "mail", "MX")
+		badAnswer.AddAnswer(dns.NewAnswer([]string{"mail.example.com"}))
+		otherZone := dns.NewRecord("example.net", "www", "A")
+		otherZone.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+		weighted := dns.NewRecord("example.com", "lb", "A")
+		a := dns.NewAv4Answer("1.2.3.4")
+		a.Meta.Weight = 10.0
+		weighted.AddAnswer(a)
+
+		err := client.Records.ValidateAll("example.com", []*dns.Record{good, badAnswer, otherZone, weighted, good})
+		require.NotNil(t, err)
+
+		errs, ok := err.(api.MultiError)
+		require.True(t, ok)
+		require.Len(t, errs, 4)
+		require.True(t, dns.IsWarning(errs[2]))
+		require.Contains(t, errs[3].Error(), "duplicate")
+	})
+
+	t.Run("ForNetwork", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		uri := "/zones/example.com/www.example.com/A"
+		rec := dns.NewRecord("example.com", "www", "A")
+		rec.AddAnswer(dns.NewAv4Answer("10.0.0.1"))
+		rec.NetworkIDs = []int{1}
+
+		require.Nil(t, mock.AddTestCase(http.MethodPut, uri+"?networks=1", http.StatusOK, nil, nil, rec, rec))
+		_, err := client.Records.Create(rec, api.ForNetwork(1))
+		require.Nil(t, err)
+
+		all := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A","answers":[{"answer":["1.2.3.4"]}]}`)
+		net1 := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A","networks":[1],"answers":[{"answer":["10.0.0.1"]}]}`)
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", all))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri+"?networks=1", http.StatusOK, nil, nil, "", net1))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri+"?networks=2", http.StatusNotFound, nil, nil, "", `{"message": "record not found"}`))
+
+		r, _, err := client.Records.Get("example.com", "www.example.com", "A")
+		require.Nil(t, err)
+		require.Equal(t, []string{"1.2.3.4"}, r.Answers[0].Rdata)
+
+		byNetwork, _, err := client.Records.GetByNetwork("example.com", "www.example.com", "A", 1, 2)
+		require.Nil(t, err)
+		require.Len(t, byNetwork, 1)
+		require.Equal(t, []int{1}, byNetwork[1].NetworkIDs)
+		require.Equal(t, []string{"10.0.0.1"}, byNetwork[1].Answers[0].Rdata)
+	})
+
+	t.Run("BindAnswerToFeed", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		uri := "/zones/example.com/www.example.com/A"
+		current := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+			"answers":[{"answer":["1.2.3.4"]},{"answer":["5.6.7.8"]}],"filters":[]}`)
+		updated := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+			"answers":[{"answer":["1.2.3.4"],"meta":{"up":{"feed":"f1"}}},{"answer":["5.6.7.8"]}],"filters":[]}`)
+		feed := data.NewFeed("www-1", data.Config{"label": "www-1"})
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", current))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1", http.StatusOK, nil, nil, "", "[]"))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/data/feeds/s1", http.StatusOK, nil, nil, feed,
+			`{"id":"f1","name":"www-1","config":{"label":"www-1"}}`))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusOK, nil, nil, updated, updated))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1/f1", http.StatusOK, nil, nil, "",
+			`{"id":"f1","name":"www-1","config":{"label":"www-1"},"destinations":[{"destid":"a1","desttype":"answer","record":"r1"}]}`))
+
+		f, _, err := client.Records.BindAnswerToFeed("example.com", "www.example.com", "A", "1.2.3.4", "s1", "www-1")
+		require.Nil(t, err)
+		require.Equal(t, "f1", f.ID)
+		require.Equal(t, []data.Destination{{ID: "a1", Type: "answer", RecordID: "r1"}}, f.Destinations)
+
+		_, _, err = client.Records.BindAnswerToFeed("example.com", "www.example.com", "A", "9.9.9.9", "s1", "www-1")
+		require.Equal(t, api.ErrAnswerMissing, err)
+	})
+
+	t.Run("BindAnswerMetaToFeed", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		uri := "/zones/example.com/www.example.com/A"
+		current := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+			"answers":[{"answer":["1.2.3.4"]}],"filters":[]}`)
+		updated := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A",
+			"answers":[{"answer":["1.2.3.4"],"meta":{"ip_prefixes":{"feed":"f2"}}}],"filters":[]}`)
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", current))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1", http.StatusOK, nil, nil, "",
+			`[{"id":"f2","name":"cdn","config":{"label":"cdn"}}]`))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusOK, nil, nil, updated, updated))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1/f2", http.StatusOK, nil, nil, "",
+			`{"id":"f2","name":"cdn","config":{"label":"cdn"}}`))
+
+		f, _, err := client.Records.BindAnswerMetaToFeed("example.com", "www.example.com", "A", "1.2.3.4", "ip_prefixes", "s1", "cdn")
+		require.Nil(t, err)
+		require.Equal(t, "f2", f.ID)
+
+		_, _, err = client.Records.BindAnswerMetaToFeed("example.com", "www.example.com", "A", "1.2.3.4", "nope", "s1", "cdn")
+		require.NotNil(t, err)
+	})
+
+	t.Run("SetupFailover", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		uri := "/zones/example.com/www.example.com/A"
+		newJob := func() *monitor.Job {
+			return &monitor.Job{
+				Type:        "tcp",
+				Config:      monitor.Config{"host": "1.2.3.4", "port": 443},
+				Regions:     []string{"lga", "sjc"},
+				Frequency:   60,
+				Policy:      "quorum",
+				RegionScope: "fixed",
+				Active:      true,
+			}
+		}
+		job := newJob()
+		job.Name = "failover www.example.com 1.2.3.4"
+		created := *job
+		created.ID = "j1"
+		source := data.NewSource("NS1 Monitoring", "nsone_monitoring")
+		feed := data.NewFeed(job.Name, data.Config{"jobid": "j1"})
+		record := json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A","meta":{},
+			"answers":[{"answer":["1.2.3.4"],"meta":{"up":{"feed":"f1"}}},{"answer":["5.6.7.8"],"meta":{}}],
+			"filters":[{"filter":"up","config":{}},{"filter":"select_first_n","config":{"N":1}}]}`)
+
+		// First run creates everything.
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/monitoring/jobs", http.StatusOK, nil, nil, "", "[]"))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/monitoring/jobs/", http.StatusOK, nil, nil, job, &created))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/sources", http.StatusOK, nil, nil, "", "[]"))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/data/sources", http.StatusOK, nil, nil, source,
+			`{"id":"s1","name":"NS1 Monitoring","sourcetype":"nsone_monitoring"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1", http.StatusOK, nil, nil, "", "[]"))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/data/feeds/s1", http.StatusOK, nil, nil, feed,
+			`{"id":"f1","name":"failover www.example.com 1.2.3.4","config":{"jobid":"j1"}}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusNotFound, nil, nil, "", `{"message": "record not found"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, uri, http.StatusOK, nil, nil, record, record))
+
+		fo, _, err := client.Records.SetupFailover("example.com", "www.example.com", "A", "1.2.3.4", "5.6.7.8", newJob())
+		require.Nil(t, err)
+		require.Equal(t, "j1", fo.Monitor.ID)
+		require.Equal(t, "s1", fo.Source.ID)
+		require.Equal(t, "f1", fo.Feed.ID)
+		require.Len(t, fo.Record.Answers, 2)
+
+		// Second run finds and updates the existing resources.
+		mock.ClearTestCases()
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/monitoring/jobs", http.StatusOK, nil, nil, "", []*monitor.Job{&created}))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/monitoring/jobs/j1", http.StatusOK, nil, nil, &created, &created))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/sources", http.StatusOK, nil, nil, "",
+			`[{"id":"s0","name":"Other","sourcetype":"datadog"},{"id":"s1","name":"NS1 Monitoring","sourcetype":"nsone_monitoring"}]`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/data/feeds/s1", http.StatusOK, nil, nil, "",
+			`[{"id":"f1","name":"failover www.example.com 1.2.3.4","config":{"jobid":"j1"}}]`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", record))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusOK, nil, nil, record, record))
+
+		fo, _, err = client.Records.SetupFailover("example.com", "www.example.com", "A", "1.2.3.4", "5.6.7.8", newJob())
+		require.Nil(t, err)
+		require.Equal(t, "j1", fo.Monitor.ID)
+		require.Equal(t, "f1", fo.Feed.ID)
+	})
+
+	t.Run("SwapAnswers", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		blueURI := "/zones/example.com/blue.example.com/A"
+		greenURI := "/zones/example.com/green.example.com/A"
+		blue := json.RawMessage(`{"zone":"example.com","domain":"blue.example.com","type":"A",
+			"answers":[{"answer":["1.1.1.1"]}],"filters":[]}`)
+		green := json.RawMessage(`{"zone":"example.com","domain":"green.example.com","type":"A",
+			"answers":[{"answer":["2.2.2.2"]},{"answer":["3.3.3.3"]}],"filters":[{"filter":"shuffle","config":{}}]}`)
+		blueSwapped := json.RawMessage(`{"zone":"example.com","domain":"blue.example.com","type":"A",
+			"answers":[{"answer":["2.2.2.2"]},{"answer":["3.3.3.3"]}],"filters":[{"filter":"shuffle","config":{}}]}`)
+		greenSwapped := json.RawMessage(`{"zone":"example.com","domain":"green.example.com","type":"A",
+			"answers":[{"answer":["1.1.1.1"]}],"filters":[]}`)
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, blueURI, http.StatusOK, nil, nil, "", blue))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, greenURI, http.StatusOK, nil, nil, "", green))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, blueURI, http.StatusOK, nil, nil, blueSwapped, blueSwapped))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, greenURI, http.StatusOK, nil, nil, greenSwapped, greenSwapped))
+
+		a, b, _, err := client.Records.SwapAnswers("example.com", "blue.example.com", "green.example.com", "A")
+		require.Nil(t, err)
+		require.Equal(t, []string{"2.2.2.2"}, a.Answers[0].Rdata)
+		require.Len(t, b.Answers, 1)
+		require.Equal(t, []string{"1.1.1.1"}, b.Answers[0].Rdata)
+
+		// A failed second write restores the first record.
+		mock.ClearTestCases()
+		require.Nil(t, mock.AddTestCase(http.MethodGet, blueURI, http.StatusOK, nil, nil, "", blue))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, greenURI, http.StatusOK, nil, nil, "", green))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, blueURI, http.StatusOK, nil, nil, blueSwapped, blueSwapped))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, greenURI, http.StatusInternalServerError, nil, nil, greenSwapped,
+			`{"message": "internal error"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, blueURI, http.StatusOK, nil, nil, blue, blue))
+
+		_, _, _, err = client.Records.SwapAnswers("example.com", "blue.example.com", "green.example.com", "A")
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "internal error")
+		require.NotContains(t, err.Error(), "restoring")
+
+		// A failed rollback is reported.
+		mock.ClearTestCases()
+		require.Nil(t, mock.AddTestCase(http.MethodGet, blueURI, http.StatusOK, nil, nil, "", blue))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, greenURI, http.StatusOK, nil, nil, "", green))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, blueURI, http.StatusOK, nil, nil, blueSwapped, blueSwapped))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, greenURI, http.StatusInternalServerError, nil, nil, greenSwapped,
+			`{"message": "internal error"}`))
+
+		_, _, _, err = client.Records.SwapAnswers("example.com", "blue.example.com", "green.example.com", "A")
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "restoring blue.example.com also failed")
+	})
+
+	t.Run("ReplaceAnswers", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		uri := "/zones/example.com/www.example.com/A"
+		require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusOK, nil, nil,
+			json.RawMessage(`{"answers":[{"answer":["5.5.5.5"],"meta":{}},{"answer":["6.6.6.6"],"meta":{}}]}`),
+			json.RawMessage(`{"zone":"example.com","domain":"www.example.com","type":"A","ttl":600,
+				"answers":[{"answer":["5.5.5.5"]},{"answer":["6.6.6.6"]}],"filters":[{"filter":"up","config":{}}]}`)))
+
+		r, _, err := client.Records.ReplaceAnswers("example.com", "www.example.com", "A",
+			[]*dns.Answer{dns.NewAv4Answer("5.5.5.5"), dns.NewAv4Answer("6.6.6.6")})
+		require.Nil(t, err)
+		require.Equal(t, 600, r.TTL)
+		require.Len(t, r.Filters, 1)
+		require.Len(t, r.Answers, 2)
+
+		mock.ClearTestCases()
+		require.Nil(t, mock.AddTestCase(http.MethodPost, uri, http.StatusNotFound, nil, nil,
+			json.RawMessage(`{"answers":[]}`), `{"message": "record not found"}`))
+		_, _, err = client.Records.ReplaceAnswers("example.com", "www.example.com", "A", nil)
+		require.Equal(t, api.ErrRecordMissing, err)
+	})
+
+	t.Run("EffectiveFilters", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/a.com/www.a.com/A", http.StatusOK, nil, nil, "",
+			json.RawMessage(`{"zone":"a.com","domain":"www.a.com","type":"A","link":"target.sub.b.com","answers":[],"filters":[]}`)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/sub.b.com/target.sub.b.com/A", http.StatusNotFound, nil, nil, "",
+			`{"message": "zone not found"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/b.com/target.sub.b.com/A", http.StatusOK, nil, nil, "",
+			json.RawMessage(`{"zone":"b.com","domain":"target.sub.b.com","type":"A","answers":[{"answer":["1.2.3.4"]}],
+				"filters":[{"filter":"up","config":{}},{"filter":"shuffle","disabled":true,"config":{}},{"filter":"select_first_n","config":{"N":1}}]}`)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/a.com/plain.a.com/A", http.StatusOK, nil, nil, "",
+			json.RawMessage(`{"zone":"a.com","domain":"plain.a.com","type":"A","answers":[{"answer":["1.2.3.4"]}]}`)))
+
+		chain, _, err := client.Records.EffectiveFilters("a.com", "www.a.com", "A")
+		require.Nil(t, err)
+		require.Len(t, chain, 2)
+		require.Equal(t, "up", chain[0].Type)
+		require.Equal(t, "select_first_n", chain[1].Type)
+
+		chain, _, err = client.Records.EffectiveFilters("a.com", "plain.a.com", "A")
+		require.Nil(t, err)
+		require.Empty(t, chain)
+	})
+
+	t.Run("ApplyTemplate", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		zone := json.RawMessage(`{"zone":"example.com","records":[
+			{"domain":"www.example.com","type":"A"},
+			{"domain":"example.com","type":"MX"},
+			{"domain":"example.com","type":"TXT"},
+			{"domain":"alias.example.com","type":"CNAME","link":"www.example.com"}]}`)
+		www := `{"zone":"example.com","domain":"www.example.com","type":"A",
+			"answers":[{"answer":["1.2.3.4"]}],"filters":[],"tags":{"team":"web"}}`
+		mx := `{"zone":"example.com","domain":"example.com","type":"MX","ttl":300,
+			"answers":[{"answer":["10","mx.example.com"]}],"filters":[{"filter":"up","config":{}}]}`
+		txt := `{"zone":"example.com","domain":"example.com","type":"TXT","answers":[{"answer":["v=spf1 -all"]}],"filters":[]}`
+
+		decode := func(s string) *dns.Record {
+			var r dns.Record
+			require.Nil(t, json.Unmarshal([]byte(s), &r))
+			return &r
+		}
+		wwwWant := decode(www)
+		wwwWant.TTL = 600
+		wwwWant.Filters = []*filter.Filter{filter.NewShuffle()}
+		wwwWant.Tags["owner"] = "netops"
+		mxWant := decode(mx)
+		mxWant.Tags = map[string]string{"owner": "netops", "team": "dns"}
+		mxWant.Meta = &data.Meta{Note: "standard"}
+		wwwWant.Meta = &data.Meta{Note: "standard"}
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com", http.StatusOK, nil, nil, "", zone))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/www.example.com/A", http.StatusOK, nil, nil, "", json.RawMessage(www)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/example.com/MX", http.StatusOK, nil, nil, "", json.RawMessage(mx)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/example.com/TXT", http.StatusOK, nil, nil, "", json.RawMessage(txt)))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/zones/example.com/www.example.com/A", http.StatusOK, nil, nil, wwwWant, wwwWant))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/zones/example.com/example.com/MX", http.StatusOK, nil, nil, mxWant, mxWant))
+
+		tmpl := api.RecordTemplate{
+			TTL:     600,
+			Tags:    map[string]string{"owner": "netops", "team": "dns"},
+			Filters: []*filter.Filter{filter.NewShuffle()},
+			Meta:    &data.Meta{Note: "standard"},
+		}
+		notTXT := func(r *dns.Record) bool { return r.Type != "TXT" }
+		changes, _, err := client.Records.ApplyTemplate(context.Background(), "example.com", notTXT, tmpl)
+		require.Nil(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, "www.example.com", changes[0].Domain)
+		require.Len(t, changes[0].Fields, 4)
+		require.Equal(t, "MX", changes[1].Type)
+		require.Equal(t, "meta", changes[1].Fields[0].Field)
+		require.Equal(t, "tags", changes[1].Fields[1].Field)
+
+		// Overriding replaces the values the records already have.
+		mock.ClearTestCases()
+		mxOverride := decode(mx)
+		mxOverride.TTL = 600
+		mxOverride.Filters = []*filter.Filter{filter.NewShuffle()}
+		mxOverride.Tags = map[string]string{"owner": "netops", "team": "dns"}
+		mxOverride.Meta = &data.Meta{Note: "standard"}
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com", http.StatusOK, nil, nil, "", zone))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/www.example.com/A", http.StatusOK, nil, nil, "", json.RawMessage(www)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/example.com/MX", http.StatusOK, nil, nil, "", json.RawMessage(mx)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/example.com/TXT", http.StatusOK, nil, nil, "", json.RawMessage(txt)))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/zones/example.com/example.com/MX", http.StatusOK, nil, nil, mxOverride, mxOverride))
+
+		tmpl.Merge = api.TemplateOverride
+		onlyMX := func(r *dns.Record) bool { return r.Type == "MX" }
+		changes, _, err = client.Records.ApplyTemplate(context.Background(), "example.com", onlyMX, tmpl)
+		require.Nil(t, err)
+		require.Len(t, changes, 1)
+		require.Len(t, changes[0].Fields, 4)
+	})
+
+	t.Run("ApplyTemplate context", func(t *testing.T) {
+		c, unbound := unboundRequests(`{"zone":"example.com","records":[]}`)
+		ctx := context.WithValue(context.Background(), ctxKey{}, true)
+		_, _, err := c.Records.ApplyTemplate(ctx, "example.com", nil, api.RecordTemplate{})
+		require.Nil(t, err)
+		require.Empty(t, *unbound)
+	})
+}
+
+// ctxKey marks the context a call was given, to check its requests carry it.
+type ctxKey struct{}
+
+// unboundRequests returns a client answering every request with body, and
+// the paths of the requests made without the context marked with ctxKey.
+func unboundRequests(body string) (*api.Client, *[]string) {
+	var unbound []string
+	doer := api.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Context().Value(ctxKey{}) == nil {
+			unbound = append(unbound, r.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})
+	return api.NewClient(doer, api.SetEndpoint("https://api.example.com/v1/")), &unbound
+}
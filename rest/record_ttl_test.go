@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_LowerTTLAndWait(t *testing.T) {
+	defer func(u time.Duration) { ttlUnit = u }(ttlUnit)
+	ttlUnit = time.Millisecond
+
+	var posted []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			b, _ := ioutil.ReadAll(r.Body)
+			var rec struct {
+				TTL int `json:"ttl"`
+			}
+			assert.NoError(t, json.Unmarshal(b, &rec))
+			posted = append(posted, rec.TTL)
+			w.Write(b) // nolint: errcheck
+			return
+		}
+		w.Write([]byte(`{"zone":"example.com","domain":"www.example.com","type":"A","ttl":50,"answers":[{"answer":["1.2.3.4"]}]}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	start := time.Now()
+	oldTTL, newTTL, _, err := c.Records.LowerTTLAndWait(context.Background(), "example.com", "www.example.com", "A", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 50, oldTTL)
+	assert.Equal(t, 5, newTTL)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	// Raising the TTL does not wait.
+	_, _, _, err = c.Records.LowerTTLAndWait(context.Background(), "example.com", "www.example.com", "A", 300)
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 300}, posted)
+
+	ttlUnit = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, _, err = c.Records.LowerTTLAndWait(ctx, "example.com", "www.example.com", "A", 5)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// A done ctx sends no update.
+	posted = nil
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, _, _, err = c.Records.LowerTTLAndWait(ctx, "example.com", "www.example.com", "A", 5)
+	assert.True(t, errors.Is(err, context.Canceled), err)
+	assert.Empty(t, posted)
+}
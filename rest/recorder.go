@@ -0,0 +1,201 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// RecordedExchange is a request/response pair written by the recorder set
+// with SetRecorder, one JSON object per line. The mockns1 package can load
+// them as test cases for replay.
+type RecordedExchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the request half of a RecordedExchange. URI is the
+// path and query of the request, e.g. "/v1/zones/example.com".
+type RecordedRequest struct {
+	Method  string          `json:"method"`
+	URI     string          `json:"uri"`
+	Headers http.Header     `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response half of a RecordedExchange.
+type RecordedResponse struct {
+	Status  int             `json:"status"`
+	Headers http.Header     `json:"headers,omitempty"`
+	Body    json.RawMessage `json:"body,omitempty"`
+}
+
+const redacted = "REDACTED"
+
+// recorder serializes exchanges to a writer. It is shared by pointer between
+// copies of a Client, and is safe for concurrent use.
+type recorder struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetRecorder makes the client write every request/response pair it sends to
+// w as a RecordedExchange, e.g. to build golden files for tests. The API key
+// header, and other request and response headers that look like
+// credentials, are redacted, as are the "key" and "secret" string fields of
+// JSON bodies (API and TSIG keys); a request whose body has them no longer
+// matches its recording on replay. A nil w disables recording. Errors
+// writing to w are logged, and do not fail the request.
+func SetRecorder(w io.Writer) func(*Client) {
+	return func(c *Client) {
+		if w == nil {
+			c.recorder = nil
+			return
+		}
+		c.recorder = &recorder{w: w}
+	}
+}
+
+// requestBody returns a copy of the body of req, without consuming it.
+func requestBody(req *http.Request) []byte {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+	b, _ := ioutil.ReadAll(body)
+	return b
+}
+
+// record writes the exchange, replacing resp.Body so it can still be read.
+// If the body fails to read, the replacement fails with the same error once
+// the part that was read is consumed.
+func (r *recorder) record(req *http.Request, reqBody []byte, resp *http.Response) error {
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		resp.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(respBody), errReader{err}))
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	ex := RecordedExchange{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URI:     req.URL.RequestURI(),
+			Headers: redactHeaders(req.Header),
+			Body:    rawJSON(redactBody(reqBody)),
+		},
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: redactHeaders(resp.Header),
+			Body:    rawJSON(redactBody(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return json.NewEncoder(r.w).Encode(ex)
+}
+
+// errReader is a reader that always fails with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// redactHeaders returns a copy of h with the values of sensitive headers
+// redacted.
+func redactHeaders(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	headers := h.Clone()
+	for k := range headers {
+		if sensitiveHeader(k) {
+			headers.Set(k, redacted)
+		}
+	}
+	return headers
+}
+
+// sensitiveHeader reports whether the header of the given name likely
+// carries credentials: the API key, a session cookie, or e.g. a gateway's
+// auth token.
+func sensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	if name == strings.ToLower(headerAuth) || name == "cookie" || name == "set-cookie" {
+		return true
+	}
+	for _, s := range []string{"auth", "token", "key", "secret", "password"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveFields are the JSON fields of bodies that carry credentials: the
+// key of an API key (APIKeysService.Create and Rotate) and the secret of a
+// TSIG key.
+var sensitiveFields = map[string]bool{"key": true, "secret": true}
+
+// redactBody returns the JSON body b with the string values of its
+// sensitive fields, at any depth, redacted. Other bodies are returned as is.
+func redactBody(b []byte) []byte {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || !redactValue(v) {
+		return b
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+// redactValue redacts the sensitive fields of the decoded JSON value v,
+// reporting whether any was found.
+func redactValue(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if _, ok := field.(string); ok && sensitiveFields[strings.ToLower(k)] {
+				v[k] = redacted
+				found = true
+			} else if redactValue(field) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if redactValue(elem) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// rawJSON returns b as a JSON value, quoting it as a string if it is not
+// valid JSON.
+func rawJSON(b []byte) json.RawMessage {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return nil
+	}
+	if json.Valid(b) {
+		return b
+	}
+	quoted, _ := json.Marshal(string(b))
+	return quoted
+}
@@ -0,0 +1,273 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+// RedirectService handles 'redirect' endpoint.
+type RedirectService service
+
+// List returns the redirect configurations of the account, following the
+// pages of the list until all of them are listed. WithParam("limit", n)
+// sets the size of a page, and WithParam("offset", n) skips the first n.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-get
+func (s *RedirectService) List(opts ...RequestOption) ([]*redirect.Configuration, *http.Response, error) {
+	all := []*redirect.Configuration{}
+	resp, err := redirectPages(s.client, "redirect", opts,
+		func(req *http.Request) (int, int, *http.Response, error) {
+			var cl redirect.ConfigurationList
+			resp, err := s.client.Do(req, &cl)
+			all = append(all, cl.Results...)
+			return len(cl.Results), cl.Total, resp, err
+		})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return all, resp, nil
+}
+
+// Get takes a redirect configuration id and returns the configuration.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-id-get
+func (s *RedirectService) Get(id string) (*redirect.Configuration, *http.Response, error) {
+	path := fmt.Sprintf("redirect/%s", id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c redirect.Configuration
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		return nil, resp, redirectError(err)
+	}
+
+	return &c, resp, nil
+}
+
+// Create takes a *Configuration and creates a new redirect. Its ID is set
+// from the response.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-put
+func (s *RedirectService) Create(c *redirect.Configuration) (*http.Response, error) {
+	req, err := s.client.NewRequest("PUT", "redirect", &c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update configuration fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			if err.(*Error).statusCode() == http.StatusConflict {
+				return resp, ErrRedirectExists
+			}
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Update takes a *Configuration and modifies the redirect.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-id-post
+func (s *RedirectService) Update(c *redirect.Configuration) (*http.Response, error) {
+	path := fmt.Sprintf("redirect/%s", c.ID)
+
+	req, err := s.client.NewRequest("POST", path, &c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update configuration fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		return resp, redirectError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes a redirect configuration id and deletes the redirect.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-id-delete
+func (s *RedirectService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("redirect/%s", id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, redirectError(err)
+	}
+
+	return resp, nil
+}
+
+// RedirectCertificateService handles 'redirect/certificates' endpoint.
+type RedirectCertificateService service
+
+// List returns the redirect certificates of the account, following the
+// pages of the list as RedirectService.List does.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-certificates-get
+func (s *RedirectCertificateService) List(opts ...RequestOption) ([]*redirect.Certificate, *http.Response, error) {
+	all := []*redirect.Certificate{}
+	resp, err := redirectPages(s.client, "redirect/certificates", opts,
+		func(req *http.Request) (int, int, *http.Response, error) {
+			var cl redirect.CertificateList
+			resp, err := s.client.Do(req, &cl)
+			all = append(all, cl.Results...)
+			return len(cl.Results), cl.Total, resp, err
+		})
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return all, resp, nil
+}
+
+// Get takes a certificate id and returns the certificate.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-certificates-id-get
+func (s *RedirectCertificateService) Get(id string) (*redirect.Certificate, *http.Response, error) {
+	path := fmt.Sprintf("redirect/certificates/%s", id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c redirect.Certificate
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		return nil, resp, redirectCertError(err)
+	}
+
+	return &c, resp, nil
+}
+
+// Create requests a certificate for domain. NS1 obtains it asynchronously;
+// the returned certificate is Processing until it is issued.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-certificates-put
+func (s *RedirectCertificateService) Create(domain string) (*redirect.Certificate, *http.Response, error) {
+	req, err := s.client.NewRequest("PUT", "redirect/certificates", &redirect.Certificate{Domain: domain})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var c redirect.Certificate
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &c, resp, nil
+}
+
+// Renew takes a certificate id and asks NS1 to renew the certificate.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-certificates-id-post
+func (s *RedirectCertificateService) Renew(id string) (*http.Response, error) {
+	path := fmt.Sprintf("redirect/certificates/%s", id)
+
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, redirectCertError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes a certificate id and revokes and deletes the certificate.
+//
+// NS1 API docs: https://ns1.com/api/#redirect-certificates-id-delete
+func (s *RedirectCertificateService) Delete(id string) (*http.Response, error) {
+	path := fmt.Sprintf("redirect/certificates/%s", id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, redirectCertError(err)
+	}
+
+	return resp, nil
+}
+
+// redirectPages requests the pages of a redirect list, from the offset opts
+// select, until the total number of results is listed. page does a request
+// and returns the number of results of its page and the total. The response
+// of the last page is returned.
+func redirectPages(c *Client, path string, opts []RequestOption, page func(*http.Request) (int, int, *http.Response, error)) (*http.Response, error) {
+	offset := -1
+	for {
+		pageOpts := opts
+		if offset >= 0 {
+			pageOpts = append(opts[:len(opts):len(opts)], WithParam("offset", strconv.Itoa(offset)))
+		}
+		req, err := c.NewRequest("GET", path, nil, pageOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if offset < 0 {
+			offset, _ = strconv.Atoi(req.URL.Query().Get("offset"))
+		}
+
+		n, total, resp, err := page(req)
+		if err != nil {
+			return resp, err
+		}
+		offset += n
+		if n == 0 || offset >= total {
+			return resp, nil
+		}
+	}
+}
+
+// redirectError maps a 404 for a redirect to ErrRedirectMissing.
+func redirectError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrRedirectMissing
+	}
+	return err
+}
+
+// redirectCertError maps a 404 for a redirect certificate to
+// ErrRedirectCertificateMissing.
+func redirectCertError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		return ErrRedirectCertificateMissing
+	}
+	return err
+}
+
+var (
+	// ErrRedirectExists bundles PUT create error.
+	ErrRedirectExists = existsError("redirect already exists")
+	// ErrRedirectMissing bundles GET/POST/DELETE error.
+	ErrRedirectMissing = missingError("redirect does not exist")
+	// ErrRedirectCertificateMissing bundles GET/POST/DELETE error.
+	ErrRedirectCertificateMissing = missingError("redirect certificate does not exist")
+)
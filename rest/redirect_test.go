@@ -0,0 +1,82 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/redirect"
+)
+
+func TestRedirect(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("Configurations", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		cfg := redirect.NewConfiguration("example.com", "/", "https://www.example.com")
+		created := *cfg
+		created.ID = "r-1"
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/redirect", http.StatusOK, nil, nil, cfg, &created))
+		other := *redirect.NewConfiguration("example.com", "/docs", "https://docs.example.com")
+		other.ID = "r-2"
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/redirect?limit=1", http.StatusOK, nil, nil, "",
+			redirect.ConfigurationList{Count: 1, Total: 2, Results: []*redirect.Configuration{&created}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/redirect?limit=1&offset=1", http.StatusOK, nil, nil, "",
+			redirect.ConfigurationList{Count: 1, Total: 2, Results: []*redirect.Configuration{&other}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/redirect/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "redirect not found"}`))
+
+		_, err := client.Redirects.Create(cfg)
+		require.Nil(t, err)
+		require.Equal(t, "r-1", cfg.ID)
+
+		// The read-only update time is not sent.
+		b, err := json.Marshal(cfg)
+		require.Nil(t, err)
+		require.NotContains(t, string(b), "lastUpdated")
+
+		cl, _, err := client.Redirects.List(api.WithParam("limit", "1"))
+		require.Nil(t, err)
+		require.Len(t, cl, 2)
+		require.Equal(t, redirect.Permanent, cl[0].ForwardingType)
+		require.Equal(t, "r-2", cl[1].ID)
+
+		_, _, err = client.Redirects.Get("gone")
+		require.Equal(t, api.ErrRedirectMissing, err)
+	})
+
+	t.Run("Certificates", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/redirect/certificates", http.StatusOK, nil, nil,
+			&redirect.Certificate{Domain: "example.com"},
+			`{"id": "c-1", "domain": "example.com", "processing": true}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/redirect/certificates", http.StatusOK, nil, nil, "",
+			`{"count": 1, "total": 2, "results": [{"id": "c-1", "domain": "example.com"}]}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/redirect/certificates?offset=1", http.StatusOK, nil, nil, "",
+			`{"count": 1, "total": 2, "results": [{"id": "c-2", "domain": "example.net"}]}`))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/redirect/certificates/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "certificate not found"}`))
+
+		c, _, err := client.RedirectCerts.Create("example.com")
+		require.Nil(t, err)
+		require.Equal(t, "c-1", c.ID)
+		require.True(t, c.Processing)
+
+		cl, _, err := client.RedirectCerts.List()
+		require.Nil(t, err)
+		require.Len(t, cl, 2)
+		require.Equal(t, "c-2", cl[1].ID)
+
+		_, err = client.RedirectCerts.Renew("gone")
+		require.Equal(t, api.ErrRedirectCertificateMissing, err)
+	})
+}
@@ -14,8 +14,8 @@ type ReservationService service
 // List returns a list of all reservations.
 //
 // NS1 API docs: https://ns1.com/api#getlist-reservations
-func (s *ReservationService) List() ([]dhcp.Reservation, *http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "dhcp/reservation", nil)
+func (s *ReservationService) List(opts ...RequestOption) ([]dhcp.Reservation, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "dhcp/reservation", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+	defaultRetryJitter    = 0.5
+)
+
+// RetryPolicy configures Do to retry requests that failed with a 429 Too
+// Many Requests or a 5xx response (other than 501 Not Implemented). Once
+// the retries are exhausted the last response and its *Error are returned.
+// Transport errors are not retried, nor are successful responses with a
+// truncated body unless enabled with SetRetryOnDecodeError for GET, HEAD and
+// OPTIONS requests.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS and DELETE) are retried
+// unless RetryNonIdempotent is set. PUT is not among them, as NS1 creates
+// resources with PUT: a retried create whose first attempt did take effect
+// reports that the resource already exists.
+//
+// Request bodies are replayed on each attempt. Requests built by NewRequest
+// support this already; other bodies are buffered in memory first.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+
+	// BaseDelay is the backoff before the first retry, doubled for each
+	// following one and randomized (see Jitter). It defaults to 500ms.
+	BaseDelay time.Duration
+
+	// Jitter is the fraction of each backoff that is randomized, to spread
+	// out clients retrying together: with the default of 0.5, a backoff of
+	// 1s becomes a wait between 0.5s and 1s. A negative Jitter disables it.
+	Jitter float64
+
+	// MaxDelay caps the backoff, and defaults to 30s. A Retry-After header
+	// asking for a longer wait than the backoff is honored up to MaxDelay;
+	// if it asks for more (e.g. during NS1 maintenance, see
+	// ErrServiceUnavailable) the error is returned without retrying. A 429
+	// without Retry-After whose rate limit headers report no requests
+	// remaining waits at least for a request to be available again
+	// (RateLimit.WaitTime), up to MaxDelay.
+	MaxDelay time.Duration
+
+	// RetryNonIdempotent allows retrying POST, PUT (and PATCH) requests,
+	// which may apply their change twice.
+	RetryNonIdempotent bool
+}
+
+// SetRetry makes the client retry failed idempotent requests up to
+// maxRetries times, with exponential backoff starting at baseDelay. See
+// RetryPolicy for the details; use SetRetryPolicy for more control.
+func SetRetry(maxRetries int, baseDelay time.Duration) func(*Client) {
+	return SetRetryPolicy(RetryPolicy{MaxRetries: maxRetries, BaseDelay: baseDelay})
+}
+
+// SetRetryPolicy sets a Client instances' RetryPolicy.
+func SetRetryPolicy(p RetryPolicy) func(*Client) {
+	return func(c *Client) { c.Retry = &p }
+}
+
+// SetRetryOnDecodeError makes the client's Retry policy also apply to
+// successful responses whose JSON body fails to decode as truncated, e.g.
+// cut off by a proxy, see Client.RetryOnDecodeError. Only GET, HEAD and
+// OPTIONS requests are sent again: a write that succeeded is not repeated.
+func SetRetryOnDecodeError(retry bool) func(*Client) {
+	return func(c *Client) { c.RetryOnDecodeError = retry }
+}
+
+// readOnly reports whether a request of the method has no effect on the
+// server, so its successful response may be fetched again.
+func readOnly(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// decodeError reports whether err is the failure to decode a truncated or
+// otherwise malformed JSON body, as opposed to a body of the wrong shape.
+func decodeError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &syntaxErr)
+}
+
+// allows reports whether requests of the given method may be retried.
+func (p *RetryPolicy) allows(method string) bool {
+	if p.MaxRetries < 1 {
+		return false
+	}
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "DELETE":
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// backoff returns the wait before the given retry (starting at 0) of a
+// request whose last attempt got resp (nil for a decode error), and false if
+// the request should not be retried after all.
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) (time.Duration, bool) {
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = defaultRetryBaseDelay
+	}
+	if max <= 0 {
+		max = defaultRetryMaxDelay
+	}
+
+	d := base
+	for i := 0; i < retry && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	jitter := p.Jitter
+	switch {
+	case jitter == 0:
+		jitter = defaultRetryJitter
+	case jitter < 0:
+		jitter = 0
+	case jitter > 1:
+		jitter = 1
+	}
+	if spread := int64(float64(d) * jitter); spread > 0 {
+		d = d - time.Duration(spread) + time.Duration(rand.Int63n(spread+1))
+	}
+
+	if resp == nil {
+		return d, true
+	}
+	now := time.Now()
+	if at, ok := parseRetryAfter(resp.Header, now); ok {
+		wait := at.Sub(now)
+		if wait > max {
+			return 0, false
+		}
+		if wait > d {
+			d = wait
+		}
+		return d, true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rl := parseRate(resp)
+		if wait := rl.WaitTime(); rl.Limit > 0 && rl.Remaining == 0 && wait > d {
+			d = wait
+			if d > max {
+				d = max
+			}
+		}
+	}
+	return d, true
+}
+
+// retryable reports whether an attempt that got resp should be retried.
+func retryable(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+	c := resp.StatusCode
+	return c == http.StatusTooManyRequests || (c >= 500 && c != http.StatusNotImplemented)
+}
+
+// rateWaitKey is the context key of the time the rate limit strategy waited
+// on the response of the current attempt of doWithRetry.
+type rateWaitKey struct{}
+
+// doWithRetry is Do for a request the client's Retry policy applies to.
+// Once the request's context is done, no further attempt is made and a
+// pending backoff is cut short, returning the context's error.
+func (c Client) doWithRetry(req *http.Request, v interface{}) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(b)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+	waited := new(time.Duration)
+	req = req.WithContext(context.WithValue(req.Context(), rateWaitKey{}, waited))
+
+	for retry := 0; ; retry++ {
+		if retry > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		// A canceled request is not attempted again, even if its backoff
+		// was already over.
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		*waited = 0
+		resp, err := c.do(req, v, retry > 0)
+		if err == nil || retry >= c.Retry.MaxRetries {
+			return resp, err
+		}
+		// A decoding error returns no response: the body was consumed. The
+		// request did succeed, so only reads are sent again.
+		truncated := resp == nil && c.RetryOnDecodeError && readOnly(req.Method) && decodeError(err)
+		if !truncated && !retryable(resp) {
+			return resp, err
+		}
+		wait, ok := c.Retry.backoff(retry, resp)
+		if !ok {
+			return resp, err
+		}
+		// The rate limit strategy may already have waited on the response,
+		// e.g. for its Retry-After; only the rest of the backoff is left.
+		if wait -= *waited; wait < 0 {
+			wait = 0
+		}
+
+		start := time.Now()
+		sleepErr := sleepContext(req.Context(), wait)
+		c.counters.backedOff(time.Since(start))
+		if sleepErr != nil {
+			return resp, sleepErr
+		}
+	}
+}
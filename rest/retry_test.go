@@ -0,0 +1,327 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Retry(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		failures int
+		bodies   []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, strings.TrimSpace(string(b)))
+		if r.URL.Path == "/maintenance" {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message": "maintenance"}`)) // nolint: errcheck
+			return
+		}
+		if failures > 0 {
+			failures--
+			if failures%2 == 0 {
+				w.WriteHeader(http.StatusTooManyRequests)
+			} else {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+			w.Write([]byte(`{"message": "try again"}`)) // nolint: errcheck
+			return
+		}
+		w.Write([]byte(`{"zone": "example.com"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	reset := func(n int) {
+		mu.Lock()
+		failures, bodies = n, nil
+		mu.Unlock()
+	}
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetry(3, time.Millisecond))
+
+	t.Run("replays body until success", func(t *testing.T) {
+		reset(2)
+		c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetryPolicy(RetryPolicy{
+			MaxRetries: 3, BaseDelay: time.Millisecond, RetryNonIdempotent: true,
+		}))
+		req, err := c.NewRequest("PUT", "zones/example.com", map[string]string{"zone": "example.com"})
+		require.Nil(t, err)
+
+		var v map[string]string
+		_, err = c.Do(req, &v)
+		require.Nil(t, err)
+		assert.Equal(t, "example.com", v["zone"])
+		assert.Equal(t, []string{`{"zone":"example.com"}`, `{"zone":"example.com"}`, `{"zone":"example.com"}`}, bodies)
+
+		stats := c.RequestStats()
+		assert.Equal(t, int64(3), stats.Attempts)
+		assert.Equal(t, int64(2), stats.Retries)
+		assert.Equal(t, int64(1), stats.RateLimited)
+		assert.True(t, stats.BackoffTime > 0)
+	})
+
+	t.Run("custom body", func(t *testing.T) {
+		reset(1)
+		req, err := http.NewRequest("DELETE", ts.URL+"/zones/example.com", ioutil.NopCloser(strings.NewReader("x")))
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		require.Nil(t, err)
+		assert.Equal(t, []string{"x", "x"}, bodies)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		reset(10)
+		req, err := c.NewRequest("GET", "zones/example.com", nil)
+		require.Nil(t, err)
+		resp, err := c.Do(req, nil)
+		require.NotNil(t, err)
+		restErr, ok := err.(*Error)
+		require.True(t, ok)
+		assert.Equal(t, "try again", restErr.Message)
+		assert.Equal(t, resp, restErr.Resp)
+		assert.Len(t, bodies, 4)
+	})
+
+	t.Run("does not retry POST or PUT", func(t *testing.T) {
+		for _, method := range []string{"POST", "PUT"} {
+			reset(1)
+			req, err := c.NewRequest(method, "zones/example.com", map[string]string{})
+			require.Nil(t, err)
+			_, err = c.Do(req, nil)
+			require.NotNil(t, err, method)
+			assert.Len(t, bodies, 1, method)
+		}
+
+		// Unless opted in.
+		reset(1)
+		c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetryPolicy(RetryPolicy{
+			MaxRetries: 1, BaseDelay: time.Millisecond, RetryNonIdempotent: true,
+		}))
+		req, err := c.NewRequest("POST", "zones/example.com", map[string]string{})
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		require.Nil(t, err)
+		assert.Len(t, bodies, 2)
+	})
+
+	t.Run("long Retry-After", func(t *testing.T) {
+		reset(0)
+		req, err := c.NewRequest("GET", "maintenance", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		assert.True(t, errors.Is(err, ErrServiceUnavailable))
+		assert.Len(t, bodies, 1)
+	})
+
+	t.Run("canceled during backoff", func(t *testing.T) {
+		reset(10)
+		c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetry(3, time.Minute))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, err := c.NewRequestWithContext(ctx, "GET", "zones/example.com", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Len(t, bodies, 1)
+	})
+
+	t.Run("canceled mid-backoff returns promptly", func(t *testing.T) {
+		reset(10)
+		c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetry(3, time.Minute))
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		req, err := c.NewRequestWithContext(ctx, "GET", "zones/example.com", nil)
+		require.Nil(t, err)
+		start := time.Now()
+		_, err = c.Do(req, nil)
+		assert.Equal(t, context.Canceled, err)
+		assert.True(t, time.Since(start) < time.Second, time.Since(start))
+		assert.Len(t, bodies, 1)
+	})
+
+	t.Run("not attempted once canceled", func(t *testing.T) {
+		reset(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, err := c.NewRequestWithContext(ctx, "GET", "zones/example.com", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, bodies)
+	})
+}
+
+func TestClient_RetryAfterWaitsOnce(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		status []int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(status) > 0 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(status[0])
+			status = status[1:]
+			w.Write([]byte(`{"message": "try again"}`)) // nolint: errcheck
+			return
+		}
+		w.Write([]byte(`{}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	// The strategy sleeps for the Retry-After of the 429, and the backoff
+	// does not wait for it a second time.
+	status = []int{http.StatusTooManyRequests}
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"), SetRetry(1, time.Millisecond))
+	c.RateLimitStrategySleep()
+	req, err := c.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	start := time.Now()
+	_, err = c.Do(req, nil)
+	require.Nil(t, err)
+	took := time.Since(start)
+	assert.True(t, took >= 900*time.Millisecond, took)
+	assert.True(t, took < 1800*time.Millisecond, took)
+
+	// The Retry-After of a 503 is not a rate limit, so the strategy does
+	// not sleep for it.
+	status = []int{http.StatusServiceUnavailable}
+	c = NewClient(nil, SetEndpoint(ts.URL+"/"))
+	c.RateLimitStrategySleep()
+	req, err = c.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	start = time.Now()
+	_, err = c.Do(req, nil)
+	require.NotNil(t, err)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestClient_RetryOnDecodeError(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts int
+		bodies   []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		if attempts == 1 {
+			w.Write([]byte(`{"zone": "exa`)) // nolint: errcheck
+			return
+		}
+		w.Write([]byte(`{"zone": "example.com"}`)) // nolint: errcheck
+	}))
+	defer ts.Close()
+
+	reset := func() {
+		mu.Lock()
+		attempts, bodies = 0, nil
+		mu.Unlock()
+	}
+
+	retry := SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+	client := NewClient(nil, SetEndpoint(ts.URL+"/"), retry, SetRetryOnDecodeError(true))
+	req, _ := client.NewRequest("GET", "zones/example.com", nil)
+	var v map[string]string
+	_, err := client.Do(req, &v)
+	require.Nil(t, err)
+	assert.Equal(t, "example.com", v["zone"])
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, int64(1), client.RequestStats().Retries)
+
+	// A write that succeeded is not sent again, even with a body that
+	// replays.
+	reset()
+	req, _ = client.NewRequest("DELETE", "zones/example.com", map[string]string{"zone": "example.com"})
+	_, err = client.Do(req, &v)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), err)
+	assert.Equal(t, 1, attempts)
+	assert.Len(t, bodies, 1)
+
+	// Without the option, the decode error is returned.
+	reset()
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), retry)
+	req, _ = client.NewRequest("GET", "zones/example.com", nil)
+	_, err = client.Do(req, &v)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), err)
+	assert.Equal(t, 1, attempts)
+
+	// Bodies of the wrong shape are not retried.
+	reset()
+	client = NewClient(nil, SetEndpoint(ts.URL+"/"), retry, SetRetryOnDecodeError(true))
+	mu.Lock()
+	attempts = 1
+	mu.Unlock()
+	req, _ = client.NewRequest("GET", "zones/example.com", nil)
+	var wrong []string
+	_, err = client.Do(req, &wrong)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for i := 0; i < 20; i++ {
+		d, ok := p.backoff(1, nil)
+		assert.True(t, ok)
+		assert.True(t, d >= 100*time.Millisecond && d <= 200*time.Millisecond, "%s", d)
+	}
+
+	p.Jitter = -1
+	d, _ := p.backoff(2, nil)
+	assert.Equal(t, 400*time.Millisecond, d)
+	d, _ = p.backoff(10, nil)
+	assert.Equal(t, time.Second, d)
+
+	p.Jitter = 1
+	for i := 0; i < 20; i++ {
+		d, _ := p.backoff(0, nil)
+		assert.True(t, d >= 0 && d <= 100*time.Millisecond, "%s", d)
+	}
+
+	// An exhausted rate limit waits for the next request to be available.
+	p.Jitter = -1
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set(headerRateLimit, "2")
+	resp.Header.Set(headerRateRemaining, "0")
+	resp.Header.Set(headerRatePeriod, "1")
+	d, ok := p.backoff(0, resp)
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, d)
+
+	resp.Header.Set(headerRatePeriod, "60")
+	d, _ = p.backoff(0, resp)
+	assert.Equal(t, time.Second, d)
+
+	resp.Header.Set(headerRateRemaining, "1")
+	d, _ = p.backoff(0, resp)
+	assert.Equal(t, 100*time.Millisecond, d)
+
+	// Retry-After takes precedence.
+	resp.Header.Set(headerRateRemaining, "0")
+	resp.Header.Set("Retry-After", "0")
+	d, _ = p.backoff(0, resp)
+	assert.Equal(t, 100*time.Millisecond, d)
+}
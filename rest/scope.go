@@ -14,8 +14,8 @@ type ScopeService service
 // List returns a list of all scopes.
 //
 // NS1 API docs: https://ns1.com/api#getlist-scopes
-func (s *ScopeService) List() ([]dhcp.Scope, *http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "dhcp/scope", nil)
+func (s *ScopeService) List(opts ...RequestOption) ([]dhcp.Scope, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "dhcp/scope", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
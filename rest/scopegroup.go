@@ -13,8 +13,8 @@ type ScopeGroupService service
 // List returns a list of all scope groups.
 //
 // NS1 API docs: https://ns1.com/api#getlist-scope-groups
-func (s *ScopeGroupService) List() ([]dhcp.ScopeGroup, *http.Response, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "dhcp/scopegroup", nil)
+func (s *ScopeGroupService) List(opts ...RequestOption) ([]dhcp.ScopeGroup, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "dhcp/scopegroup", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
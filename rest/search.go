@@ -0,0 +1,248 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+const (
+	defaultSearchParallelism = 4
+	defaultSearchMax         = 1000
+)
+
+// SearchService handles the 'search' endpoint.
+type SearchService service
+
+// AnswerMatch is an answer found by SearchService.FindAnswersWithRData, with
+// the record it belongs to and its position among the record's answers.
+type AnswerMatch struct {
+	Zone   string
+	Domain string
+	Type   string
+	Index  int
+	Answer *dns.Answer
+}
+
+func (m AnswerMatch) String() string {
+	return fmt.Sprintf("%s %s %s [%d] %s", m.Zone, m.Domain, m.Type, m.Index, m.Answer)
+}
+
+// searchResult identifies a record found by the search endpoint.
+type searchResult struct {
+	Zone   string
+	Domain string
+	Type   string
+}
+
+// SearchType selects the kinds of results of Search.
+type SearchType string
+
+// The SearchTypes.
+const (
+	SearchAll    SearchType = "all"
+	SearchZone   SearchType = "zone"
+	SearchRecord SearchType = "record"
+	SearchAnswer SearchType = "answer"
+)
+
+// SearchResult is a result of Search: a zone, or a record (Domain and Type
+// set), which for an answer search is the record holding the answer. Raw is
+// the whole result as returned, for the fields of each kind of result that
+// are not decoded.
+type SearchResult struct {
+	Zone   string `json:"zone"`
+	Domain string `json:"domain,omitempty"`
+	Type   string `json:"type,omitempty"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// IsRecord reports whether the result is a record rather than a zone.
+func (r *SearchResult) IsRecord() bool {
+	return r.Domain != "" && r.Type != ""
+}
+
+// UnmarshalJSON decodes a result, keeping it in Raw.
+func (r *SearchResult) UnmarshalJSON(b []byte) error {
+	type result SearchResult
+	var v result
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*r = SearchResult(v)
+	r.Raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// Search looks up query, e.g. an IP address or a host name, across the
+// zones, records and answers of the account, returning the results of type
+// t (SearchAll for all of them). opts set further parameters, e.g.
+// SetIntParam("max", 100) for the number of results. Unlike
+// FindAnswersWithRData, results are returned as the endpoint finds them,
+// without reading the records to confirm the match.
+//
+// NS1 API docs: https://ns1.com/api/#search-get
+func (s *SearchService) Search(query string, t SearchType, opts ...func(*url.Values)) ([]*SearchResult, *http.Response, error) {
+	v := url.Values{"q": {query}}
+	if t != "" {
+		v.Set("type", string(t))
+	}
+	for _, opt := range opts {
+		opt(&v)
+	}
+
+	req, err := s.client.NewRequest("GET", "search", nil, WithParams(v))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := []*SearchResult{}
+	resp, err := s.client.Do(req, &results)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return results, resp, nil
+}
+
+// FindAnswersWithRData returns every answer of the account with rdata among
+// its fields, e.g. an IP address being decommissioned, with the zone and
+// record it is in, sorted by zone, domain and type. Host names match
+// regardless of case and trailing dot.
+//
+// Candidate records are looked up with the search endpoint. Where it is
+// unavailable (it returns 404, e.g. on some DDI deployments), all zones are
+// scanned instead, which takes a request per zone. Either way each candidate
+// record is then read to confirm the match, at most 4 at a time through the
+// client's rate limiting; use the same parallelism with
+// RateLimitStrategyConcurrent.
+//
+// NS1 API docs: https://ns1.com/api/#search-get
+func (s *SearchService) FindAnswersWithRData(rdata string) ([]AnswerMatch, *http.Response, error) {
+	candidates, resp, err := s.searchRecords(rdata)
+	if errors.Is(err, ErrNotFound) {
+		candidates, resp, err = s.scanRecords(rdata)
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	matches := make([][]AnswerMatch, len(candidates))
+	errs := make([]error, len(candidates))
+	resps := make([]*http.Response, len(candidates))
+	parallel(len(candidates), defaultSearchParallelism, func(i int) {
+		c := candidates[i]
+		r, resp, err := s.client.Records.Get(c.Zone, c.Domain, c.Type)
+		resps[i] = resp
+		if err == ErrRecordMissing {
+			return // deleted since the search
+		}
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		for j, a := range r.Answers {
+			if answerHasRData(a.Rdata, rdata) {
+				matches[i] = append(matches[i], AnswerMatch{Zone: r.Zone, Domain: r.Domain, Type: r.Type, Index: j, Answer: a})
+			}
+		}
+	})
+
+	var found []AnswerMatch
+	for i := range candidates {
+		if errs[i] != nil {
+			return nil, resps[i], errs[i]
+		}
+		found = append(found, matches[i]...)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		if a.Domain != b.Domain {
+			return a.Domain < b.Domain
+		}
+		return a.Type < b.Type
+	})
+	return found, resp, nil
+}
+
+// searchRecords returns the records the search endpoint finds for rdata.
+func (s *SearchService) searchRecords(rdata string) ([]searchResult, *http.Response, error) {
+	results, resp, err := s.Search(rdata, SearchRecord, SetIntParam("max", defaultSearchMax))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	seen := map[searchResult]bool{}
+	var records []searchResult
+	for _, res := range results {
+		r := searchResult{Zone: res.Zone, Domain: res.Domain, Type: res.Type}
+		if r.Zone == "" || !res.IsRecord() || seen[r] {
+			continue
+		}
+		seen[r] = true
+		records = append(records, r)
+	}
+	return records, resp, nil
+}
+
+// scanRecords returns the records of all zones with a short answer
+// containing rdata.
+func (s *SearchService) scanRecords(rdata string) ([]searchResult, *http.Response, error) {
+	zones, resp, err := s.client.Zones.List()
+	if err != nil {
+		return nil, resp, err
+	}
+
+	found := make([][]searchResult, len(zones))
+	errs := make([]error, len(zones))
+	resps := make([]*http.Response, len(zones))
+	parallel(len(zones), defaultSearchParallelism, func(i int) {
+		z, resp, err := s.client.Zones.Get(zones[i].Zone)
+		if err == ErrZoneMissing {
+			return // deleted since the listing
+		}
+		resps[i], errs[i] = resp, err
+		if err != nil {
+			return
+		}
+		for _, zr := range z.Records {
+			for _, short := range zr.ShortAns {
+				if answerHasRData(strings.Fields(short), rdata) {
+					found[i] = append(found[i], searchResult{Zone: z.Zone, Domain: zr.Domain, Type: zr.Type})
+					break
+				}
+			}
+		}
+	})
+
+	var records []searchResult
+	for i := range zones {
+		if errs[i] != nil {
+			return nil, resps[i], errs[i]
+		}
+		records = append(records, found[i]...)
+	}
+	return records, resp, nil
+}
+
+// answerHasRData reports whether one of the rdata fields is rdata.
+func answerHasRData(fields []string, rdata string) bool {
+	norm := func(s string) string { return strings.ToLower(strings.TrimSuffix(s, ".")) }
+	want := norm(rdata)
+	for _, f := range fields {
+		if norm(f) == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestSearch(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	searchURI := "/search?max=1000&q=1.2.3.4&type=record"
+	www := json.RawMessage(`{"zone":"a.com","domain":"www.a.com","type":"A",
+		"answers":[{"answer":["5.6.7.8"]},{"answer":["1.2.3.4"]}]}`)
+	mx := json.RawMessage(`{"zone":"b.com","domain":"b.com","type":"MX",
+		"answers":[{"answer":["10","mail.b.com"]}]}`)
+	api1 := json.RawMessage(`{"zone":"b.com","domain":"api.b.com","type":"A",
+		"answers":[{"answer":["1.2.3.4"]}]}`)
+
+	t.Run("Search Endpoint", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, searchURI, http.StatusOK, nil, nil, "", json.RawMessage(`[
+			{"zone":"b.com","domain":"api.b.com","type":"A"},
+			{"zone":"a.com","domain":"www.a.com","type":"A"},
+			{"zone":"a.com","domain":"www.a.com","type":"A"},
+			{"zone":"b.com","domain":"b.com","type":"MX"},
+			{"zone":"c.com"}
+		]`)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/a.com/www.a.com/A", http.StatusOK, nil, nil, "", www))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/b.com/b.com/MX", http.StatusOK, nil, nil, "", mx))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/b.com/api.b.com/A", http.StatusOK, nil, nil, "", api1))
+
+		found, _, err := client.Search.FindAnswersWithRData("1.2.3.4")
+		require.Nil(t, err)
+		require.Len(t, found, 2)
+		require.Equal(t, "a.com www.a.com A [1] 1.2.3.4", found[0].String())
+		require.Equal(t, "b.com api.b.com A [0] 1.2.3.4", found[1].String())
+	})
+
+	t.Run("Scan Fallback", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/search?max=1000&q=MAIL.b.com.&type=record", http.StatusNotFound, nil, nil, "",
+			`{"message": "not found"}`))
+		require.Nil(t, mock.AddZoneListTestCase(nil, nil, []*dns.Zone{{Zone: "a.com"}, {Zone: "b.com"}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/a.com", http.StatusOK, nil, nil, "", json.RawMessage(`{"zone":"a.com",
+			"records":[{"domain":"www.a.com","type":"A","short_answers":["5.6.7.8","1.2.3.4"]}]}`)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/b.com", http.StatusOK, nil, nil, "", json.RawMessage(`{"zone":"b.com",
+			"records":[{"domain":"b.com","type":"MX","short_answers":["10 mail.b.com"]},
+			{"domain":"api.b.com","type":"A","short_answers":["1.2.3.4"]}]}`)))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/b.com/b.com/MX", http.StatusOK, nil, nil, "", mx))
+
+		found, _, err := client.Search.FindAnswersWithRData("MAIL.b.com.")
+		require.Nil(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, "b.com", found[0].Domain)
+		require.Equal(t, []string{"10", "mail.b.com"}, found[0].Answer.Rdata)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/search?max=10&q=example&type=zone", http.StatusOK, nil, nil, "",
+			json.RawMessage(`[{"zone":"example.com","ttl":3600},{"zone":"example.net"}]`)))
+		results, _, err := client.Search.Search("example", api.SearchZone, api.SetIntParam("max", 10))
+		require.Nil(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "example.com", results[0].Zone)
+		require.False(t, results[0].IsRecord())
+		require.JSONEq(t, `{"zone":"example.com","ttl":3600}`, string(results[0].Raw))
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/search?q=1.2.3.4&type=all", http.StatusOK, nil, nil, "",
+			json.RawMessage(`[{"zone":"a.com","domain":"www.a.com","type":"A"}]`)))
+		results, _, err = client.Search.Search("1.2.3.4", api.SearchAll)
+		require.Nil(t, err)
+		require.Len(t, results, 1)
+		require.True(t, results[0].IsRecord())
+		require.Equal(t, "www.a.com", results[0].Domain)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, searchURI, http.StatusInternalServerError, nil, nil, "",
+			`{"message": "internal error"}`))
+		_, _, err := client.Search.FindAnswersWithRData("1.2.3.4")
+		require.NotNil(t, err)
+	})
+}
@@ -3,11 +3,17 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
 )
 
-const statsQPSEndpoint = "stats/qps"
+const (
+	statsQPSEndpoint   = "stats/qps"
+	statsUsageEndpoint = "stats/usage"
+)
 
-// StatsService handles 'stats/qps' endpoint.
+// StatsService handles 'stats/qps' and 'stats/usage' endpoints.
 type StatsService service
 
 // GetQPS returns current queries per second (QPS) for the account.
@@ -61,3 +67,61 @@ func (s *StatsService) getQPS(path string) (float32, *http.Response, error) {
 	}
 	return qps, resp, nil
 }
+
+// GetUsage returns the query usage of the account. The period defaults to
+// 24h and can be set with SetStringParam("period", account.UsagePeriod1h);
+// SetBoolParam("expand", true) breaks the usage down by zone, and
+// SetBoolParam("aggregate", true) sums it into a single Usage.
+//
+// NS1 API docs: https://ns1.com/api/#usage-get
+func (s *StatsService) GetUsage(opts ...func(*url.Values)) ([]*account.Usage, *http.Response, error) {
+	return s.getUsage(statsUsageEndpoint, opts)
+}
+
+// GetZoneUsage returns the query usage of a specific zone.
+//
+// NS1 API docs: https://ns1.com/api/#usage-get
+func (s *StatsService) GetZoneUsage(zone string, opts ...func(*url.Values)) ([]*account.Usage, *http.Response, error) {
+	path := fmt.Sprintf("%s/%s", statsUsageEndpoint, zone)
+	return s.getUsage(path, opts)
+}
+
+// GetRecordUsage returns the query usage of a specific record.
+//
+// NS1 API docs: https://ns1.com/api/#usage-get
+func (s *StatsService) GetRecordUsage(zone, record, t string, opts ...func(*url.Values)) ([]*account.Usage, *http.Response, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", statsUsageEndpoint, zone, record, t)
+	return s.getUsage(path, opts)
+}
+
+func (s *StatsService) getUsage(path string, opts []func(*url.Values)) ([]*account.Usage, *http.Response, error) {
+	v := url.Values{}
+	for _, opt := range opts {
+		opt(&v)
+	}
+	if len(v) > 0 {
+		path = fmt.Sprintf("%s?%s", path, v.Encode())
+	}
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var u []*account.Usage
+	resp, err := s.client.Do(req, &u)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			switch err.(*Error).Message {
+			case "zone not found":
+				return nil, resp, ErrZoneMissing
+			case "record not found":
+				return nil, resp, ErrRecordMissing
+			}
+		}
+		return nil, resp, err
+	}
+
+	return u, resp, nil
+}
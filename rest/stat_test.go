@@ -0,0 +1,55 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsUsage(t *testing.T) {
+	var gotURI string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/stats/usage/gone.com":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`)) // nolint: errcheck
+		case "/account/plan":
+			w.Write([]byte(`{"type": "managed", "period": "monthly", "included": {"queries": 1000000}}`)) // nolint: errcheck
+		default:
+			w.Write([]byte(`[{"zone": "example.com", "period": "24h", "queries": 42, "graph": [[1500000000, 40], [1500003600, 2]]}]`)) // nolint: errcheck
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	u, _, err := c.Stats.GetZoneUsage("example.com", SetStringParam("period", "24h"))
+	require.Nil(t, err)
+	assert.Equal(t, "/stats/usage/example.com?period=24h", gotURI)
+	require.Len(t, u, 1)
+	assert.Equal(t, int64(42), u[0].Queries)
+	require.Len(t, u[0].Graph, 2)
+	assert.Equal(t, int64(1500003600), u[0].Graph[1].Time.Unix())
+	assert.Equal(t, int64(2), u[0].Graph[1].Queries)
+
+	_, _, err = c.Stats.GetUsage()
+	require.Nil(t, err)
+	assert.Equal(t, "/stats/usage", gotURI)
+
+	_, _, err = c.Stats.GetRecordUsage("example.com", "www.example.com", "A", SetBoolParam("expand", true))
+	require.Nil(t, err)
+	assert.Equal(t, "/stats/usage/example.com/www.example.com/A?expand=true", gotURI)
+
+	_, _, err = c.Stats.GetZoneUsage("gone.com")
+	assert.Equal(t, ErrZoneMissing, err)
+
+	p, _, err := c.Plan.Get()
+	require.Nil(t, err)
+	assert.Equal(t, "managed", p.Type)
+	assert.Equal(t, float64(1000000), p.Included["queries"])
+}
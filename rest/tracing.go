@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Tracer is implemented by tracing integrations to get a span for each API
+// call made with Do, covering its rate limiting, retries and decoding. The
+// client takes no tracing dependency; an OpenTelemetry Tracer is a few
+// lines, e.g.
+//
+//	func (t otelTracer) StartCall(ctx context.Context, call rest.CallInfo) (context.Context, func(rest.CallResult)) {
+//		ctx, span := t.tracer.Start(ctx, "ns1 "+call.Method+" "+call.Route)
+//		return ctx, func(res rest.CallResult) {
+//			span.SetAttributes(attribute.Int("http.status_code", res.StatusCode), attribute.Int("ns1.retries", res.Retries))
+//			if res.Err != nil {
+//				span.RecordError(res.Err)
+//			}
+//			span.End()
+//		}
+//	}
+//
+// The context StartCall returns is the one the requests are sent with, so
+// spans of an instrumented Doer nest under the call's. Latency and rate
+// limit metrics are available from Client.MetricsHandler, or can be
+// recorded in the end function.
+type Tracer interface {
+	StartCall(ctx context.Context, call CallInfo) (context.Context, func(CallResult))
+}
+
+// CallInfo describes an API call to a Tracer.
+type CallInfo struct {
+	Method string
+	// Route is the request path relative to the endpoint with the names
+	// and ids replaced by "{id}", e.g. "zones/{id}/{id}/{id}" for a
+	// record, so that it is fit to name spans and label metrics.
+	Route string
+	URL   *url.URL
+}
+
+// CallResult is the outcome of an API call, for a Tracer.
+type CallResult struct {
+	// StatusCode of the last response, 0 if there was none.
+	StatusCode int
+	// Retries is the number of attempts after the first one.
+	Retries int
+	// RateLimit reported by the last response, if any.
+	RateLimit RateLimit
+	Err       error
+}
+
+// SetTracer sets a Tracer that gets a span for each call of Do. A nil
+// Tracer removes it.
+func SetTracer(t Tracer) func(*Client) {
+	return func(c *Client) { c.tracer = t }
+}
+
+// attemptsKey is the context key of the attempt counter of a traced call.
+type attemptsKey struct{}
+
+// countAttempt increments the attempt counter of a traced call, if any.
+func countAttempt(req *http.Request) {
+	if n, ok := req.Context().Value(attemptsKey{}).(*int32); ok {
+		atomic.AddInt32(n, 1)
+	}
+}
+
+// traced is send within a span of the client's Tracer.
+func (c Client) traced(req *http.Request, v interface{}) (*http.Response, error) {
+	t := c.tracer
+	c.tracer = nil
+
+	ctx, end := t.StartCall(req.Context(), CallInfo{Method: req.Method, Route: c.route(req.URL), URL: req.URL})
+	attempts := new(int32)
+	ctx = context.WithValue(ctx, attemptsKey{}, attempts)
+	resp, err := c.send(req.WithContext(ctx), v)
+
+	res := CallResult{Err: err}
+	if n := atomic.LoadInt32(attempts); n > 1 {
+		res.Retries = int(n) - 1
+	}
+	if resp != nil {
+		res.StatusCode = resp.StatusCode
+		res.RateLimit = parseRate(resp)
+	}
+	end(res)
+	return resp, err
+}
+
+// routeWord matches the path segments kept as is in a route: the lowercase
+// names of the API's collections and actions.
+var routeWord = regexp.MustCompile(`^[a-z][a-z_-]*$`)
+
+// route returns the route template of u, see CallInfo.
+func (c Client) route(u *url.URL) string {
+	path := u.Path
+	if c.Endpoint != nil {
+		path = strings.TrimPrefix(path, c.Endpoint.Path)
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if !routeWord.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+type recordingTracer struct {
+	calls   []CallInfo
+	results []CallResult
+	inSpan  []bool
+}
+
+func (t *recordingTracer) StartCall(ctx context.Context, call CallInfo) (context.Context, func(CallResult)) {
+	t.calls = append(t.calls, call)
+	return context.WithValue(ctx, spanKey{}, true), func(res CallResult) {
+		t.results = append(t.results, res)
+	}
+}
+
+func TestClient_SetTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set(headerRateLimit, "10")
+		w.Header().Set(headerRateRemaining, "7")
+		w.Header().Set(headerRatePeriod, "1")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	doer := DoerFunc(func(r *http.Request) (*http.Response, error) {
+		_, ok := r.Context().Value(spanKey{}).(bool)
+		tracer.inSpan = append(tracer.inSpan, ok)
+		return http.DefaultClient.Do(r)
+	})
+	client := NewClient(doer, SetEndpoint(ts.URL+"/v1/"), SetTracer(tracer),
+		SetRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}))
+
+	req, err := client.NewRequest("GET", "zones/example.com/www.example.com/A", nil)
+	require.Nil(t, err)
+	_, err = client.Do(req, nil)
+	require.Nil(t, err)
+
+	require.Len(t, tracer.calls, 1)
+	assert.Equal(t, "GET", tracer.calls[0].Method)
+	assert.Equal(t, "zones/{id}/{id}/{id}", tracer.calls[0].Route)
+	require.Len(t, tracer.results, 1)
+	assert.Equal(t, CallResult{
+		StatusCode: http.StatusOK,
+		Retries:    1,
+		RateLimit:  RateLimit{Limit: 10, Remaining: 7, Period: 1},
+	}, tracer.results[0])
+	assert.Equal(t, []bool{true, true}, tracer.inSpan)
+
+	SetTracer(nil)(client)
+	req, _ = client.NewRequest("GET", "zones", nil)
+	_, err = client.Do(req, nil)
+	require.Nil(t, err)
+	assert.Len(t, tracer.calls, 1)
+}
+
+func TestClient_route(t *testing.T) {
+	client := NewClient(nil, SetEndpoint("https://api.nsone.net/v1/"))
+	for path, want := range map[string]string{
+		"/v1/zones": "zones",
+		"/v1/data/sources/5b8c6e360e7c4fa5a4f6c5c0":            "data/sources/{id}",
+		"/v1/monitoring/jobs/52a27d4397d5f07003fdbe7b/history": "monitoring/jobs/{id}/history",
+		"/v1/account/apikeys/":                                 "account/apikeys",
+		"/v1/filtertypes":                                      "filtertypes",
+	} {
+		assert.Equal(t, want, client.route(&url.URL{Path: path}), path)
+	}
+}
@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrUnsupportedDoer is returned by the transport setters (SetForceHTTP1,
+// SetDialContext, SetTLSConfig and the like) when the client's Doer is not
+// an *http.Client whose Transport is nil or an *http.Transport. Wrapping
+// Doers hide the transport from the client; configure it directly before
+// passing them in instead.
+var ErrUnsupportedDoer = errors.New("http client must be an *http.Client with an *http.Transport")
+
+// configureTransport applies fn to a copy of the client's *http.Transport and
+// installs the copy, so transports shared with other clients (including
+// http.DefaultTransport) are never modified. The *http.Client is copied as
+// well, so http.DefaultClient is left alone too.
+func (c *Client) configureTransport(fn func(*http.Transport)) error {
+	c.lock()
+	defer c.unlock()
+	hc, ok := c.httpClient.(*http.Client)
+	if !ok {
+		return ErrUnsupportedDoer
+	}
+
+	var t *http.Transport
+	switch rt := hc.Transport.(type) {
+	case nil:
+		t = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		t = rt.Clone()
+	default:
+		return ErrUnsupportedDoer
+	}
+	fn(t)
+
+	copied := *hc
+	copied.Transport = t
+	c.httpClient = &copied
+	return nil
+}
+
+// SetForceHTTP1 restricts the client to HTTP/1.1 when force is true, e.g. to
+// work around proxies with broken HTTP/2 support, and restores the default
+// HTTP/2 negotiation when false.
+//
+// Only an *http.Client Doer (including the default used by NewClient) with a
+// nil or *http.Transport Transport can be configured; ErrUnsupportedDoer is
+// returned for any other Doer. Idle connections of the previous transport
+// are not reused.
+func (c *Client) SetForceHTTP1(force bool) error {
+	return c.configureTransport(func(t *http.Transport) {
+		t.ForceAttemptHTTP2 = !force
+		if !force {
+			t.TLSNextProto = nil
+			return
+		}
+
+		// A non-nil, empty TLSNextProto disables HTTP/2. ALPN must not offer
+		// h2 either, or servers may pick a protocol the transport can't speak.
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		if t.TLSClientConfig != nil {
+			protos := make([]string, 0, len(t.TLSClientConfig.NextProtos))
+			for _, p := range t.TLSClientConfig.NextProtos {
+				if p != "h2" {
+					protos = append(protos, p)
+				}
+			}
+			t.TLSClientConfig.NextProtos = protos
+		}
+	})
+}
+
+// SetDialContext makes the client open its connections with dial, e.g. a
+// net.Dialer bound to a local address so requests originate from the
+// interface whose IP is allowlisted for the account:
+//
+//	d := &net.Dialer{LocalAddr: &net.TCPAddr{IP: net.ParseIP("192.0.2.10")}}
+//	err := client.SetDialContext(d.DialContext)
+//
+// As with SetForceHTTP1, only an *http.Client Doer with a nil or
+// *http.Transport Transport can be configured, and ErrUnsupportedDoer is
+// returned otherwise. A nil dial restores the default dialer.
+func (c *Client) SetDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) error {
+	return c.configureTransport(func(t *http.Transport) {
+		if dial == nil {
+			dial = http.DefaultTransport.(*http.Transport).DialContext
+		}
+		t.DialContext = dial
+	})
+}
+
+// SetTLSConfig makes the client's connections use a copy of cfg, e.g. with
+// the client certificate or the CA pool of a private deployment. A nil cfg
+// restores the default TLS configuration. As with SetForceHTTP1, only an
+// *http.Client Doer with a nil or *http.Transport Transport can be
+// configured, and ErrUnsupportedDoer is returned otherwise.
+func (c *Client) SetTLSConfig(cfg *tls.Config) error {
+	return c.configureTransport(func(t *http.Transport) {
+		if cfg != nil {
+			cfg = cfg.Clone()
+		}
+		t.TLSClientConfig = cfg
+	})
+}
+
+// SetRootCAs makes the client verify the server certificates against pool
+// rather than the system roots, e.g. for a private deployment with
+// certificates from an internal CA. The rest of the TLS configuration is
+// kept. See SetTLSConfig for the Doers that can be configured.
+func (c *Client) SetRootCAs(pool *x509.CertPool) error {
+	return c.configureTLS(func(cfg *tls.Config) { cfg.RootCAs = pool })
+}
+
+// SetInsecureSkipVerify disables the verification of the server certificate
+// when skip is true, e.g. for a lab deployment with a self-signed
+// certificate. It leaves the connections open to interception, so prefer
+// SetRootCAs with the deployment's certificate. See SetTLSConfig for the
+// Doers that can be configured.
+func (c *Client) SetInsecureSkipVerify(skip bool) error {
+	return c.configureTLS(func(cfg *tls.Config) { cfg.InsecureSkipVerify = skip })
+}
+
+// configureTLS applies fn to a copy of the TLS configuration of the client's
+// transport.
+func (c *Client) configureTLS(fn func(*tls.Config)) error {
+	return c.configureTransport(func(t *http.Transport) {
+		cfg := &tls.Config{}
+		if t.TLSClientConfig != nil {
+			cfg = t.TLSClientConfig.Clone()
+		}
+		fn(cfg)
+		t.TLSClientConfig = cfg
+	})
+}
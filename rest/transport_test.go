@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetForceHTTP1(t *testing.T) {
+	var proto int
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.ProtoMajor
+		w.Write([]byte(`{}`))
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	get := func(c *Client) {
+		req, err := c.NewRequest("GET", "zones", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		require.Nil(t, err)
+	}
+
+	c := NewClient(ts.Client(), SetEndpoint(ts.URL+"/"))
+	get(c)
+	assert.Equal(t, 2, proto)
+
+	require.Nil(t, c.SetForceHTTP1(true))
+	get(c)
+	assert.Equal(t, 1, proto)
+
+	require.Nil(t, c.SetForceHTTP1(false))
+	get(c)
+	assert.Equal(t, 2, proto)
+
+	t.Run("default client", func(t *testing.T) {
+		c := NewClient(nil)
+		require.Nil(t, c.SetForceHTTP1(true))
+		assert.False(t, c.httpClient == http.DefaultClient)
+		assert.Nil(t, http.DefaultClient.Transport)
+		assert.True(t, http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2)
+	})
+
+	t.Run("unsupported doer", func(t *testing.T) {
+		c := NewClient(&mockHTTPClient{})
+		assert.Equal(t, ErrUnsupportedDoer, c.SetForceHTTP1(true))
+	})
+}
+
+func TestSetDialContext(t *testing.T) {
+	var remote string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote = r.RemoteAddr
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	var dials int
+	d := &net.Dialer{LocalAddr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}}
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials++
+		return d.DialContext(ctx, network, addr)
+	}
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+	require.Nil(t, c.SetDialContext(dial))
+	req, err := c.NewRequest("GET", "zones", nil)
+	require.Nil(t, err)
+	_, err = c.Do(req, nil)
+	require.Nil(t, err)
+	assert.Equal(t, 1, dials)
+	host, _, err := net.SplitHostPort(remote)
+	require.Nil(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+	assert.Nil(t, http.DefaultClient.Transport)
+
+	t.Run("unsupported doers", func(t *testing.T) {
+		c := NewClient(&mockHTTPClient{})
+		assert.Equal(t, ErrUnsupportedDoer, c.SetDialContext(dial))
+
+		c = NewClient(&http.Client{Transport: roundTripperFunc(nil)})
+		assert.Equal(t, ErrUnsupportedDoer, c.SetDialContext(dial))
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestSetTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	get := func(c *Client) error {
+		req, err := c.NewRequest("GET", "zones", nil)
+		require.Nil(t, err)
+		_, err = c.Do(req, nil)
+		return err
+	}
+
+	// The self-signed certificate isn't trusted by default.
+	c := NewClient(&http.Client{}, SetEndpoint(ts.URL+"/"))
+	assert.NotNil(t, get(c))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ts.Certificate())
+	require.Nil(t, c.SetRootCAs(pool))
+	assert.Nil(t, get(c))
+
+	c = NewClient(&http.Client{}, SetEndpoint(ts.URL+"/"))
+	require.Nil(t, c.SetInsecureSkipVerify(true))
+	assert.Nil(t, get(c))
+	require.Nil(t, c.SetInsecureSkipVerify(false))
+	assert.NotNil(t, get(c))
+
+	cfg := &tls.Config{RootCAs: pool}
+	require.Nil(t, c.SetTLSConfig(cfg))
+	assert.Nil(t, get(c))
+	cfg.RootCAs = nil // the client has its own copy
+	assert.Nil(t, get(c))
+	require.Nil(t, c.SetTLSConfig(nil))
+	assert.NotNil(t, get(c))
+
+	c = NewClient(&mockHTTPClient{})
+	assert.Equal(t, ErrUnsupportedDoer, c.SetRootCAs(pool))
+}
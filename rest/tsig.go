@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// TSIGService handles 'tsig' endpoint.
+type TSIGService service
+
+// List returns all TSIG keys of the account.
+//
+// NS1 API docs: https://ns1.com/api/#tsig-get
+func (s *TSIGService) List(opts ...RequestOption) ([]*dns.TSIGKey, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "tsig", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kl := []*dns.TSIGKey{}
+	resp, err := s.client.Do(req, &kl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return kl, resp, nil
+}
+
+// Get takes a TSIG key name and returns the key.
+//
+// NS1 API docs: https://ns1.com/api/#tsig-name-get
+func (s *TSIGService) Get(name string) (*dns.TSIGKey, *http.Response, error) {
+	path := fmt.Sprintf("tsig/%s", name)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var k dns.TSIGKey
+	resp, err := s.client.Do(req, &k)
+	if err != nil {
+		return nil, resp, tsigError(err)
+	}
+
+	return &k, resp, nil
+}
+
+// Create takes a *TSIGKey and creates a new TSIG key.
+//
+// NS1 API docs: https://ns1.com/api/#tsig-name-put
+func (s *TSIGService) Create(k *dns.TSIGKey) (*http.Response, error) {
+	path := fmt.Sprintf("tsig/%s", k.Name)
+
+	req, err := s.client.NewRequest("PUT", path, &k)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update key fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &k)
+	if err != nil {
+		return resp, tsigError(err)
+	}
+
+	return resp, nil
+}
+
+// Update takes a *TSIGKey and changes the algorithm or secret of the TSIG
+// key, e.g. to rotate its secret.
+//
+// NS1 API docs: https://ns1.com/api/#tsig-name-post
+func (s *TSIGService) Update(k *dns.TSIGKey) (*http.Response, error) {
+	path := fmt.Sprintf("tsig/%s", k.Name)
+
+	req, err := s.client.NewRequest("POST", path, &k)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update key fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &k)
+	if err != nil {
+		return resp, tsigError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes a TSIG key name and deletes the key.
+//
+// NS1 API docs: https://ns1.com/api/#tsig-name-delete
+func (s *TSIGService) Delete(name string) (*http.Response, error) {
+	path := fmt.Sprintf("tsig/%s", name)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, tsigError(err)
+	}
+
+	return resp, nil
+}
+
+// tsigError maps a 409 or 404 for a TSIG key to ErrTSIGKeyExists or
+// ErrTSIGKeyMissing.
+func tsigError(err error) error {
+	if e, ok := err.(*Error); ok {
+		switch e.statusCode() {
+		case http.StatusConflict:
+			return ErrTSIGKeyExists
+		case http.StatusNotFound:
+			return ErrTSIGKeyMissing
+		}
+	}
+	return err
+}
+
+var (
+	// ErrTSIGKeyExists bundles PUT create error.
+	ErrTSIGKeyExists = existsError("TSIG key already exists")
+	// ErrTSIGKeyMissing bundles GET/POST/DELETE error.
+	ErrTSIGKeyMissing = missingError("TSIG key does not exist")
+)
@@ -0,0 +1,65 @@
+package rest_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestTSIG(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	key := &dns.TSIGKey{Name: "xfr", Algorithm: "hmac-sha256", Secret: "c2VjcmV0"}
+
+	t.Run("List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/tsig", http.StatusOK, nil, nil, "", []*dns.TSIGKey{key}))
+
+		kl, _, err := client.TSIG.List()
+		require.Nil(t, err)
+		require.Equal(t, []*dns.TSIGKey{key}, kl)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/tsig/xfr", http.StatusOK, nil, nil, key, key))
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/tsig/dup", http.StatusConflict, nil, nil,
+			&dns.TSIGKey{Name: "dup"}, `{"message": "TSIG key already exists"}`))
+
+		_, err := client.TSIG.Create(&dns.TSIGKey{Name: "xfr", Algorithm: "hmac-sha256", Secret: "c2VjcmV0"})
+		require.Nil(t, err)
+
+		_, err = client.TSIG.Create(&dns.TSIGKey{Name: "dup"})
+		require.Equal(t, api.ErrTSIGKeyExists, err)
+		require.True(t, errors.Is(err, api.ErrAlreadyExists))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/tsig/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "TSIG key not found"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/tsig/gone", http.StatusNotFound, nil, nil,
+			&dns.TSIGKey{Name: "gone"}, `{"message": "TSIG key not found"}`))
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "/tsig/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "TSIG key not found"}`))
+
+		_, _, err := client.TSIG.Get("gone")
+		require.Equal(t, api.ErrTSIGKeyMissing, err)
+		_, err = client.TSIG.Update(&dns.TSIGKey{Name: "gone"})
+		require.Equal(t, api.ErrTSIGKeyMissing, err)
+		_, err = client.TSIG.Delete("gone")
+		require.Equal(t, api.ErrTSIGKeyMissing, err)
+	})
+}
@@ -1,9 +1,6 @@
 package rest
 
-import (
-	"log"
-	"net/http"
-)
+import "net/http"
 
 // DoerFunc satisfies Interface. DoerFuncs are useful for adding
 // logging/instrumentation to the http.Client that is used
@@ -30,9 +27,10 @@ func Decorate(d Doer, ds ...Decorator) Doer {
 	return decorated
 }
 
-// Logging returns a Decorator that logs a Doer's requests.
+// Logging returns a Decorator that logs a Doer's requests. Only the user
+// agent, method and URL are logged, never headers such as the API key.
 // Dependency injection for the logger instance(inside the closures environment).
-func Logging(l *log.Logger) Decorator {
+func Logging(l Logger) Decorator {
 	return func(d Doer) Doer {
 		return DoerFunc(func(r *http.Request) (*http.Response, error) {
 			l.Printf("%s: %s %s", r.UserAgent(), r.Method, r.URL)
@@ -40,3 +38,16 @@ func Logging(l *log.Logger) Decorator {
 		})
 	}
 }
+
+// Header returns a Decorator that sets a header on a Doer's requests, e.g.
+// a tracing or tenant header for use with SetMiddleware. The request is
+// copied rather than modified.
+func Header(key, value string) Decorator {
+	return func(d Doer) Doer {
+		return DoerFunc(func(r *http.Request) (*http.Response, error) {
+			r = r.Clone(r.Context())
+			r.Header.Set(key, value)
+			return d.Do(r)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// ViewsService handles the DDI 'views' endpoints.
+type ViewsService service
+
+// List returns all DNS views of the account.
+//
+// NS1 API docs: https://ns1.com/api#getlist-dns-views
+func (s *ViewsService) List(opts ...RequestOption) ([]*dns.View, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "views", nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vl := []*dns.View{}
+	resp, err := s.client.Do(req, &vl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vl, resp, nil
+}
+
+// Get takes a view name and returns the view.
+//
+// NS1 API docs: https://ns1.com/api#getview-dns-view-details
+func (s *ViewsService) Get(name string) (*dns.View, *http.Response, error) {
+	path := fmt.Sprintf("views/%s", name)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v dns.View
+	resp, err := s.client.Do(req, &v)
+	if err != nil {
+		return nil, resp, viewError(err)
+	}
+
+	return &v, resp, nil
+}
+
+// Create takes a *View and creates a new DNS view.
+//
+// NS1 API docs: https://ns1.com/api#putcreate-a-dns-view
+func (s *ViewsService) Create(v *dns.View) (*http.Response, error) {
+	path := fmt.Sprintf("views/%s", v.Name)
+
+	req, err := s.client.NewRequest("PUT", path, viewBody(v))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update view fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &v)
+	if err != nil {
+		return resp, viewError(err)
+	}
+
+	return resp, nil
+}
+
+// Update takes a *View and replaces the configuration of the DNS view.
+//
+// NS1 API docs: https://ns1.com/api#patchedit-a-dns-view
+func (s *ViewsService) Update(v *dns.View) (*http.Response, error) {
+	path := fmt.Sprintf("views/%s", v.Name)
+
+	req, err := s.client.NewRequest("PATCH", path, viewBody(v))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update view fields with data from api(ensure consistent)
+	resp, err := s.client.Do(req, &v)
+	if err != nil {
+		return resp, viewError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete takes a view name and deletes the DNS view.
+//
+// NS1 API docs: https://ns1.com/api#deletedelete-a-dns-view
+func (s *ViewsService) Delete(name string) (*http.Response, error) {
+	path := fmt.Sprintf("views/%s", name)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, viewError(err)
+	}
+
+	return resp, nil
+}
+
+// SetZones makes the view serve exactly the given zones. Only the zones list
+// is sent, and only if it changes; zones being added must exist, otherwise a
+// MultiError listing the missing ones is returned and nothing is changed.
+// The view is returned as read back.
+func (s *ViewsService) SetZones(viewName string, zones []string) (*dns.View, *http.Response, error) {
+	v, resp, err := s.Get(viewName)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.setZones(v, zones, resp)
+}
+
+// setZones is SetZones for the view v as just read, with response resp.
+func (s *ViewsService) setZones(v *dns.View, zones []string, resp *http.Response) (*dns.View, *http.Response, error) {
+	current := make(map[string]bool, len(v.Zones))
+	for _, z := range v.Zones {
+		current[z] = true
+	}
+	wanted := make(map[string]bool, len(zones))
+	var added []string
+	for _, z := range zones {
+		if !current[z] && !wanted[z] {
+			added = append(added, z)
+		}
+		wanted[z] = true
+	}
+	removed := false
+	for _, z := range v.Zones {
+		if !wanted[z] {
+			removed = true
+		}
+	}
+	if len(added) == 0 && !removed {
+		return v, resp, nil
+	}
+
+	if len(added) > 0 {
+		if resp, err := s.checkZones(added); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	names := make([]string, 0, len(wanted))
+	for z := range wanted {
+		names = append(names, z)
+	}
+	sort.Strings(names)
+
+	path := fmt.Sprintf("views/%s", v.Name)
+	req, err := s.client.NewRequest("PATCH", path, map[string][]string{"zones": names})
+	if err != nil {
+		return nil, nil, err
+	}
+	var updated dns.View
+	if resp, err = s.client.Do(req, &updated); err != nil {
+		return nil, resp, viewError(err)
+	}
+	return &updated, resp, nil
+}
+
+// AddZone makes the view serve zone as well, see SetZones.
+func (s *ViewsService) AddZone(viewName, zone string) (*dns.View, *http.Response, error) {
+	v, resp, err := s.Get(viewName)
+	if err != nil {
+		return nil, resp, err
+	}
+	return s.setZones(v, append(v.Zones, zone), resp)
+}
+
+// RemoveZone makes the view stop serving zone, see SetZones.
+func (s *ViewsService) RemoveZone(viewName, zone string) (*dns.View, *http.Response, error) {
+	v, resp, err := s.Get(viewName)
+	if err != nil {
+		return nil, resp, err
+	}
+	zones := make([]string, 0, len(v.Zones))
+	for _, z := range v.Zones {
+		if z != zone {
+			zones = append(zones, z)
+		}
+	}
+	return s.setZones(v, zones, resp)
+}
+
+// SetPreference changes only the view's preference, which decides the view
+// served to clients matched by the read ACLs of several views: the one with
+// the highest preference wins. The view is returned as read back.
+func (s *ViewsService) SetPreference(viewName string, preference int) (*dns.View, *http.Response, error) {
+	path := fmt.Sprintf("views/%s", viewName)
+	req, err := s.client.NewRequest("PATCH", path, map[string]int{"preference": preference})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v dns.View
+	resp, err := s.client.Do(req, &v)
+	if err != nil {
+		return nil, resp, viewError(err)
+	}
+	return &v, resp, nil
+}
+
+// ByPreference sorts views in the order NS1 matches them, from the highest
+// preference down, and returns the same slice.
+func ByPreference(views []*dns.View) []*dns.View {
+	sort.SliceStable(views, func(i, j int) bool { return views[i].Preference > views[j].Preference })
+	return views
+}
+
+// checkZones returns a MultiError of ErrZoneMissing errors for the zones the
+// account does not have.
+func (s *ViewsService) checkZones(zones []string) (*http.Response, error) {
+	existing, resp, err := s.client.Zones.List()
+	if err != nil {
+		return resp, err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, z := range existing {
+		known[z.Zone] = true
+	}
+
+	var errs MultiError
+	for _, z := range zones {
+		if !known[z] {
+			errs = append(errs, fmt.Errorf("zone %s: %w", z, ErrZoneMissing))
+		}
+	}
+	if len(errs) > 0 {
+		return resp, errs
+	}
+	return resp, nil
+}
+
+// viewBody returns the request body of a view: a copy without the
+// read-only timestamps, which a view read back from the API has set.
+func viewBody(v *dns.View) *dns.View {
+	w := *v
+	w.Created, w.Updated = nil, nil
+	return &w
+}
+
+// viewError maps API errors to ErrViewExists and ErrViewMissing.
+func viewError(err error) error {
+	if e, ok := err.(*Error); ok {
+		switch e.statusCode() {
+		case http.StatusConflict:
+			return ErrViewExists
+		case http.StatusNotFound:
+			return ErrViewMissing
+		}
+	}
+	return err
+}
+
+var (
+	// ErrViewExists bundles PUT create error.
+	ErrViewExists = existsError("view already exists")
+	// ErrViewMissing bundles GET/PATCH/DELETE error.
+	ErrViewMissing = missingError("view does not exist")
+)
@@ -0,0 +1,112 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestView(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	internal := json.RawMessage(`{"name":"internal","read_acls":["office"],"update_acls":[],
+		"zones":["a.com","b.com"],"networks":[0],"preference":10,"created_at":1600000000}`)
+	zones := []*dns.Zone{{Zone: "a.com"}, {Zone: "b.com"}, {Zone: "c.com"}}
+
+	t.Run("Get", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/views/internal", http.StatusOK, nil, nil, "", internal))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/views/gone", http.StatusNotFound, nil, nil, "",
+			`{"message": "view not found"}`))
+
+		v, _, err := client.Views.Get("internal")
+		require.Nil(t, err)
+		require.Equal(t, []string{"a.com", "b.com"}, v.Zones)
+		require.Equal(t, int64(1600000000), v.Created.Unix())
+
+		// The read-only timestamps are not sent back.
+		require.Nil(t, mock.AddTestCase(http.MethodPatch, "/views/internal", http.StatusOK, nil, nil,
+			json.RawMessage(`{"name":"internal","read_acls":["office"],"update_acls":[],
+				"zones":["a.com","b.com"],"networks":[0],"preference":10}`), internal))
+		_, err = client.Views.Update(v)
+		require.Nil(t, err)
+		require.Equal(t, int64(1600000000), v.Created.Unix())
+		require.Nil(t, v.Updated)
+
+		_, _, err = client.Views.Get("gone")
+		require.Equal(t, api.ErrViewMissing, err)
+		require.True(t, errors.Is(err, api.ErrNotFound))
+	})
+
+	t.Run("SetZones", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		updated := json.RawMessage(`{"name":"internal","zones":["b.com","c.com"]}`)
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/views/internal", http.StatusOK, nil, nil, "", internal))
+		require.Nil(t, mock.AddZoneListTestCase(nil, nil, zones))
+		require.Nil(t, mock.AddTestCase(http.MethodPatch, "/views/internal", http.StatusOK, nil, nil,
+			json.RawMessage(`{"zones":["b.com","c.com"]}`), updated))
+
+		v, _, err := client.Views.SetZones("internal", []string{"c.com", "b.com"})
+		require.Nil(t, err)
+		require.Equal(t, []string{"b.com", "c.com"}, v.Zones)
+
+		// An unchanged association is not written.
+		v, _, err = client.Views.SetZones("internal", []string{"b.com", "a.com"})
+		require.Nil(t, err)
+		require.Equal(t, []string{"a.com", "b.com"}, v.Zones)
+
+		// Adding unknown zones fails without writing.
+		_, _, err = client.Views.SetZones("internal", []string{"a.com", "x.com", "y.com"})
+		require.NotNil(t, err)
+		errs, ok := err.(api.MultiError)
+		require.True(t, ok)
+		require.Len(t, errs, 2)
+		require.True(t, errors.Is(errs[0], api.ErrZoneMissing))
+		require.Contains(t, errs[1].Error(), "y.com")
+	})
+
+	t.Run("AddZone and RemoveZone", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/views/internal", http.StatusOK, nil, nil, "", internal))
+		require.Nil(t, mock.AddZoneListTestCase(nil, nil, zones))
+		require.Nil(t, mock.AddTestCase(http.MethodPatch, "/views/internal", http.StatusOK, nil, nil,
+			json.RawMessage(`{"zones":["a.com","b.com","c.com"]}`), json.RawMessage(`{"name":"internal","zones":["a.com","b.com","c.com"]}`)))
+		require.Nil(t, mock.AddTestCase(http.MethodPatch, "/views/internal", http.StatusOK, nil, nil,
+			json.RawMessage(`{"zones":["b.com"]}`), json.RawMessage(`{"name":"internal","zones":["b.com"]}`)))
+
+		v, _, err := client.Views.AddZone("internal", "c.com")
+		require.Nil(t, err)
+		require.Len(t, v.Zones, 3)
+
+		v, _, err = client.Views.RemoveZone("internal", "a.com")
+		require.Nil(t, err)
+		require.Equal(t, []string{"b.com"}, v.Zones)
+	})
+
+	t.Run("SetPreference", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPatch, "/views/internal", http.StatusOK, nil, nil,
+			json.RawMessage(`{"preference":20}`), json.RawMessage(`{"name":"internal","preference":20}`)))
+
+		v, _, err := client.Views.SetPreference("internal", 20)
+		require.Nil(t, err)
+		require.Equal(t, 20, v.Preference)
+
+		views := api.ByPreference([]*dns.View{{Name: "external", Preference: 1}, v, {Name: "lab", Preference: 5}})
+		require.Equal(t, []string{"internal", "lab", "external"}, []string{views[0].Name, views[1].Name, views[2].Name})
+	})
+}
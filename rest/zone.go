@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,15 +13,15 @@ type ZonesService service
 // List returns all active zones and basic zone configuration details for each.
 //
 // NS1 API docs: https://ns1.com/api/#zones-get
-func (s *ZonesService) List() ([]*dns.Zone, *http.Response, error) {
-	req, err := s.client.NewRequest("GET", "zones", nil)
+func (s *ZonesService) List(opts ...RequestOption) ([]*dns.Zone, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "zones", nil, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	zl := []*dns.Zone{}
 	var resp *http.Response
-	if s.client.FollowPagination {
+	if s.client.snapshot().FollowPagination {
 		resp, err = s.client.DoWithPagination(req, &zl, s.nextZones)
 	} else {
 		resp, err = s.client.Do(req, &zl)
@@ -34,6 +33,25 @@ func (s *ZonesService) List() ([]*dns.Zone, *http.Response, error) {
 	return zl, resp, nil
 }
 
+// ListSecondaries returns the zones that are secondaries of an external
+// primary, with their Secondary block (primary address, transfer status and
+// last transfer, see ZoneSecondary.LastTransfer). The API has no filter for
+// these, so all zones are listed, with opts, and filtered client-side.
+func (s *ZonesService) ListSecondaries(opts ...RequestOption) ([]*dns.Zone, *http.Response, error) {
+	zl, resp, err := s.List(opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	secondaries := []*dns.Zone{}
+	for _, z := range zl {
+		if z.Secondary != nil && z.Secondary.Enabled {
+			secondaries = append(secondaries, z)
+		}
+	}
+	return secondaries, resp, nil
+}
+
 // Get takes a zone name and returns a single active zone and its basic configuration details.
 //
 // NS1 API docs: https://ns1.com/api/#zones-zone-get
@@ -47,7 +65,7 @@ func (s *ZonesService) Get(zone string) (*dns.Zone, *http.Response, error) {
 
 	var z dns.Zone
 	var resp *http.Response
-	if s.client.FollowPagination {
+	if s.client.snapshot().FollowPagination {
 		resp, err = s.client.DoWithPagination(req, &z, s.nextRecords)
 	} else {
 		resp, err = s.client.Do(req, &z)
@@ -182,7 +200,7 @@ func (s *ZonesService) nextRecords(v *interface{}, uri string) (*http.Response,
 
 var (
 	// ErrZoneExists bundles PUT create error.
-	ErrZoneExists = errors.New("zone already exists")
+	ErrZoneExists = existsError("zone already exists")
 	// ErrZoneMissing bundles GET/POST/DELETE error.
-	ErrZoneMissing = errors.New("zone does not exist")
+	ErrZoneMissing = missingError("zone does not exist")
 )
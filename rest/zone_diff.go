@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+const defaultZoneDiffParallelism = 4
+
+// ZoneDiffRecord identifies a record of a ZoneDiff by its name relative to
+// the zone apex ("@" for the apex itself) and its type.
+type ZoneDiffRecord struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (r ZoneDiffRecord) String() string {
+	return r.Name + " " + r.Type
+}
+
+// ZoneRecordDiff is a record present in both zones of a ZoneDiff, with the
+// fields that differ as reported by dns.DiffRecords, From being the value in
+// zone A and To the value in zone B.
+type ZoneRecordDiff struct {
+	ZoneDiffRecord
+	Fields []dns.FieldDiff `json:"fields"`
+}
+
+// ZoneDiff is the result of ZonesService.Diff. It marshals to JSON for
+// machine consumption, and String renders it as a human-readable report.
+type ZoneDiff struct {
+	ZoneA string `json:"zone_a"`
+	ZoneB string `json:"zone_b"`
+
+	OnlyInA []ZoneDiffRecord `json:"only_in_a"`
+	OnlyInB []ZoneDiffRecord `json:"only_in_b"`
+	Changed []ZoneRecordDiff `json:"changed"`
+}
+
+// Equal reports whether the zones have the same records.
+func (d *ZoneDiff) Equal() bool {
+	return len(d.OnlyInA) == 0 && len(d.OnlyInB) == 0 && len(d.Changed) == 0
+}
+
+func (d *ZoneDiff) String() string {
+	if d.Equal() {
+		return fmt.Sprintf("%s and %s have the same records", d.ZoneA, d.ZoneB)
+	}
+	lines := []string{fmt.Sprintf("--- %s\n+++ %s", d.ZoneA, d.ZoneB)}
+	for _, r := range d.OnlyInA {
+		lines = append(lines, fmt.Sprintf("- %s (only in %s)", r, d.ZoneA))
+	}
+	for _, r := range d.OnlyInB {
+		lines = append(lines, fmt.Sprintf("+ %s (only in %s)", r, d.ZoneB))
+	}
+	for _, r := range d.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s", r.ZoneDiffRecord))
+		for _, f := range r.Fields {
+			lines = append(lines, "    "+f.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares the records of two zones, e.g. staging and production
+// copies, and reports the records only in zoneA, only in zoneB, and the
+// records of both whose configuration differs. Records are matched by name
+// relative to each zone's apex and type; record fields are compared as by
+// dns.DiffRecords, so answers naming hosts in the zones themselves are
+// compared as is. Zone settings are not compared.
+//
+// Both zones and all their records are read, at most 4 records at a time;
+// the requests go through the client's rate limiting, so use the same
+// parallelism with RateLimitStrategyConcurrent. ctx applies to the record
+// reads.
+func (s *ZonesService) Diff(ctx context.Context, zoneA, zoneB string) (*ZoneDiff, error) {
+	a, err := s.diffRecords(ctx, zoneA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.diffRecords(ctx, zoneB)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ZoneDiff{
+		ZoneA:   zoneA,
+		ZoneB:   zoneB,
+		OnlyInA: []ZoneDiffRecord{},
+		OnlyInB: []ZoneDiffRecord{},
+		Changed: []ZoneRecordDiff{},
+	}
+	for k, ra := range a {
+		rb, ok := b[k]
+		if !ok {
+			d.OnlyInA = append(d.OnlyInA, k)
+			continue
+		}
+		if fields := dns.DiffRecords(ra, rb); len(fields) > 0 {
+			d.Changed = append(d.Changed, ZoneRecordDiff{ZoneDiffRecord: k, Fields: fields})
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			d.OnlyInB = append(d.OnlyInB, k)
+		}
+	}
+
+	less := func(x, y ZoneDiffRecord) bool {
+		if x.Name != y.Name {
+			return x.Name < y.Name
+		}
+		return x.Type < y.Type
+	}
+	sort.Slice(d.OnlyInA, func(i, j int) bool { return less(d.OnlyInA[i], d.OnlyInA[j]) })
+	sort.Slice(d.OnlyInB, func(i, j int) bool { return less(d.OnlyInB[i], d.OnlyInB[j]) })
+	sort.Slice(d.Changed, func(i, j int) bool { return less(d.Changed[i].ZoneDiffRecord, d.Changed[j].ZoneDiffRecord) })
+	return d, nil
+}
+
+// diffRecords reads all records of zone, keyed by relative name and type.
+func (s *ZonesService) diffRecords(ctx context.Context, zone string) (map[ZoneDiffRecord]*dns.Record, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	z, _, err := s.client.WithContext(ctx).Zones.Get(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*dns.Record, len(z.Records))
+	errs := make([]error, len(z.Records))
+	parallel(len(z.Records), defaultZoneDiffParallelism, func(i int) {
+		zr := z.Records[i]
+		records[i], _, errs[i] = s.client.Records.Get(zone, zr.Domain, zr.Type, withContext(ctx))
+	})
+
+	out := make(map[ZoneDiffRecord]*dns.Record, len(records))
+	for i, r := range records {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		k := ZoneDiffRecord{Name: relativeName(r.Domain, zone), Type: strings.ToUpper(r.Type)}
+		out[k] = r
+	}
+	return out, nil
+}
+
+// relativeName returns domain relative to the zone apex, "@" for the apex.
+func relativeName(domain, zone string) string {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if domain == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(domain, "."+zone)
+}
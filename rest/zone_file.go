@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// Import creates or replaces the records of a zone from a BIND zone file,
+// uploaded to NS1's zone file import, and returns the imported zone. The
+// zone is created if it does not exist yet.
+//
+// NS1 API docs: https://ns1.com/api/#import-zonefile-put
+func (s *ZonesService) Import(zone string, zonefile io.Reader) (*dns.Zone, *http.Response, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("zonefile", zone)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := io.Copy(part, zonefile); err != nil {
+		return nil, nil, err
+	}
+	if err := mw.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("import/zonefile/%s", zone)
+	req, err := s.client.NewRequest("PUT", path, bytes.NewReader(buf.Bytes()), WithHeader("Content-Type", mw.FormDataContentType()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var z dns.Zone
+	resp, err := s.client.Do(req, &z)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &z, resp, nil
+}
+
+// Export writes a zone and its records to w in BIND zone file format, see
+// dns.Zone.WriteZoneFile.
+func (s *ZonesService) Export(zone string, w io.Writer) (*http.Response, error) {
+	z, resp, err := s.Get(zone)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, z.WriteZoneFile(w)
+}
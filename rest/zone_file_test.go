@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+www	300	IN	A	1.2.3.4
+`
+
+func TestZonesService_ImportExport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/import/zonefile/example.com":
+			f, _, err := r.FormFile("zonefile")
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			b, err := ioutil.ReadAll(f)
+			assert.NoError(t, err)
+			assert.Equal(t, testZoneFile, string(b))
+			fallthrough
+		case r.URL.Path == "/zones/example.com":
+			w.Write([]byte(`{"zone": "example.com", "ttl": 3600, "records": [
+				{"domain": "www.example.com", "type": "A", "ttl": 300, "short_answers": ["1.2.3.4"]}
+			]}`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`)) // nolint: errcheck
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	z, _, err := c.Zones.Import("example.com", strings.NewReader(testZoneFile))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", z.Zone)
+
+	var buf bytes.Buffer
+	_, err = c.Zones.Export("example.com", &buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "www.example.com.\t300\tIN\tA\t1.2.3.4\n")
+
+	_, err = c.Zones.Export("gone.com", &buf)
+	assert.Equal(t, ErrZoneMissing, err)
+}
@@ -1,13 +1,19 @@
 package rest_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/ns1/ns1-go.v2/mockns1"
 	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
 
@@ -92,6 +98,106 @@ func TestZone(t *testing.T) {
 		})
 	})
 
+	t.Run("ListPages", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		base := "https://" + mock.Address + "/v1/"
+		first := http.Header{}
+		first.Set("Link", `<`+base+`zones?after=b.zone&limit=2>; rel="next"`)
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones?limit=2", http.StatusOK,
+			nil, first, "", []*dns.Zone{{Zone: "a.zone"}, {Zone: "b.zone"}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones?after=b.zone&limit=2", http.StatusOK,
+			nil, nil, "", []*dns.Zone{{Zone: "c.zone"}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones?after=x.zone", http.StatusInternalServerError,
+			nil, nil, "", `{"message": "boom"}`))
+
+		zl := client.Zones.ListPages(&api.ListOptions{Limit: 2})
+		var pages [][]string
+		var cursors []string
+		for zl.Next() {
+			var names []string
+			for _, z := range zl.Zones() {
+				names = append(names, z.Zone)
+			}
+			pages = append(pages, names)
+			cursors = append(cursors, zl.Cursor())
+		}
+		require.Nil(t, zl.Err())
+		require.Equal(t, [][]string{{"a.zone", "b.zone"}, {"c.zone"}}, pages)
+		require.Equal(t, []string{"b.zone", ""}, cursors)
+		require.False(t, zl.Next())
+
+		zl = client.Zones.ListPages(&api.ListOptions{After: "x.zone"})
+		require.False(t, zl.Next())
+		require.Contains(t, zl.Err().Error(), "boom")
+		require.Equal(t, http.StatusInternalServerError, zl.Response().StatusCode)
+		require.Nil(t, zl.Zones())
+	})
+
+	t.Run("ListOptions", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones?limit=10&name=example&offset=20", http.StatusOK,
+			nil, nil, "", []*dns.Zone{{Zone: "example.com"}}))
+
+		opts := &api.ListOptions{Limit: 10, Offset: 20}
+		zones, _, err := client.Zones.List(opts.Option(), api.WithParam("name", "example"))
+		require.Nil(t, err)
+		require.Equal(t, 1, len(zones))
+		require.Equal(t, "example.com", zones[0].Zone)
+	})
+
+	t.Run("RecordPages", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		base := "https://" + mock.Address + "/v1/"
+		first := http.Header{}
+		first.Set("Link", `<`+base+`zones/paged.zone?after=www&limit=1>; rel="next"`)
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/paged.zone?limit=1", http.StatusOK,
+			nil, first, "", &dns.Zone{Zone: "paged.zone", Records: []*dns.ZoneRecord{{Domain: "a.paged.zone", Type: "A"}}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/paged.zone?after=www&limit=1", http.StatusOK,
+			nil, nil, "", &dns.Zone{Zone: "paged.zone", Records: []*dns.ZoneRecord{{Domain: "www.paged.zone", Type: "CNAME"}}}))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/gone.zone", http.StatusNotFound,
+			nil, nil, "", `{"message": "zone not found"}`))
+
+		rl := client.Zones.RecordPages("paged.zone", &api.ListOptions{Limit: 1})
+		var domains []string
+		for rl.Next() {
+			require.Equal(t, "paged.zone", rl.Zone().Zone)
+			for _, r := range rl.Records() {
+				domains = append(domains, r.Domain)
+			}
+		}
+		require.Nil(t, rl.Err())
+		require.Equal(t, []string{"a.paged.zone", "www.paged.zone"}, domains)
+
+		rl = client.Zones.RecordPages("gone.zone", nil)
+		require.False(t, rl.Next())
+		require.Equal(t, api.ErrZoneMissing, rl.Err())
+	})
+
+	t.Run("ListSecondaries", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		lastXfr := model.Unix(1600000000)
+		zones := []*dns.Zone{
+			{Zone: "primary.zone"},
+			{Zone: "secondary.zone", Secondary: &dns.ZoneSecondary{
+				Enabled: true, PrimaryIP: "192.0.2.1", Status: "pending", LastXfr: &lastXfr,
+			}},
+			{Zone: "disabled.zone", Secondary: &dns.ZoneSecondary{}},
+		}
+		require.Nil(t, mock.AddZoneListTestCase(nil, nil, zones))
+
+		secondaries, _, err := client.Zones.ListSecondaries()
+		require.Nil(t, err)
+		require.Len(t, secondaries, 1)
+		require.Equal(t, "secondary.zone", secondaries[0].Zone)
+		require.Equal(t, "pending", secondaries[0].Secondary.Status)
+		require.Equal(t, time.Unix(1600000000, 0), secondaries[0].Secondary.LastTransfer())
+		require.True(t, zones[2].Secondary.LastTransfer().IsZero())
+	})
+
 	t.Run("Get", func(t *testing.T) {
 		zoneName := "a.get.zone"
 
@@ -260,3 +366,69 @@ type errorClient struct{}
 func (c errorClient) Do(req *http.Request) (*http.Response, error) {
 	return nil, errors.New("oops")
 }
+
+func TestZoneDiff(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/staging.example.com", http.StatusOK, nil, nil, "",
+		json.RawMessage(`{"zone":"staging.example.com","records":[
+			{"domain":"staging.example.com","type":"A"},
+			{"domain":"www.staging.example.com","type":"A"},
+			{"domain":"beta.staging.example.com","type":"A"}
+		]}`)))
+	require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com", http.StatusOK, nil, nil, "",
+		json.RawMessage(`{"zone":"example.com","records":[
+			{"domain":"example.com","type":"A"},
+			{"domain":"www.example.com","type":"A"},
+			{"domain":"mail.example.com","type":"MX"}
+		]}`)))
+	records := map[string]string{
+		"/zones/staging.example.com/staging.example.com/A":      `{"id":"s1","ttl":300,"answers":[{"answer":["1.2.3.4"]}]}`,
+		"/zones/staging.example.com/www.staging.example.com/A":  `{"id":"s2","ttl":60,"answers":[{"answer":["1.2.3.5"]}]}`,
+		"/zones/staging.example.com/beta.staging.example.com/A": `{"id":"s3","ttl":60,"answers":[{"answer":["1.2.3.6"]}]}`,
+		"/zones/example.com/example.com/A":                      `{"id":"p1","ttl":300,"answers":[{"answer":["1.2.3.4"]}]}`,
+		"/zones/example.com/www.example.com/A":                  `{"id":"p2","ttl":3600,"answers":[{"answer":["1.2.3.5"]}]}`,
+		"/zones/example.com/mail.example.com/MX":                `{"id":"p3","ttl":3600,"answers":[{"answer":["10","mx.example.com"]}]}`,
+	}
+	for uri, body := range records {
+		parts := strings.Split(uri, "/")
+		rec := fmt.Sprintf(`{"zone":%q,"domain":%q,"type":%q,%s`, parts[2], parts[3], parts[4], body[1:])
+		require.Nil(t, mock.AddTestCase(http.MethodGet, uri, http.StatusOK, nil, nil, "", json.RawMessage(rec)))
+	}
+
+	diff, err := client.Zones.Diff(context.Background(), "staging.example.com", "example.com")
+	require.Nil(t, err)
+	require.False(t, diff.Equal())
+	require.Equal(t, []api.ZoneDiffRecord{{Name: "beta", Type: "A"}}, diff.OnlyInA)
+	require.Equal(t, []api.ZoneDiffRecord{{Name: "mail", Type: "MX"}}, diff.OnlyInB)
+	require.Equal(t, []api.ZoneRecordDiff{{
+		ZoneDiffRecord: api.ZoneDiffRecord{Name: "www", Type: "A"},
+		Fields:         []dns.FieldDiff{{Field: "ttl", From: float64(60), To: float64(3600)}},
+	}}, diff.Changed)
+	require.Equal(t, `--- staging.example.com
++++ example.com
+- beta A (only in staging.example.com)
++ mail MX (only in example.com)
+~ www A
+    ttl: 60 -> 3600`, diff.String())
+
+	same, err := client.Zones.Diff(context.Background(), "example.com", "example.com")
+	require.Nil(t, err)
+	require.True(t, same.Equal())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Zones.Diff(ctx, "staging.example.com", "example.com")
+	require.Equal(t, context.Canceled, err)
+
+	// The zones are read with the given context too.
+	c, unbound := unboundRequests(`{"zone":"example.com","records":[]}`)
+	ctx = context.WithValue(context.Background(), ctxKey{}, true)
+	_, err = c.Zones.Diff(ctx, "staging.example.com", "example.com")
+	require.Nil(t, err)
+	require.Empty(t, *unbound)
+}
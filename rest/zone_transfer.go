@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// Transfer asks NS1 to transfer a secondary zone from its primary now,
+// rather than waiting for the zone's refresh interval or a NOTIFY. The
+// transfer happens asynchronously; see TransferStatus for its outcome.
+//
+// NS1 API docs: https://ns1.com/api/#zones-transfer-post
+func (s *ZonesService) Transfer(zone string) (*http.Response, error) {
+	path := fmt.Sprintf("zones/%s/transfer", zone)
+
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		switch err.(type) {
+		case *Error:
+			if err.(*Error).Message == "zone not found" {
+				return resp, ErrZoneMissing
+			}
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// TransferStatus returns the Secondary block of a secondary zone, holding
+// the status and error of its last transfer (see ZoneSecondary.LastTransfer)
+// along with its primary and TSIG configuration. An error is returned for
+// zones that are not secondaries.
+func (s *ZonesService) TransferStatus(zone string) (*dns.ZoneSecondary, *http.Response, error) {
+	z, resp, err := s.Get(zone)
+	if err != nil {
+		return nil, resp, err
+	}
+	if z.Secondary == nil || !z.Secondary.Enabled {
+		return nil, resp, fmt.Errorf("zone %s is not a secondary zone", zone)
+	}
+
+	return z.Secondary, resp, nil
+}
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZonesService_Transfer(t *testing.T) {
+	var transfers int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/zones/secondary.com/transfer":
+			assert.Equal(t, "POST", r.Method)
+			transfers++
+		case "/zones/secondary.com":
+			w.Write([]byte(`{"zone": "secondary.com", "secondary": {
+				"enabled": true, "primary_ip": "192.0.2.1", "status": "pending", "error": null
+			}}`)) // nolint: errcheck
+		case "/zones/primary.com":
+			w.Write([]byte(`{"zone": "primary.com", "primary": {"enabled": true, "secondaries": []}}`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "zone not found"}`)) // nolint: errcheck
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil, SetEndpoint(ts.URL+"/"))
+
+	_, err := c.Zones.Transfer("secondary.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, transfers)
+
+	sec, _, err := c.Zones.TransferStatus("secondary.com")
+	require.NoError(t, err)
+	assert.Equal(t, "pending", sec.Status)
+	assert.Equal(t, "192.0.2.1", sec.PrimaryIP)
+
+	_, _, err = c.Zones.TransferStatus("primary.com")
+	assert.NotNil(t, err)
+
+	_, err = c.Zones.Transfer("gone.com")
+	assert.Equal(t, ErrZoneMissing, err)
+}
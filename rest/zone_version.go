@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// ZoneVersionsService handles 'zones/{zone}/versions' endpoint.
+type ZoneVersionsService service
+
+// List returns all versions of a zone.
+//
+// NS1 API docs: https://ns1.com/api/#versions-get
+func (s *ZoneVersionsService) List(zone string, opts ...RequestOption) ([]*dns.ZoneVersion, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s/versions", zone)
+
+	req, err := s.client.NewRequest("GET", path, nil, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vl := []*dns.ZoneVersion{}
+	resp, err := s.client.Do(req, &vl)
+	if err != nil {
+		return nil, resp, zoneVersionError(err)
+	}
+
+	return vl, resp, nil
+}
+
+// Create snapshots the current records of a zone as a new version, e.g.
+// before bulk edits, and returns it. The API refuses to create a version of
+// a zone with an inactive one pending unless force is set.
+//
+// NS1 API docs: https://ns1.com/api/#versions-put
+func (s *ZoneVersionsService) Create(zone string, force bool) (*dns.ZoneVersion, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s/versions", zone)
+
+	req, err := s.client.NewRequest("PUT", path, nil, WithParam("force", fmt.Sprint(force)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v dns.ZoneVersion
+	resp, err := s.client.Do(req, &v)
+	if err != nil {
+		return nil, resp, zoneVersionError(err)
+	}
+
+	return &v, resp, nil
+}
+
+// Preview returns a zone with the records of one of its versions, to check a
+// version before activating it.
+//
+// NS1 API docs: https://ns1.com/api/#versions-id-get
+func (s *ZoneVersionsService) Preview(zone string, id int) (*dns.Zone, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s/versions/%d", zone, id)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var z dns.Zone
+	resp, err := s.client.Do(req, &z)
+	if err != nil {
+		return nil, resp, zoneVersionError(err)
+	}
+
+	return &z, resp, nil
+}
+
+// Activate makes a version of a zone the served one, e.g. to roll back to
+// the version created before a failed deploy.
+//
+// NS1 API docs: https://ns1.com/api/#versions-id-activate-post
+func (s *ZoneVersionsService) Activate(zone string, id int) (*http.Response, error) {
+	path := fmt.Sprintf("zones/%s/versions/%d/activate", zone, id)
+
+	req, err := s.client.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, zoneVersionError(err)
+	}
+
+	return resp, nil
+}
+
+// Delete deletes an inactive version of a zone.
+//
+// NS1 API docs: https://ns1.com/api/#versions-id-delete
+func (s *ZoneVersionsService) Delete(zone string, id int) (*http.Response, error) {
+	path := fmt.Sprintf("zones/%s/versions/%d", zone, id)
+
+	req, err := s.client.NewRequest("DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	if err != nil {
+		return resp, zoneVersionError(err)
+	}
+
+	return resp, nil
+}
+
+func zoneVersionError(err error) error {
+	if e, ok := err.(*Error); ok && e.statusCode() == http.StatusNotFound {
+		if e.Message == "zone not found" {
+			return ErrZoneMissing
+		}
+		return ErrZoneVersionMissing
+	}
+	return err
+}
+
+var (
+	// ErrZoneVersionMissing bundles GET/POST/DELETE error.
+	ErrZoneVersionMissing = missingError("zone version does not exist")
+)
@@ -0,0 +1,82 @@
+package rest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/mockns1"
+	api "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestZoneVersions(t *testing.T) {
+	mock, doer, err := mockns1.New(t)
+	require.Nil(t, err)
+	defer mock.Shutdown()
+
+	client := api.NewClient(doer, api.SetEndpoint("https://"+mock.Address+"/v1/"))
+
+	t.Run("List", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/versions", http.StatusOK, nil, nil, "",
+			`[{"id": 1, "active": true, "created_at": 1600000000, "activated_at": 1600000100}, {"id": 2, "active": false, "created_at": 1600000200}]`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/missing.com/versions", http.StatusNotFound, nil, nil, "",
+			`{"message": "zone not found"}`))
+
+		versions, _, err := client.ZoneVersions.List("example.com")
+		require.Nil(t, err)
+		require.Equal(t, []*dns.ZoneVersion{
+			{ID: 1, Active: true, CreatedAt: model.NewTime(time.Unix(1600000000, 0)), ActivatedAt: model.NewTime(time.Unix(1600000100, 0))},
+			{ID: 2, CreatedAt: model.NewTime(time.Unix(1600000200, 0))},
+		}, versions)
+
+		_, _, err = client.ZoneVersions.List("missing.com")
+		require.Equal(t, api.ErrZoneMissing, err)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPut, "/zones/example.com/versions?force=true", http.StatusOK, nil, nil, "",
+			`{"id": 3, "active": false}`))
+		v, _, err := client.ZoneVersions.Create("example.com", true)
+		require.Nil(t, err)
+		require.Equal(t, 3, v.ID)
+	})
+
+	t.Run("Preview", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/versions/3", http.StatusOK, nil, nil, "",
+			`{"zone": "example.com", "records": [{"domain": "www.example.com", "type": "A"}]}`))
+		require.Nil(t, mock.AddTestCase(http.MethodGet, "/zones/example.com/versions/9", http.StatusNotFound, nil, nil, "",
+			`{"message": "version not found"}`))
+
+		z, _, err := client.ZoneVersions.Preview("example.com", 3)
+		require.Nil(t, err)
+		require.Len(t, z.Records, 1)
+
+		_, _, err = client.ZoneVersions.Preview("example.com", 9)
+		require.Equal(t, api.ErrZoneVersionMissing, err)
+	})
+
+	t.Run("Activate", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodPost, "/zones/example.com/versions/1/activate", http.StatusOK, nil, nil, "", ""))
+		_, err := client.ZoneVersions.Activate("example.com", 1)
+		require.Nil(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer mock.ClearTestCases()
+
+		require.Nil(t, mock.AddTestCase(http.MethodDelete, "/zones/example.com/versions/3", http.StatusNoContent, nil, nil, "", ""))
+		_, err := client.ZoneVersions.Delete("example.com", 3)
+		require.Nil(t, err)
+	})
+}